@@ -0,0 +1,246 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/albertocavalcante/lspls/fetch"
+	"github.com/albertocavalcante/lspls/generator"
+)
+
+// benchTypes is the fixed type set compiled for the codec benchmark. Their
+// dependencies are resolved, so the payloads below exercise nested
+// structures, unions, and enums.
+var benchTypes = []string{"Diagnostic", "HoverParams", "Location", "TextEdit"}
+
+// benchVariant is one set of Go generator options to benchmark.
+type benchVariant struct {
+	name    string
+	options map[string]string
+}
+
+// benchVariants are the codec variants compared by "lspls bench". The
+// stdlib variant is the default output; the others change how structures
+// are decoded.
+var benchVariants = []benchVariant{
+	{name: "stdlib"},
+	{name: "strict-required", options: map[string]string{"strict_required": "true"}},
+}
+
+// runBench implements the hidden "lspls bench" subcommand. It generates
+// each codec variant for benchTypes into its own package of a temporary
+// module, runs marshal/unmarshal benchmarks over sample payloads, and
+// prints ns/op per benchmark and variant.
+func runBench(args []string) error {
+	fs := flag.NewFlagSet("bench", flag.ContinueOnError)
+	lspVersion := fs.String("v", "", "LSP version or git ref (default: $"+fetch.RefEnv+", then "+fetch.DefaultRef+")")
+	specPath := fs.String("spec", "", "Path to local metaModel.json")
+	repoDir := fs.String("repo", "", "Path to local vscode-languageserver-node clone")
+	specRepo := fs.String("spec-repo", "", "Git remote to clone (default: "+fetch.VSCodeRepo+")")
+	benchtime := fs.String("benchtime", "1s", "Run time per benchmark (go test -benchtime)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		return fmt.Errorf("bench needs the go toolchain in PATH: %w", err)
+	}
+	gen, ok := generator.Get("go")
+	if !ok {
+		return fmt.Errorf("go generator not available")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	result, err := fetch.Fetch(ctx, fetch.Options{
+		Ref:       *lspVersion,
+		LocalPath: *specPath,
+		RepoDir:   *repoDir,
+		Repo:      *specRepo,
+		Timeout:   90 * time.Second,
+	})
+	if err != nil {
+		return fmt.Errorf("fetch specification: %w", err)
+	}
+
+	dir, err := os.MkdirTemp("", "lspls-bench-*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module lsplsbench\n\ngo 1.22\n"), 0o644); err != nil {
+		return err
+	}
+	for _, v := range benchVariants {
+		pkg := strings.ReplaceAll(v.name, "-", "")
+		cfg := generator.Config{
+			Types:       benchTypes,
+			ResolveDeps: true,
+			Options:     map[string]string{"package": pkg},
+		}
+		for k, val := range v.options {
+			cfg.Options[k] = val
+		}
+		out, err := gen.Generate(ctx, result.Model, cfg)
+		if err != nil {
+			return fmt.Errorf("generate %s: %w", v.name, err)
+		}
+		src, err := singleFile(out)
+		if err != nil {
+			return err
+		}
+		pkgDir := filepath.Join(dir, pkg)
+		if err := os.MkdirAll(pkgDir, 0o755); err != nil {
+			return err
+		}
+		files := map[string]string{
+			"protocol.go":   string(src),
+			"codec_test.go": "package " + pkg + "\n" + benchSource,
+		}
+		for name, content := range files {
+			if err := os.WriteFile(filepath.Join(pkgDir, name), []byte(content), 0o644); err != nil {
+				return err
+			}
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "benchmarking %s against %s\n", strings.Join(variantNames(), ", "), result.Source)
+
+	cmd := exec.CommandContext(ctx, goBin, "test", "-run", "^$", "-bench", ".", "-benchtime", *benchtime, "./...")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOWORK=off")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return fmt.Errorf("go test: %w\n%s%s", err, output, stderr.Bytes())
+	}
+
+	return writeBenchTable(os.Stdout, parseBenchOutput(output))
+}
+
+// parseBenchOutput extracts ns/op from go test -bench output, keyed by
+// benchmark name (without the GOMAXPROCS suffix) and then by package.
+func parseBenchOutput(output []byte) map[string]map[string]string {
+	results := make(map[string]map[string]string)
+	var pkg string
+	sc := bufio.NewScanner(bytes.NewReader(output))
+	for sc.Scan() {
+		fields := strings.Fields(sc.Text())
+		if len(fields) == 2 && fields[0] == "pkg:" {
+			pkg = filepath.Base(fields[1])
+			continue
+		}
+		if len(fields) < 4 || !strings.HasPrefix(fields[0], "Benchmark") || fields[3] != "ns/op" {
+			continue
+		}
+		name := strings.TrimPrefix(fields[0], "Benchmark")
+		if i := strings.LastIndex(name, "-"); i > 0 {
+			name = name[:i]
+		}
+		if results[name] == nil {
+			results[name] = make(map[string]string)
+		}
+		results[name][pkg] = fields[2]
+	}
+	return results
+}
+
+// writeBenchTable prints one row per benchmark with a ns/op column per
+// variant, in benchTypes order.
+func writeBenchTable(w io.Writer, results map[string]map[string]string) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintf(tw, "benchmark\t%s\t\n", strings.Join(variantNames(), " ns/op\t")+" ns/op")
+	for _, typ := range benchTypes {
+		for _, op := range []string{"Unmarshal", "Marshal"} {
+			name := typ + "/" + op
+			row := []string{name}
+			for _, v := range benchVariants {
+				ns := results[name][strings.ReplaceAll(v.name, "-", "")]
+				if ns == "" {
+					ns = "-"
+				}
+				row = append(row, ns)
+			}
+			fmt.Fprintf(tw, "%s\t\n", strings.Join(row, "\t"))
+		}
+	}
+	return tw.Flush()
+}
+
+func variantNames() []string {
+	names := make([]string, len(benchVariants))
+	for i, v := range benchVariants {
+		names[i] = v.name
+	}
+	return names
+}
+
+// benchSource is the benchmark file compiled into every variant package.
+// Payloads only use required and common optional properties so they decode
+// under every variant.
+const benchSource = `
+import (
+	"encoding/json"
+	"testing"
+)
+
+const (
+	diagnosticJSON = ` + "`" + `{"range":{"start":{"line":10,"character":4},"end":{"line":10,"character":12}},"severity":1,"code":"unused","source":"compiler","message":"x declared and not used","tags":[1],"relatedInformation":[{"location":{"uri":"file:///src/main.go","range":{"start":{"line":8,"character":1},"end":{"line":8,"character":2}}},"message":"declared here"}]}` + "`" + `
+	hoverParamsJSON = ` + "`" + `{"textDocument":{"uri":"file:///src/main.go"},"position":{"line":3,"character":7}}` + "`" + `
+	locationJSON    = ` + "`" + `{"uri":"file:///src/main.go","range":{"start":{"line":1,"character":0},"end":{"line":1,"character":9}}}` + "`" + `
+	textEditJSON    = ` + "`" + `{"range":{"start":{"line":4,"character":2},"end":{"line":4,"character":14}},"newText":"fmt.Println(x)"}` + "`" + `
+)
+
+func BenchmarkDiagnostic(b *testing.B)  { benchCodec[Diagnostic](b, diagnosticJSON) }
+func BenchmarkHoverParams(b *testing.B) { benchCodec[HoverParams](b, hoverParamsJSON) }
+func BenchmarkLocation(b *testing.B)    { benchCodec[Location](b, locationJSON) }
+func BenchmarkTextEdit(b *testing.B)    { benchCodec[TextEdit](b, textEditJSON) }
+
+func benchCodec[T any](b *testing.B, payload string) {
+	data := []byte(payload)
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		b.Fatal(err)
+	}
+
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetBytes(int64(len(data)))
+		for i := 0; i < b.N; i++ {
+			var v T
+			if err := json.Unmarshal(data, &v); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Marshal", func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetBytes(int64(len(data)))
+		for i := 0; i < b.N; i++ {
+			if _, err := json.Marshal(&v); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+`
@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// parseAcronyms returns the words of the comma-separated --acronyms flag.
+// Each must be letters and digits.
+func parseAcronyms(s string) ([]string, error) {
+	if s == "" {
+		return nil, nil
+	}
+	var words []string
+	for w := range strings.SplitSeq(s, ",") {
+		w = strings.TrimSpace(w)
+		if !isWord(w, false) {
+			return nil, fmt.Errorf("invalid --acronyms %q: want comma-separated words of letters and digits", s)
+		}
+		words = append(words, w)
+	}
+	return words, nil
+}
+
+// parseNameOverrides returns the Name=Ident pairs of the --name-override
+// flags as a map. Ident must be letters, digits, and "_".
+func parseNameOverrides(pairs []string) (map[string]string, error) {
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+	overrides := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		name, ident, ok := strings.Cut(pair, "=")
+		if !ok || name == "" || !isWord(ident, true) {
+			return nil, fmt.Errorf("invalid --name-override %q: want Name=Ident", pair)
+		}
+		overrides[name] = ident
+	}
+	return overrides, nil
+}
+
+// isWord reports whether s is a non-empty run of letters and digits, and
+// of "_" if underscore is set.
+func isWord(s string, underscore bool) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && (!underscore || r != '_') {
+			return false
+		}
+	}
+	return true
+}
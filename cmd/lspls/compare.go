@@ -0,0 +1,190 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/albertocavalcante/lspls/fetch"
+	"github.com/albertocavalcante/lspls/generator"
+	"github.com/albertocavalcante/lspls/model"
+)
+
+// coverage is what one generator reported while generating one type.
+type coverage struct {
+	degraded int // warnings about parts generated as the dynamic type
+	skipped  int // warnings about members left out
+	other    int // any other warnings
+	err      error
+}
+
+// String returns the table cell of c: "ok", or the counts of its warnings.
+func (c coverage) String() string {
+	if c.err != nil {
+		return "error"
+	}
+	var parts []string
+	if c.degraded > 0 {
+		parts = append(parts, fmt.Sprintf("%d degraded", c.degraded))
+	}
+	if c.skipped > 0 {
+		parts = append(parts, fmt.Sprintf("%d skipped", c.skipped))
+	}
+	if c.other > 0 {
+		parts = append(parts, fmt.Sprintf("%d warned", c.other))
+	}
+	if len(parts) == 0 {
+		return "ok"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// runCompareGenerators implements "lspls compare-generators". It generates
+// each type on its own with every registered generator, without its
+// dependencies, and prints a matrix of the parts each generator degraded
+// to its dynamic type or skipped, from the warnings the generators log.
+func runCompareGenerators(args []string) error {
+	fs := flag.NewFlagSet("compare-generators", flag.ContinueOnError)
+	types := fs.String("t", "", "Comma-separated types to compare (default: all)")
+	lspVersion := fs.String("v", "", "LSP version or git ref (default: $"+fetch.RefEnv+", then "+fetch.DefaultRef+")")
+	specPath := fs.String("spec", "", "Path to local metaModel.json")
+	repoDir := fs.String("repo", "", "Path to local vscode-languageserver-node clone")
+	specRepo := fs.String("spec-repo", "", "Git remote to clone (default: "+fetch.VSCodeRepo+")")
+	cacheDir := fs.String("cache-dir", "", "Directory for reusable clones")
+	proposed := fs.Bool("proposed", false, "Include proposed types and properties")
+	all := fs.Bool("all", false, "Print every type, not only those some generator degrades or skips")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	result, err := fetch.Fetch(ctx, fetch.Options{
+		Ref:       *lspVersion,
+		LocalPath: *specPath,
+		RepoDir:   *repoDir,
+		Repo:      *specRepo,
+		CacheDir:  *cacheDir,
+		Timeout:   90 * time.Second,
+	})
+	if err != nil {
+		return fmt.Errorf("fetch specification: %w", err)
+	}
+
+	var names []string
+	if *types != "" {
+		for name := range strings.SplitSeq(*types, ",") {
+			if name = strings.TrimSpace(name); name == "" {
+				continue
+			}
+			if !hasType(result.Model, name) {
+				return fmt.Errorf("unknown type: %s", name)
+			}
+			names = append(names, name)
+		}
+	} else {
+		names = typeNames(result.Model, *proposed)
+	}
+
+	gens := generator.List()
+	matrix := make(map[string][]coverage, len(names))
+	for _, name := range names {
+		for _, target := range gens {
+			gen, _ := generator.Get(target)
+			matrix[name] = append(matrix[name], compareOne(ctx, gen, result.Model, name, *proposed))
+		}
+	}
+	return writeCompareTable(os.Stdout, gens, names, matrix, *all)
+}
+
+// typeNames returns the names of the structures, enumerations, and type
+// aliases of m, leaving out proposed ones unless proposed is set.
+func typeNames(m *model.Model, proposed bool) []string {
+	var names []string
+	for _, s := range m.Structures {
+		if proposed || !s.Proposed {
+			names = append(names, s.Name)
+		}
+	}
+	for _, e := range m.Enumerations {
+		if proposed || !e.Proposed {
+			names = append(names, e.Name)
+		}
+	}
+	for _, a := range m.TypeAliases {
+		if proposed || !a.Proposed {
+			names = append(names, a.Name)
+		}
+	}
+	return names
+}
+
+// compareOne generates the named type alone with gen and classifies the
+// warnings it logs.
+func compareOne(ctx context.Context, gen generator.Generator, m *model.Model, name string, proposed bool) coverage {
+	var warnings generator.Warnings
+	cfg := generator.Config{
+		Types:           []string{name},
+		IncludeProposed: proposed,
+		Options:         make(map[string]string),
+		Logger:          slog.New(warnings.Handler(slog.DiscardHandler)),
+	}
+	var c coverage
+	if _, err := generate(ctx, gen, m, cfg); err != nil {
+		c.err = err
+		return c
+	}
+	for _, w := range warnings.List() {
+		switch {
+		case strings.Contains(w, "degraded"):
+			c.degraded++
+		case strings.Contains(w, "skipped"):
+			c.skipped++
+		default:
+			c.other++
+		}
+	}
+	return c
+}
+
+// writeCompareTable prints one row per type and one column per generator,
+// and a last row counting the types each generator does not fully cover.
+// Unless all is set, types every generator covers are left out.
+func writeCompareTable(w io.Writer, gens, names []string, matrix map[string][]coverage, all bool) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "type\t%s\n", strings.Join(gens, "\t"))
+	gaps := make([]int, len(gens))
+	for _, name := range names {
+		cells := make([]string, len(gens))
+		covered := true
+		for i, c := range matrix[name] {
+			cells[i] = c.String()
+			if cells[i] != "ok" {
+				covered = false
+				gaps[i]++
+			}
+		}
+		if all || !covered {
+			fmt.Fprintf(tw, "%s\t%s\n", name, strings.Join(cells, "\t"))
+		}
+	}
+	totals := make([]string, len(gens))
+	for i, n := range gaps {
+		totals[i] = fmt.Sprintf("%d/%d", n, len(names))
+	}
+	fmt.Fprintf(tw, "not covered\t%s\n", strings.Join(totals, "\t"))
+	return tw.Flush()
+}
@@ -0,0 +1,127 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/albertocavalcante/lspls/fetch"
+	"github.com/albertocavalcante/lspls/generator"
+	"github.com/albertocavalcante/lspls/model"
+)
+
+// runDeps implements "lspls deps". It prints the types that -t would
+// generate for the given roots, one per line, or with --graph the
+// dependency edges between them, as text or, with --dot, in Graphviz DOT.
+func runDeps(args []string) error {
+	fs := flag.NewFlagSet("deps", flag.ContinueOnError)
+	lspVersion := fs.String("v", "", "LSP version or git ref (default: $"+fetch.RefEnv+", then "+fetch.DefaultRef+")")
+	specPath := fs.String("spec", "", "Path to local metaModel.json")
+	repoDir := fs.String("repo", "", "Path to local vscode-languageserver-node clone")
+	specRepo := fs.String("spec-repo", "", "Git remote to clone (default: "+fetch.VSCodeRepo+")")
+	cacheDir := fs.String("cache-dir", "", "Directory for reusable clones")
+	proposed := fs.Bool("proposed", false, "Follow proposed properties")
+	graph := fs.Bool("graph", false, "Print the dependency edges instead of the type list")
+	dot := fs.Bool("dot", false, "Print the graph in Graphviz DOT (implies --graph)")
+
+	// Flags may follow the type list, as in "lspls deps Range --graph".
+	var roots []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return err
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		for name := range strings.SplitSeq(fs.Arg(0), ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				roots = append(roots, name)
+			}
+		}
+		args = fs.Args()[1:]
+	}
+	if len(roots) == 0 {
+		return errors.New("usage: lspls deps [flags] Type[,Type...]")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	result, err := fetch.Fetch(ctx, fetch.Options{
+		Ref:       *lspVersion,
+		LocalPath: *specPath,
+		RepoDir:   *repoDir,
+		Repo:      *specRepo,
+		CacheDir:  *cacheDir,
+		Timeout:   90 * time.Second,
+	})
+	if err != nil {
+		return fmt.Errorf("fetch specification: %w", err)
+	}
+	for _, name := range roots {
+		if !hasType(result.Model, name) {
+			return fmt.Errorf("unknown type: %s", name)
+		}
+	}
+
+	deps := generator.DepGraph(result.Model, roots, *proposed)
+	switch {
+	case *dot:
+		writeDepGraphDOT(os.Stdout, deps)
+	case *graph:
+		writeDepGraph(os.Stdout, deps)
+	default:
+		for _, name := range slices.Sorted(maps.Keys(deps)) {
+			fmt.Println(name)
+		}
+	}
+	return nil
+}
+
+// hasType reports whether m defines a structure, enumeration, or type
+// alias with the given name.
+func hasType(m *model.Model, name string) bool {
+	return slices.ContainsFunc(m.Structures, func(s *model.Structure) bool { return s.Name == name }) ||
+		slices.ContainsFunc(m.Enumerations, func(e *model.Enumeration) bool { return e.Name == name }) ||
+		slices.ContainsFunc(m.TypeAliases, func(a *model.TypeAlias) bool { return a.Name == name })
+}
+
+// writeDepGraph writes one line per edge of graph, "From -> To", and a
+// line with the name alone for types that reference nothing.
+func writeDepGraph(w io.Writer, graph map[string][]string) {
+	for _, name := range slices.Sorted(maps.Keys(graph)) {
+		if len(graph[name]) == 0 {
+			fmt.Fprintln(w, name)
+		}
+		for _, dep := range graph[name] {
+			fmt.Fprintf(w, "%s -> %s\n", name, dep)
+		}
+	}
+}
+
+// writeDepGraphDOT writes graph as a Graphviz digraph.
+func writeDepGraphDOT(w io.Writer, graph map[string][]string) {
+	fmt.Fprintln(w, "digraph deps {")
+	for _, name := range slices.Sorted(maps.Keys(graph)) {
+		if len(graph[name]) == 0 {
+			fmt.Fprintf(w, "\t%q;\n", name)
+		}
+		for _, dep := range graph[name] {
+			fmt.Fprintf(w, "\t%q -> %q;\n", name, dep)
+		}
+	}
+	fmt.Fprintln(w, "}")
+}
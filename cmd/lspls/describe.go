@@ -0,0 +1,70 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/albertocavalcante/lspls/generator"
+)
+
+// describe writes the metadata of the named generator to w, followed by
+// its options, outputs, and requirements when it implements
+// [generator.Describer].
+func describe(w io.Writer, name string) error {
+	gen, ok := generator.Get(name)
+	if !ok {
+		return fmt.Errorf("unknown generator: %s\nAvailable: %s", name, strings.Join(generator.List(), ", "))
+	}
+	meta := gen.Metadata()
+	fmt.Fprintf(w, "%s %s: %s\n", meta.Name, meta.Version, meta.Description)
+	if len(meta.FileExtensions) > 0 {
+		fmt.Fprintf(w, "Extensions: %s\n", strings.Join(meta.FileExtensions, ", "))
+	}
+	if meta.URL != "" {
+		fmt.Fprintf(w, "URL: %s\n", meta.URL)
+	}
+
+	d, ok := gen.(generator.Describer)
+	if !ok {
+		return nil
+	}
+	desc := d.Describe()
+	if len(desc.Options) > 0 {
+		fmt.Fprintf(w, "\nOptions:\n")
+		for _, o := range desc.Options {
+			fmt.Fprintf(w, "  %s", o.Key)
+			var notes []string
+			if o.Flag != "" {
+				notes = append(notes, o.Flag)
+			}
+			if o.Default != "" {
+				notes = append(notes, "default: "+o.Default)
+			}
+			if len(notes) > 0 {
+				fmt.Fprintf(w, " (%s)", strings.Join(notes, ", "))
+			}
+			fmt.Fprintf(w, "\n      %s\n", o.Description)
+		}
+	}
+	writeList(w, "Outputs", desc.Outputs)
+	writeList(w, "Requirements", desc.Requirements)
+	return nil
+}
+
+// writeList writes a titled list, or nothing if items is empty.
+func writeList(w io.Writer, title string, items []string) {
+	if len(items) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "\n%s:\n", title)
+	for _, item := range items {
+		fmt.Fprintf(w, "  %s\n", item)
+	}
+}
@@ -12,8 +12,12 @@ import (
 	"github.com/albertocavalcante/lspls/generator"
 	"github.com/albertocavalcante/lspls/generators/golang"
 	"github.com/albertocavalcante/lspls/generators/groovy"
+	"github.com/albertocavalcante/lspls/generators/jsonschema"
 	"github.com/albertocavalcante/lspls/generators/kotlin"
+	"github.com/albertocavalcante/lspls/generators/markdown"
+	"github.com/albertocavalcante/lspls/generators/openapi"
 	"github.com/albertocavalcante/lspls/generators/proto"
+	"github.com/albertocavalcante/lspls/generators/zig"
 )
 
 func init() {
@@ -22,6 +26,10 @@ func init() {
 	generator.Register(proto.NewGenerator())
 	generator.Register(kotlin.NewGenerator())
 	generator.Register(groovy.NewGenerator())
+	generator.Register(jsonschema.NewGenerator())
+	generator.Register(openapi.NewGenerator())
+	generator.Register(zig.NewGenerator())
+	generator.Register(markdown.NewGenerator())
 	// Future generators:
 	// generator.Register(thrift.NewGenerator())
 }
@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/albertocavalcante/lspls/generator"
+)
+
+// checkFilenameTemplate reports whether tmpl is a valid --filename-template:
+// a file name, without directories, that contains {name}.
+func checkFilenameTemplate(tmpl string) error {
+	if !strings.Contains(tmpl, "{name}") {
+		return fmt.Errorf("invalid --filename-template %q: want a file name containing {name}", tmpl)
+	}
+	if strings.ContainsAny(tmpl, `/\`) {
+		return fmt.Errorf("invalid --filename-template %q: want a file name without directories", tmpl)
+	}
+	return nil
+}
+
+// renameOutputFiles renames every file of out by tmpl, replacing {name}
+// with the file's name without its directory and extension, such as
+// protocol for protocol.go. Files keep their directory and content. A Go
+// test file keeps its _test suffix before the new extension, so that it
+// stays a test file. Two files renamed to the same name are an error.
+func renameOutputFiles(out *generator.Output, tmpl string) error {
+	renamed := make(map[string][]byte, len(out.Files))
+	for _, name := range slices.Sorted(maps.Keys(out.Files)) {
+		dir, base := path.Split(name)
+		ext := path.Ext(base)
+		stem := strings.TrimSuffix(base, ext)
+		var test bool
+		if ext == ".go" {
+			stem, test = strings.CutSuffix(stem, "_test")
+		}
+		newBase := strings.ReplaceAll(tmpl, "{name}", stem)
+		if test && !strings.HasSuffix(newBase, "_test.go") {
+			ext := path.Ext(newBase)
+			newBase = strings.TrimSuffix(newBase, ext) + "_test" + ext
+		}
+		newName := dir + newBase
+		if _, ok := renamed[newName]; ok {
+			return fmt.Errorf("--filename-template %q gives more than one file the name %s", tmpl, newName)
+		}
+		renamed[newName] = out.Files[name]
+	}
+	out.Files = renamed
+	return nil
+}
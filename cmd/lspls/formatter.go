@@ -0,0 +1,46 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"os/exec"
+	"slices"
+	"strings"
+
+	"github.com/albertocavalcante/lspls/generator"
+)
+
+// formatOutput pipes every file of out through the formatter command line
+// and replaces its content with the formatter's output. The command line
+// is split on spaces without shell quoting; the formatter reads the file on
+// stdin and writes the result to stdout, such as "ktlint --stdin -F".
+func formatOutput(ctx context.Context, command string, out *generator.Output) error {
+	args := strings.Fields(command)
+	if len(args) == 0 {
+		return errors.New("empty formatter command")
+	}
+	for _, name := range slices.Sorted(maps.Keys(out.Files)) {
+		var stdout, stderr bytes.Buffer
+		cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+		cmd.Stdin = bytes.NewReader(out.Files[name])
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			if msg := strings.TrimSpace(stderr.String()); msg != "" {
+				err = fmt.Errorf("%w: %s", err, msg)
+			}
+			return fmt.Errorf("format %s: %w", name, err)
+		}
+		out.Files[name] = stdout.Bytes()
+	}
+	return nil
+}
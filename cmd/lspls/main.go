@@ -9,31 +9,138 @@
 // Usage:
 //
 //	lspls [flags]
+//	lspls selftest [-v ref | -spec path | -repo dir]
+//	lspls deps [--graph [--dot]] Type[,Type...]
+//	lspls compare-generators [-t Type[,Type...]] [--all]
+//	lspls --describe target
+//	lspls --list-profiles
+//
+// The --describe flag prints a generator's options, output files, and the
+// language versions and libraries the generated code requires.
+//
+// The --list-profiles flag prints the predefined type lists that --profile
+// selects.
+//
+// The selftest command runs every registered generator over the full
+// specification and reports per-generator pass/fail and timing.
+//
+// The deps command prints the types that -t would generate for the given
+// types, or with --graph the references between them, as text or as
+// Graphviz DOT.
+//
+// The compare-generators command generates each type alone with every
+// generator and prints a matrix of the parts each one degraded to its
+// dynamic type or skipped.
 //
 // Flags:
 //
 //	--target         Target generator (default: go)
 //	-o, --output     Output directory or file (default: stdout)
-//	-v, --version    LSP version/git ref (default: 3.17.6)
+//	-v, --version    LSP version/git ref (default: $LSPLS_REF, then 3.17.6)
 //	-t, --types      Comma-separated types to generate (default: all)
+//	--types-file     File listing types to generate, one per line
+//	--profile        Generate a predefined list of types, such as minimal
+//	--methods        Comma-separated methods to generate, with their types
+//	--deps-only      Generate only the dependencies of the -t types, not the types
+//	--dep-depth      Resolve dependencies of the -t types at most n references deep (Go only)
+//	--filtered-interfaces Keep Server/Client methods whose types pass -t (Go only)
 //	-p, --package    Go package name (default: protocol)
-//	--spec           Path to local metaModel.json
+//	--type-prefix    Prefix added to every generated type name
+//	--type-suffix    Suffix added to every generated type name
+//	--acronyms       Comma-separated words cased the same way in every identifier
+//	--name-override  Name=Ident: generate an LSP name as Ident (repeatable)
+//	--spec           Path to local metaModel.json, or a .tar.gz/.tgz/.zip holding it (repeatable)
+//	                 (default: $LSPLS_SPEC, unless -v or --repo is given)
 //	--repo           Path to local vscode-languageserver-node clone
+//	--spec-repo      Git remote to clone instead of microsoft/vscode-languageserver-node
+//	--cache-dir      Keep clones here and fetch new refs into them instead of recloning
+//	--offline        Fail instead of cloning or fetching; requires --spec or --repo
+//	--spec-sha256    Fail unless metaModel.json has this SHA-256
+//	--drop-line-field Parse the spec without recording source line numbers
 //	--proposed       Include proposed/unstable features
+//	--since-ref      Generate only types new or changed since this git ref
+//	--since-spec     Like --since-ref, but compare against a local metaModel.json
+//	--minify-docs    Omit documentation comments
+//	--strict-json-names Fail when inherited properties share a JSON name
+//	--emit-timestamp Add the generation time to file headers (not reproducible)
+//	--index          Add an index of generated types (directory output only)
+//	--indent         Indentation: tab or a number of spaces (Kotlin, Groovy, Proto)
+//	--equal          Generate Equal methods (Go only)
+//	--discriminated-unions Generate Kind accessors on kind-discriminated unions (Go only)
+//	--dedup-literals Merge structurally identical structures (Go only)
+//	--strict-required Reject JSON missing required properties (Go only)
+//	--iota-enums     Write contiguous integer enums as iota blocks (Go only)
+//	--bitmask-enums  Generate bit flag methods on power-of-two integer enums (Go only)
+//	--flag-value-enums Generate flag.Value methods on string enums (Go only)
+//	--enum-json      Generate JSON methods on enums enforcing their base type (Go only)
+//	--binary         Generate MarshalBinary/UnmarshalBinary on structures (Go only)
+//	--handler-struct Generate ServerHandlers/ClientHandlers func-field structs (Go only)
+//	--async-client   Generate AsyncServer/AsyncClient callback wrappers (Go only)
+//	--conn           Generate a Conn transport interface and ServerConn/ClientConn (Go only)
+//	--no-context     Leave context.Context out of Server/Client methods (Go only)
+//	--only-stable-methods Leave proposed methods out of Server/Client (Go only)
+//	--sort-helpers   Generate Sort functions for Range/Position-keyed structures (Go only)
+//	--position-helpers Generate Position/Range comparison methods (Go only)
+//	--progress-helpers Generate WorkDoneProgress payload constructors (Go only)
+//	--capability-accessors Generate accessors for nested optional capabilities (Go only)
+//	--emit-unknown-as-interface Generate unknown type kinds as UnknownKind_<kind> types (Go only)
+//	--enum-values    Generate All<Enum> slices of enum constants (Go only)
+//	--semantic-tokens-helpers Generate semantic token legend functions (Go only)
+//	--workspace-edit-helpers Generate ApplyWorkspaceEdit and ApplyTextEdits (Go only)
+//	--server-info-helper Generate LSPVersion, NewClientInfo, and NewServerInfo (Go only)
+//	--registry       Generate a Registry of MethodSpecs for every method (Go only)
+//	--jsonrpc2       Generate jsonrpc2 Handler adapters: x-tools or sourcegraph (Go only)
+//	--jsonrpc2-import Import path of a jsonrpc2 copy or fork for --jsonrpc2 (Go only)
+//	--go-build-tags  Build constraint gating every generated file (Go only)
+//	--go-version     Oldest Go release the generated code must compile with (Go only)
+//	--embed-schemas  Generate a Schemas map of each type's JSON Schema (Go only)
+//	--gen-tests      Generate a JSON round-trip test of every structure (Go only, directory output)
+//	--error-type     Generate a ResponseError type with a constructor per error code (Go only)
+//	--raw-any        Keep LSPAny, LSPObject, and LSPArray as raw JSON (Go only)
+//	--type-override  Comma-separated base=type Go type mappings (Go only)
+//	--rename         Comma-separated Old=New renames generating deprecated aliases (Go only)
+//	--tristate       Generate optional nullable properties as Optional[T] (Go only)
+//	--no-deprecated  Omit deprecated types and properties (Go only)
+//	--no-internal    Omit types whose name starts with _ (Go only)
+//	--split-packages Move namespace-only types into subpackages (Go only)
+//	--import-path    Import path of the output directory, for --split-packages
+//	--encode-default Annotate optional properties with @EncodeDefault: never or always (Kotlin only)
+//	--builders       Generate builders for structures with at least n required properties (Kotlin and Groovy only)
+//	--kotlin-sealed-interface Generate unions of structures as sealed interfaces (Kotlin only)
+//	--proto-field-numbering Number message fields sequential or stable, from a hash of the name (Proto only)
+//	--proto-field-map File of Message.field=number lines pinning field numbers (Proto only)
+//	--formatter      Pipe each generated file through this command (stdin to stdout)
+//	--fail-on-warn   Fail if a generator logs a warning, writing no files
 //	--dry-run        Print to stdout without writing files
+//	--out-txtar      Write all generated files into one txtar archive
+//	--filename-template Name directory output files by a template, such as lsp_{name}.go
+//	--summary-json   Write provenance and a hash of every generated file as JSON (- for stdout)
+//	--patch          Print a unified diff against the files at -o instead of writing
+//	--log-level      Log level: debug, info, warn, error (default: warn)
+//	--log-format     Log format: text or json (default: text)
 package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/albertocavalcante/lspls/fetch"
 	"github.com/albertocavalcante/lspls/generator"
+	"github.com/albertocavalcante/lspls/internal/textdiff"
+	"github.com/albertocavalcante/lspls/model"
+	"golang.org/x/tools/txtar"
 )
 
 var (
@@ -43,7 +150,20 @@ var (
 )
 
 func main() {
-	if err := run(); err != nil {
+	var err error
+	switch {
+	case len(os.Args) > 1 && os.Args[1] == "bench":
+		err = runBench(os.Args[2:])
+	case len(os.Args) > 1 && os.Args[1] == "selftest":
+		err = runSelftest(os.Args[2:])
+	case len(os.Args) > 1 && os.Args[1] == "deps":
+		err = runDeps(os.Args[2:])
+	case len(os.Args) > 1 && os.Args[1] == "compare-generators":
+		err = runCompareGenerators(os.Args[2:])
+	default:
+		err = run()
+	}
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "error: %v\n", err)
 		os.Exit(1)
 	}
@@ -53,21 +173,99 @@ func run() error {
 	// Global flags
 	showVersion := flag.Bool("version", false, "Show version information")
 	showHelp := flag.Bool("help", false, "Show help")
+	describeTarget := flag.String("describe", "", "Describe a generator's options, outputs, and requirements, then exit")
+	listProfiles := flag.Bool("list-profiles", false, "List the predefined type lists that --profile selects, then exit")
 
 	// Generator selection
 	target := flag.String("target", "go", "Target generator (available: "+strings.Join(generator.List(), ", ")+")")
 
 	// Generate command flags
 	output := flag.String("o", "", "Output directory or file (default: stdout)")
-	lspVersion := flag.String("v", fetch.DefaultRef, "LSP version or git ref")
+	lspVersion := flag.String("v", "", "LSP version or git ref (default: $"+fetch.RefEnv+", then "+fetch.DefaultRef+")")
 	types := flag.String("t", "", "Comma-separated types to generate (default: all)")
+	typesFile := flag.String("types-file", "", "File listing types to generate, one per line (# starts a comment)")
+	profile := flag.String("profile", "", "Generate a predefined list of types, such as minimal, with their dependencies; merged with -t (see --list-profiles)")
+	methods := flag.String("methods", "", "Comma-separated requests and notifications to generate, with the types they use; Server and Client keep only these (Go only)")
+	depsOnly := flag.Bool("deps-only", false, "With -t or --types-file, generate the types' transitive dependencies but not the types themselves")
+	depDepth := flag.Int("dep-depth", 0, "With -t or --types-file, resolve dependencies at most this many references deep, typing references beyond as any (Go only)")
+	filteredInterfaces := flag.Bool("filtered-interfaces", false, "With -t or --types-file, generate Server and Client with the methods whose params and result types are all generated (Go only)")
 	packageName := flag.String("p", "protocol", "Package name (for Go: Go package name)")
-	specPath := flag.String("spec", "", "Path to local metaModel.json")
+	typePrefix := flag.String("type-prefix", "", "Prefix added to every generated type name, e.g. LSP (Go, Kotlin, Groovy, Zig)")
+	typeSuffix := flag.String("type-suffix", "", "Suffix added to every generated type name (Go, Kotlin, Groovy, Zig)")
+	acronyms := flag.String("acronyms", "", "Comma-separated words, such as URI,ID, cased as given wherever they appear in a generated identifier (Go, Kotlin, Groovy, Zig, Proto)")
+	var nameOverrides stringList
+	flag.Var(&nameOverrides, "name-override", "Name=Ident: generate the LSP type, property, enumeration value, or method Name as Ident; repeatable (Go, Kotlin, Groovy, Zig, Proto)")
+	var specPaths stringList
+	flag.Var(&specPaths, "spec", "Path to local metaModel.json, or a .tar.gz, .tgz, or .zip archive holding it; repeat to merge extension models into the first")
 	repoDir := flag.String("repo", "", "Path to local vscode-languageserver-node clone")
+	specRepo := flag.String("spec-repo", "", "Git remote to clone (default: "+fetch.VSCodeRepo+")")
+	cacheDir := flag.String("cache-dir", "", "Directory for reusable clones; new refs are fetched into them instead of recloning")
+	offline := flag.Bool("offline", false, "Never clone or fetch; the specification must come from --spec or --repo")
+	specSHA256 := flag.String("spec-sha256", "", "Hex SHA-256 that the fetched metaModel.json must have")
+	dropLineField := flag.Bool("drop-line-field", false, "Parse metaModel.json without recording the source line of each object")
+	sinceRef := flag.String("since-ref", "", "Generate only types new or changed since this LSP version or git ref")
+	sinceSpec := flag.String("since-spec", "", "Generate only types new or changed since this local metaModel.json")
 	proposed := flag.Bool("proposed", false, "Include proposed/unstable features")
 	resolveDeps := flag.Bool("resolve-deps", true, "Include transitive type dependencies")
+	minifyDocs := flag.Bool("minify-docs", false, "Omit documentation comments (keeps @since/@deprecated)")
+	strictJSONNames := flag.Bool("strict-json-names", false, "Fail when more than one property of a structure has the same JSON name through its extends and mixins, instead of keeping the most-derived one with a warning")
+	emitTimestamp := flag.Bool("emit-timestamp", false, "Add the generation time to file headers; output is no longer reproducible")
+	indent := flag.String("indent", "", "Indentation, tab or a number of spaces (Kotlin, Groovy, Proto; default: the target's)")
+	index := flag.Bool("index", false, "Add an index of generated types: doc.go for Go, index.md otherwise (directory output only)")
+	equal := flag.Bool("equal", false, "Generate deep Equal methods (Go only)")
+	discriminatedUnions := flag.Bool("discriminated-unions", false, "Generate Kind and As<Member> methods on unions of structures with distinct kind properties (Go only)")
+	dedupLiterals := flag.Bool("dedup-literals", false, "Merge structurally identical structures into aliases (Go only)")
+	strictRequired := flag.Bool("strict-required", false, "Generate UnmarshalJSON that rejects missing required properties (Go only)")
+	bitmaskEnums := flag.Bool("bitmask-enums", false, "Generate Has, Set, Clear, and String methods on integer enumerations whose values are distinct powers of two (Go only)")
+	enumJSON := flag.Bool("enum-json", false, "Generate MarshalJSON and UnmarshalJSON on enumerations, encoding them as their declared base type (Go only)")
+	binary := flag.Bool("binary", false, "Generate MarshalBinary and UnmarshalBinary on structures, encoding them as compact JSON (Go only)")
+	flagValueEnums := flag.Bool("flag-value-enums", false, "Generate String and Set methods making string enumerations flag.Value implementations (Go only)")
+	iotaEnums := flag.Bool("iota-enums", false, "Write contiguous integer enumerations as iota const blocks (Go only)")
+	handlerStruct := flag.Bool("handler-struct", false, "Generate ServerHandlers/ClientHandlers structs with a func field per method (Go only)")
+	asyncClient := flag.Bool("async-client", false, "Generate AsyncServer/AsyncClient wrappers adding a callback-based <Method>Async per method (Go only)")
+	conn := flag.Bool("conn", false, "Generate a Conn interface abstracting the transport, and ServerConn and ClientConn sending each method over a Conn (Go only)")
+	noContext := flag.Bool("no-context", false, "Leave the context.Context parameter out of Server/Client methods (Go only)")
+	sortHelpers := flag.Bool("sort-helpers", false, "Generate Sort<Type> functions for structures with a Range or Position property (Go only)")
+	capabilityAccessors := flag.Bool("capability-accessors", false, "Generate ClientCapabilities and ServerCapabilities methods returning a nested optional capability and whether it is set (Go only)")
+	emitUnknownAsInterface := flag.Bool("emit-unknown-as-interface", false, "Generate type kinds unknown to lspls as UnknownKind_<kind> placeholder types instead of any (Go only)")
+	positionHelpers := flag.Bool("position-helpers", false, "Generate Position.Before and Range.IsEmpty, Contains, and Overlaps methods (Go only)")
+	progressHelpers := flag.Bool("progress-helpers", false, "Generate WorkDoneProgressBegin, Report, and End constructors setting their kind, and NewProgressParams (Go only)")
+	enumValues := flag.Bool("enum-values", false, "Generate an All<Enum> slice per enumeration, in values.go for directory output (Go only)")
+	semanticTokensHelpers := flag.Bool("semantic-tokens-helpers", false, "Generate SemanticTokenTypesLegend and SemanticTokenModifiersLegend functions (Go only)")
+	workspaceEditHelpers := flag.Bool("workspace-edit-helpers", false, "Generate ApplyWorkspaceEdit and ApplyTextEdits, applying a WorkspaceEdit's text edits to documents (Go only)")
+	jsonrpc2 := flag.String("jsonrpc2", "", "Generate ServerHandler and ClientHandler adapting the interfaces to a jsonrpc2 package: x-tools or sourcegraph (Go only)")
+	jsonrpc2Import := flag.String("jsonrpc2-import", "", "Import path of a jsonrpc2 copy or fork with the API of the --jsonrpc2 flavor (Go only)")
+	goBuildTags := flag.String("go-build-tags", "", "Build constraint expression gating every generated file, e.g. lsp, with //go:build and // +build lines (Go only)")
+	goVersion := flag.String("go-version", "", "Oldest Go release the generated code must compile with, e.g. 1.21, leaving out options that need a newer one (Go only)")
+	registry := flag.Bool("registry", false, "Generate a Registry map with a MethodSpec per method, in registry.go for directory output, and a UnionTypes map of union members (Go only)")
+	embedSchemas := flag.Bool("embed-schemas", false, "Generate a Schemas map holding the JSON Schema of every type, in schemas.go for directory output (Go only)")
+	genTests := flag.Bool("gen-tests", false, "Generate protocol_roundtrip_test.go, round-tripping an example of every structure through JSON (Go only, directory output)")
+	typeOverride := flag.String("type-override", "", "Comma-separated base=type pairs generating a base type as another Go type, e.g. decimal=encoding/json.Number (Go only)")
+	rename := flag.String("rename", "", "Comma-separated Old=New pairs generating each former type name Old as a deprecated alias of New (Go only)")
+	tristate := flag.Bool("tristate", false, "Generate properties both optional and nullable as Optional[T], telling absent from null (Go only)")
+	rawAny := flag.Bool("raw-any", false, "Generate LSPAny, LSPObject, and LSPArray as raw JSON that re-encodes unchanged (Go only)")
+	serverInfoHelper := flag.Bool("server-info-helper", false, "Generate an LSPVersion constant and NewClientInfo and NewServerInfo, which fill in the version from the build information (Go only)")
+	errorType := flag.Bool("error-type", false, "Generate a ResponseError type implementing error, with a constructor per ErrorCodes and LSPErrorCodes value (Go only)")
+	noDeprecated := flag.Bool("no-deprecated", false, "Omit deprecated types and properties; references to them become any (Go only)")
+	noInternal := flag.Bool("no-internal", false, "Omit types whose specification name starts with _, such as _InitializeParams; references to them become any (Go only)")
+	splitPackages := flag.Bool("split-packages", false, "Move types used by one namespace into subpackages such as textdocument/ (Go only, directory output)")
+	importPath := flag.String("import-path", "", "Import path of the output directory, used by --split-packages")
+	encodeDefault := flag.String("encode-default", "", "Annotate optional properties with @EncodeDefault in this mode: never or always (Kotlin only)")
+	builders := flag.Int("builders", 0, "Generate a builder for structures with at least this many required properties (Kotlin and Groovy only)")
+	sealedInterface := flag.Bool("kotlin-sealed-interface", false, "Generate unions whose members are all structures as sealed interfaces the member data classes implement (Kotlin only)")
+	protoFieldNumbering := flag.String("proto-field-numbering", "", "Number message fields in this mode: sequential, in declaration order, or stable, from a hash of the field name (Proto only; default: sequential)")
+	protoFieldMap := flag.String("proto-field-map", "", "File of Message.field=number lines pinning field numbers, one per line (# starts a comment) (Proto only)")
+	onlyStableMethods := flag.Bool("only-stable-methods", false, "Leave proposed requests and notifications out of Server/Client, even with --proposed (Go only)")
+	formatter := flag.String("formatter", "", "Command each generated file is piped through (stdin to stdout) before output, e.g. \"ktlint --stdin -F\"")
+	failOnWarn := flag.Bool("fail-on-warn", false, "Fail without writing files if a generator logs a warning, such as a skipped field or a type degraded to any")
 	dryRun := flag.Bool("dry-run", false, "Print to stdout without writing files")
-	verbose := flag.Bool("verbose", false, "Verbose output")
+	patch := flag.Bool("patch", false, "Print a unified diff of the changes to the files at -o instead of writing them")
+	outTxtar := flag.String("out-txtar", "", "Write the generated files, as for directory output, into this txtar archive instead of -o")
+	summaryJSON := flag.String("summary-json", "", "Write a JSON summary of the specification's provenance, the requested and generated types, and the SHA-256 of every generated file to this path (- for stdout)")
+	filenameTemplate := flag.String("filename-template", "", "Name the files of directory output by this template, replacing {name} with the generator's name for the file without extension, e.g. lsp_{name}.go")
+	verbose := flag.Bool("verbose", false, "Verbose output (same as --log-level=info)")
+	logLevel := flag.String("log-level", "warn", "Log level: debug, info, warn, error")
+	logFormat := flag.String("log-format", "text", "Log format: text or json")
 
 	flag.Usage = func() {
 		fmt.Fprintf(os.Stderr, `lspls - LSP Protocol Type Generator
@@ -76,20 +274,281 @@ Generate typed code from the Language Server Protocol specification.
 
 Usage:
   lspls [flags]
+  lspls selftest [-v ref | -spec path | -repo dir]
+                   Run every generator over the full spec and report
+                   pass/fail and timing per generator
+  lspls deps [--graph [--dot]] Type[,Type...]
+                   Print the types -t would generate for these types, or
+                   with --graph the references between them, as text or
+                   Graphviz DOT; takes -v, -spec, -repo, and -proposed
+  lspls compare-generators [-t Type[,Type...]] [--all]
+                   Generate each type alone with every generator and
+                   print which parts each degraded or skipped, as a
+                   matrix; takes -v, -spec, -repo, and -proposed
+  lspls --describe target
+                   Print a generator's options, output files, and the
+                   language versions and libraries its output requires
+  lspls --list-profiles
+                   Print the predefined type lists that --profile selects
 
 Flags:
   --target string  Target generator (default: go)
                    Available: %s
   -o string        Output directory or file (default: stdout)
-  -v string        LSP version or git ref (default: %s)
+  -v string        LSP version or git ref (default: $LSPLS_REF, then %s)
   -t string        Comma-separated types to generate (default: all)
+  --types-file string
+                   File listing types to generate, one per line; merged
+                   with -t (# starts a comment)
+  --profile string Generate a predefined list of types, such as minimal,
+                   with their dependencies; merged with -t
+  --methods string Comma-separated requests and notifications, such as
+                   textDocument/hover; generates the types of their params,
+                   result, and error data, merged with -t, and Server and
+                   Client with only these methods (Go interfaces only)
+  --deps-only      With -t or --types-file, generate only the types they
+                   transitively depend on, leaving out the named types, e.g.
+                   to put shared base types in their own package
+  --dep-depth int  With -t or --types-file, resolve dependencies at most
+                   this many references deep; references to types beyond
+                   are typed as any, with a comment (Go only)
+  --filtered-interfaces
+                   With -t or --types-file, generate Server and Client
+                   with only the methods whose params and result types are
+                   generated (Go only)
   -p string        Package name (default: protocol)
-  --spec string    Path to local metaModel.json
+  --type-prefix string
+                   Prefix added to every generated type name, including
+                   Or_* unions and Go Method* constants; JSON names are
+                   unchanged (Go, Kotlin, Groovy, Zig)
+  --type-suffix string
+                   Suffix added to every generated type name, like
+                   --type-prefix
+  --acronyms string
+                   Comma-separated words, such as URI,ID, cased as given
+                   wherever they appear as a word of a generated
+                   identifier: DocumentURI rather than DocumentUri, and
+                   document_uri rather than document_u_r_i (Go, Kotlin,
+                   Groovy, Zig, Proto)
+  --name-override Name=Ident
+                   Generate the LSP type, property, enumeration value, or
+                   method Name as Ident, overriding the casing rules; snake
+                   case names are not overridden. Repeatable (Go, Kotlin,
+                   Groovy, Zig, Proto)
+  --spec string    Path to local metaModel.json, or a .tar.gz, .tgz, or .zip
+                   archive holding protocol/metaModel.json (default:
+                   $LSPLS_SPEC, unless -v or --repo is given). Repeat to
+                   merge metaModel-shaped extensions into the first, later
+                   definitions replacing earlier ones of the same name
   --repo string    Path to local vscode-languageserver-node clone
+  --spec-repo string
+                   Git remote to clone, e.g. a fork or mirror
+                   (default: %s)
+  --cache-dir string
+                   Keep clones in this directory and fetch new refs into
+                   them instead of cloning on every run
+  --offline        Fail instead of cloning or fetching, so that the
+                   specification must come from --spec or --repo
+  --spec-sha256 string
+                   Fail unless metaModel.json, however it is fetched, has
+                   this hex-encoded SHA-256, to pin the specification
+  --drop-line-field
+                   Parse metaModel.json as is, without recording the source
+                   line of each object; warnings then carry no line numbers
+  --since-ref string
+                   Generate only types new or changed since this git ref
+  --since-spec string
+                   Like --since-ref, but compare against a local metaModel.json
   --proposed       Include proposed/unstable features
   --resolve-deps   Include transitive type dependencies (default: true)
+  --minify-docs    Omit documentation comments (keeps @since/@deprecated)
+  --strict-json-names
+                   Fail when more than one property of a structure has the
+                   same JSON name through its extends and mixins, instead
+                   of keeping the most-derived one with a warning
+  --emit-timestamp Add the generation time to file headers; the output is
+                   no longer reproducible
+  --index          Add an index of generated types: doc.go for Go, index.md
+                   for other targets (directory output only)
+  --indent string  Indent with a tab or this number of spaces instead of the
+                   target's default (Kotlin, Groovy, Proto)
+  --equal          Generate deep Equal methods (Go only)
+  --discriminated-unions
+                   Generate a Kind method and As<Member> getters on unions
+                   whose members all have a distinct string literal kind
+                   property (Go only)
+  --dedup-literals Merge structurally identical structures into aliases (Go only)
+  --strict-required
+                   Reject JSON missing required properties on unmarshal (Go only)
+  --iota-enums     Write contiguous integer enums as iota blocks (Go only)
+  --bitmask-enums  Generate Has, Set, Clear, and String methods on integer
+                   enums whose values are distinct powers of two, which
+                   are bit flags, such as WatchKind (Go only)
+  --flag-value-enums
+                   Generate String and Set methods on string enums, so
+                   they can be passed to flag.Var (Go only)
+  --enum-json      Generate MarshalJSON and UnmarshalJSON on enums, encoding
+                   string enums as JSON strings and integer enums as JSON
+                   numbers and rejecting the other kind (Go only)
+  --binary         Generate MarshalBinary and UnmarshalBinary on structures,
+                   encoding them as compact JSON, for binary caches (Go only)
+  --handler-struct Generate ServerHandlers/ClientHandlers structs with a func
+                   field per method (Go only)
+  --async-client   Generate AsyncServer/AsyncClient structs wrapping the
+                   interfaces with a non-blocking <Method>Async variant per
+                   method that delivers the result to a callback (Go only)
+  --conn           Generate a Conn interface with Call and Notify methods,
+                   to be implemented over stdio, TCP, or an in-memory pipe,
+                   and ServerConn and ClientConn returning a Server and a
+                   Client that send each method over a Conn (Go only)
+  --no-context     Leave the context.Context parameter out of Server/Client
+                   methods and omit the request ID helpers (Go only)
+  --sort-helpers   Generate Sort<Type> functions ordering structures by their
+                   Range or Position property, for tests (Go only)
+  --position-helpers
+                   Generate Position.Before and Range.IsEmpty, Contains, and
+                   Overlaps methods, if those structures have the usual
+                   fields (Go only)
+  --progress-helpers
+                   Generate NewWorkDoneProgressBegin, Report, and End
+                   constructors setting the kind, WithPercentage methods
+                   capping at 100, and NewProgressParams (Go only)
+  --capability-accessors
+                   Generate ClientCapabilities and ServerCapabilities
+                   methods, such as TextDocumentHoverDynamicRegistration,
+                   returning a nested optional capability and whether it
+                   is set, without nil checks (Go only)
+  --emit-unknown-as-interface
+                   Generate a type kind that lspls does not recognize, from
+                   a newer specification, as a placeholder type named
+                   UnknownKind_<kind> instead of any, to find and report
+                   it (Go only)
+  --only-stable-methods
+                   Leave proposed requests and notifications out of the
+                   Server/Client interfaces, even with --proposed (Go only)
+  --enum-values    Generate an All<Enum> slice of each enumeration's constants,
+                   in values.go for directory output (Go only)
+  --semantic-tokens-helpers
+                   Generate SemanticTokenTypesLegend and
+                   SemanticTokenModifiersLegend functions returning the
+                   enumerations' values in order, for the legend a server
+                   advertises (Go only)
+  --workspace-edit-helpers
+                   Generate ApplyWorkspaceEdit and ApplyTextEdits, applying
+                   the text edits of a WorkspaceEdit to documents read and
+                   written through callbacks, for tests and tools (Go only)
+  --server-info-helper
+                   Generate an LSPVersion constant and NewClientInfo and
+                   NewServerInfo constructors, which fill in the version
+                   from the build information of the binary (Go only)
+  --jsonrpc2 string
+                   Generate ServerHandler and ClientHandler, adapting the
+                   Server and Client interfaces to the Handler of a jsonrpc2
+                   package: x-tools (golang.org/x/tools/internal/jsonrpc2)
+                   or sourcegraph (github.com/sourcegraph/jsonrpc2), in
+                   jsonrpc2.go for directory output (Go only)
+  --jsonrpc2-import string
+                   Import path of a copy or fork of the jsonrpc2 package
+                   with the API of the --jsonrpc2 flavor (Go only)
+  --go-build-tags string
+                   Build constraint expression, such as lsp, gating every
+                   generated file with a //go:build line and the matching
+                   // +build line (Go only)
+  --go-version string
+                   Oldest Go release the generated code must compile with,
+                   such as 1.21; options that need a newer release are left
+                   out with a warning (Go only)
+  --registry       Generate a Registry map describing every method, with
+                   params decoders and result encoders, in registry.go for
+                   directory output, and a UnionTypes map listing the
+                   members of every Or_* union (Go only)
+  --embed-schemas  Generate a Schemas map holding the JSON Schema of every
+                   type, as the jsonschema target writes it, so that a
+                   server can validate params before decoding them, in
+                   schemas.go for directory output (Go only)
+  --gen-tests      Generate protocol_roundtrip_test.go, which encodes an
+                   example of every structure to JSON and checks that it
+                   decodes and re-encodes without error (Go only, directory
+                   output)
+  --raw-any        Generate LSPAny, LSPObject, and LSPArray as the raw JSON
+                   they were decoded from, so it re-encodes unchanged (Go only)
+  --type-override string
+                   Comma-separated base=type pairs generating an LSP base
+                   type as another Go type, such as
+                   decimal=encoding/json.Number; types from other packages
+                   are written with their import path (Go only)
+  --rename string  Comma-separated Old=New pairs of types renamed by the
+                   specification, generating each former name Old as a
+                   deprecated alias of New (Go only)
+  --tristate       Generate properties that are both optional and T | null
+                   as Optional[T], which tells an absent property from a
+                   null one; needs Go 1.24 for omitzero (Go only)
+  --error-type     Generate a ResponseError type implementing error, with a
+                   constructor per ErrorCodes and LSPErrorCodes value
+                   (Go only)
+  --no-deprecated  Omit deprecated types and properties; references to an
+                   omitted type become any (Go only)
+  --no-internal    Omit types whose specification name starts with _, such
+                   as _InitializeParams, instead of generating them with an
+                   X prefix; references to them become any, and structures
+                   extending them lose their properties (Go only)
+  --split-packages Move types used only by one namespace, such as
+                   textDocument, into a subpackage; shared types stay in the
+                   base package (Go only, directory output)
+  --import-path string
+                   Import path of the output directory, which subpackages
+                   import (required with --split-packages)
+  --encode-default string
+                   Annotate optional properties with @EncodeDefault in
+                   this mode, never or always (Kotlin only)
+  --builders int   Generate a builder for structures with at least this
+                   many required properties: a builder { } DSL in Kotlin,
+                   @Builder in Groovy (Kotlin and Groovy only)
+  --kotlin-sealed-interface
+                   Generate unions whose members are all structures as
+                   sealed interfaces that the member data classes
+                   implement, without Value wrappers (Kotlin only)
+  --proto-field-numbering string
+                   Number message fields in this mode: sequential, 1, 2,
+                   3, ... in declaration order (default), or stable, from a
+                   hash of the field name below 19000, so reordering or
+                   inserting properties keeps the other numbers (Proto only)
+  --proto-field-map string
+                   File of Message.field=number lines, by specification
+                   names, pinning field numbers; other fields are numbered
+                   around them (# starts a comment) (Proto only)
+  --formatter string
+                   Pipe each generated file through this command before
+                   output; it reads stdin and writes stdout, and generation
+                   fails if it exits with an error
+  --fail-on-warn   Fail without writing files if a generator logs a
+                   warning, such as a skipped field or a type degraded
+                   to the target's dynamic type
   --dry-run        Print to stdout without writing files
-  --verbose        Verbose output
+  --out-txtar string
+                   Write the generated files, laid out as for directory
+                   output, into this txtar archive instead of -o, for
+                   snapshotting and reviewing generated code
+  --filename-template string
+                   Name the files of directory output by this template,
+                   replacing {name} with the file's default name without
+                   extension: lsp_{name}.go turns protocol.go into
+                   lsp_protocol.go; Go test files keep their _test suffix
+  --summary-json string
+                   Write a JSON summary of the specification's source,
+                   ref, and commit, the requested and generated types, the
+                   generator's stats, and the name, SHA-256, and size of
+                   every generated file to this path (- for stdout), for
+                   reproducible-build audits
+  --patch          Print a unified diff of what generation would change
+                   in the files at -o instead of writing them, for
+                   reviewing a spec bump or flag change
+  --verbose        Verbose output (same as --log-level=info)
+  --log-level      Log level: debug, info, warn, error (default: warn)
+  --log-format     Log format: text or json (default: text)
+  --describe string
+                   Describe a generator's options, outputs, and
+                   requirements, then exit
   --version        Show version information
   --help           Show this help
 
@@ -109,10 +568,25 @@ Examples:
   # Use local metaModel.json
   lspls --spec ./metaModel.json -o ./protocol/
 
+  # Generate only what changed since 3.17.0
+  lspls --since-ref release/protocol/3.17.0 -o ./delta.go
+
+  # Snapshot the generated files in one reviewable archive
+  lspls --out-txtar ./testdata/protocol.txtar
+
+  # Review what a spec bump would change in the generated files
+  lspls -v release/protocol/3.18.0 -o ./protocol/ --patch
+
   # Generate Protocol Buffers (when available)
   lspls --target=proto -o ./lsp.proto
 
-`, strings.Join(generator.List(), ", "), fetch.DefaultRef)
+  # Generate OpenAPI component schemas for API tooling (when available)
+  lspls --target=openapi -o ./openapi.json
+
+  # Generate a Markdown protocol reference (when available)
+  lspls --target=markdown -o ./protocol.md
+
+`, strings.Join(generator.List(), ", "), fetch.DefaultRef, fetch.VSCodeRepo)
 	}
 
 	flag.Parse()
@@ -128,6 +602,43 @@ Examples:
 		return nil
 	}
 
+	if *describeTarget != "" {
+		return describe(os.Stdout, *describeTarget)
+	}
+
+	if *listProfiles {
+		for _, p := range generator.Profiles() {
+			fmt.Printf("%s: %s\n  %s\n", p.Name, p.Description, strings.Join(p.Types, ", "))
+		}
+		return nil
+	}
+
+	level := *logLevel
+	if *verbose && level == "warn" {
+		level = "info"
+	}
+	logger, err := newLogger(os.Stderr, level, *logFormat)
+	if err != nil {
+		return err
+	}
+	indentUnit, err := parseIndent(*indent)
+	if err != nil {
+		return err
+	}
+	acronymList, err := parseAcronyms(*acronyms)
+	if err != nil {
+		return err
+	}
+	overrides, err := parseNameOverrides(nameOverrides)
+	if err != nil {
+		return err
+	}
+	if *filenameTemplate != "" {
+		if err := checkFilenameTemplate(*filenameTemplate); err != nil {
+			return err
+		}
+	}
+
 	// Resolve generator
 	gen, ok := generator.Get(*target)
 	if !ok {
@@ -138,15 +649,19 @@ Examples:
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
 	defer cancel()
 
-	if *verbose {
-		fmt.Fprintln(os.Stderr, "Fetching LSP specification...")
-	}
+	logger.Info("fetching LSP specification")
 
 	fetchOpts := fetch.Options{
-		Ref:       *lspVersion,
-		LocalPath: *specPath,
-		RepoDir:   *repoDir,
-		Timeout:   90 * time.Second,
+		Ref:             *lspVersion,
+		LocalPath:       specPaths.first(),
+		RepoDir:         *repoDir,
+		Repo:            *specRepo,
+		CacheDir:        *cacheDir,
+		Offline:         *offline,
+		Timeout:         90 * time.Second,
+		Logger:          logger,
+		ExpectedSHA256:  *specSHA256,
+		DropLineNumbers: *dropLineField,
 	}
 
 	result, err := fetch.Fetch(ctx, fetchOpts)
@@ -154,32 +669,236 @@ Examples:
 		return fmt.Errorf("fetch specification: %w", err)
 	}
 
-	if *verbose {
-		fmt.Fprintf(os.Stderr, "Loaded LSP %s from %s\n", result.Model.Version.Version, result.Source)
-		if result.CommitHash != "" {
-			fmt.Fprintf(os.Stderr, "Commit: %s\n", result.CommitHash)
+	// Further --spec files are extensions merged into the first.
+	for i := 1; i < len(specPaths); i++ {
+		path := specPaths[i]
+		ext, err := fetch.Fetch(ctx, fetch.Options{LocalPath: path, Logger: logger, DropLineNumbers: *dropLineField})
+		if err != nil {
+			return fmt.Errorf("fetch extension: %w", err)
+		}
+		if result.Model, err = model.Merge(result.Model, ext.Model); err != nil {
+			return fmt.Errorf("merge %s: %w", path, err)
 		}
-		fmt.Fprintf(os.Stderr, "Found %d structures, %d enumerations, %d type aliases\n",
-			len(result.Model.Structures),
-			len(result.Model.Enumerations),
-			len(result.Model.TypeAliases))
-		fmt.Fprintf(os.Stderr, "Using generator: %s v%s\n", gen.Metadata().Name, gen.Metadata().Version)
+		result.Source += " + " + ext.Source
+	}
+
+	logger.Info("loaded LSP specification",
+		"version", result.Model.Version.Version,
+		"source", result.Source,
+		"commit", result.CommitHash,
+		"structures", len(result.Model.Structures),
+		"enumerations", len(result.Model.Enumerations),
+		"typeAliases", len(result.Model.TypeAliases))
+	logger.Info("using generator", "name", gen.Metadata().Name, "version", gen.Metadata().Version)
+
+	// Directory output lets generators split into several files; stdout,
+	// dry runs, and single-file output always get exactly one file. A txtar
+	// archive holds the files of directory output.
+	outputPath := *output
+	toDir := !*dryRun && outputPath != "" && (strings.HasSuffix(outputPath, "/") || isDir(outputPath))
+	if *patch && (outputPath == "" || *dryRun || *outTxtar != "") {
+		return fmt.Errorf("--patch needs -o and cannot be combined with --dry-run or --out-txtar")
+	}
+	if *outTxtar != "" {
+		if outputPath != "" || *dryRun {
+			return fmt.Errorf("--out-txtar cannot be combined with -o or --dry-run")
+		}
+		outputPath = filepath.Dir(*outTxtar)
+		toDir = true
 	}
 
 	// Build generator config
 	cfg := generator.Config{
-		OutputDir:       *output,
+		TypePrefix:      *typePrefix,
+		TypeSuffix:      *typeSuffix,
+		Acronyms:        acronymList,
+		NameOverrides:   overrides,
 		ResolveDeps:     *resolveDeps,
 		IncludeProposed: *proposed,
+		MinifyDocs:      *minifyDocs,
+		StrictJSONNames: *strictJSONNames,
+		Indent:          indentUnit,
+		Index:           *index,
 		GenerateClient:  true,
 		GenerateServer:  true,
 		Source:          result.Source,
 		Ref:             result.Ref,
 		CommitHash:      result.CommitHash,
 		LSPVersion:      result.Model.Version.Version,
+		ToolVersion:     version,
 		Options:         make(map[string]string),
+		Logger:          logger,
+	}
+	if *emitTimestamp {
+		cfg.Timestamp = time.Now()
+	}
+	var warnings generator.Warnings
+	if *failOnWarn {
+		cfg.Logger = slog.New(warnings.Handler(logger.Handler()))
 	}
 	cfg.Options["package"] = *packageName
+	if *equal {
+		cfg.Options["equal"] = "true"
+	}
+	if *discriminatedUnions {
+		cfg.Options["discriminated_unions"] = "true"
+	}
+	if *dedupLiterals {
+		cfg.Options["dedup_literals"] = "true"
+	}
+	if *strictRequired {
+		cfg.Options["strict_required"] = "true"
+	}
+	if *iotaEnums {
+		cfg.Options["iota_enums"] = "true"
+	}
+	if *bitmaskEnums {
+		cfg.Options["bitmask_enums"] = "true"
+	}
+	if *flagValueEnums {
+		cfg.Options["flag_value_enums"] = "true"
+	}
+	if *enumJSON {
+		cfg.Options["enum_json"] = "true"
+	}
+	if *binary {
+		cfg.Options["binary"] = "true"
+	}
+	if *handlerStruct {
+		cfg.Options["handler_struct"] = "true"
+	}
+	if *asyncClient {
+		cfg.Options["async_client"] = "true"
+	}
+	if *conn {
+		cfg.Options["conn"] = "true"
+	}
+	if *noContext {
+		cfg.Options["no_context"] = "true"
+	}
+	if *sortHelpers {
+		cfg.Options["sort_helpers"] = "true"
+	}
+	if *positionHelpers {
+		cfg.Options["position_helpers"] = "true"
+	}
+	if *progressHelpers {
+		cfg.Options["progress_helpers"] = "true"
+	}
+	if *capabilityAccessors {
+		cfg.Options["capability_accessors"] = "true"
+	}
+	if *emitUnknownAsInterface {
+		cfg.Options["unknown_as_interface"] = "true"
+	}
+	if *onlyStableMethods {
+		cfg.Options["only_stable_methods"] = "true"
+	}
+	if *enumValues {
+		cfg.Options["enum_values"] = "true"
+	}
+	if *semanticTokensHelpers {
+		cfg.Options["semantic_tokens_helpers"] = "true"
+	}
+	if *workspaceEditHelpers {
+		cfg.Options["workspace_edit_helpers"] = "true"
+	}
+	if *serverInfoHelper {
+		cfg.Options["server_info_helper"] = "true"
+	}
+	if *registry {
+		cfg.Options["registry"] = "true"
+	}
+	if *jsonrpc2 != "" {
+		cfg.Options["jsonrpc2"] = *jsonrpc2
+	}
+	if *jsonrpc2Import != "" {
+		if *jsonrpc2 == "" {
+			return fmt.Errorf("--jsonrpc2-import requires --jsonrpc2")
+		}
+		cfg.Options["jsonrpc2_import"] = *jsonrpc2Import
+	}
+	if *goBuildTags != "" {
+		cfg.Options["build_tags"] = *goBuildTags
+	}
+	if *goVersion != "" {
+		cfg.Options["go_version"] = *goVersion
+	}
+	if *embedSchemas {
+		cfg.Options["embed_schemas"] = "true"
+	}
+	if *genTests {
+		cfg.Options["gen_tests"] = "true"
+	}
+	if *rawAny {
+		cfg.Options["raw_any"] = "true"
+	}
+	if *tristate {
+		cfg.Options["tristate"] = "true"
+	}
+	if *typeOverride != "" {
+		cfg.Options["type_overrides"] = *typeOverride
+	}
+	if *rename != "" {
+		cfg.Options["renames"] = *rename
+	}
+	if *errorType {
+		cfg.Options["error_type"] = "true"
+	}
+	if *filteredInterfaces {
+		cfg.Options["filtered_interfaces"] = "true"
+	}
+	if *noDeprecated {
+		cfg.Options["omit_deprecated"] = "true"
+	}
+	if *noInternal {
+		cfg.Options["omit_internal"] = "true"
+	}
+	if *splitPackages {
+		if *importPath == "" {
+			return fmt.Errorf("--split-packages requires --import-path")
+		}
+		cfg.Options["split_packages"] = "true"
+		cfg.Options["import_path"] = *importPath
+	}
+	if *encodeDefault != "" {
+		cfg.Options["encode_default"] = *encodeDefault
+	}
+	if *builders < 0 {
+		return fmt.Errorf("invalid --builders %d: want a number of required properties", *builders)
+	}
+	if *builders > 0 {
+		cfg.Options["builders"] = strconv.Itoa(*builders)
+	}
+	if *sealedInterface {
+		cfg.Options["sealed_interfaces"] = "true"
+	}
+	if *protoFieldNumbering != "" {
+		cfg.Options["field_numbering"] = *protoFieldNumbering
+	}
+	if *protoFieldMap != "" {
+		data, err := os.ReadFile(*protoFieldMap)
+		if err != nil {
+			return fmt.Errorf("read proto field map: %w", err)
+		}
+		cfg.Options["field_map"] = string(data)
+	}
+
+	if toDir {
+		cfg.OutputDir = outputPath
+	} else if outputPath != "" && !*dryRun {
+		cfg.OutputFile = filepath.Base(outputPath)
+	}
+
+	if *sinceRef != "" || *sinceSpec != "" {
+		if filters := typeFilterFlags(*types, *typesFile, *profile, *methods); len(filters) > 0 {
+			since := "--since-ref"
+			if *sinceRef == "" {
+				since = "--since-spec"
+			}
+			return fmt.Errorf("%s cannot be combined with %s", since, strings.Join(filters, ", "))
+		}
+	}
 
 	if *types != "" {
 		cfg.Types = strings.Split(*types, ",")
@@ -187,26 +906,149 @@ Examples:
 			cfg.Types[i] = strings.TrimSpace(cfg.Types[i])
 		}
 	}
+	if *typesFile != "" {
+		listed, err := readTypesFile(*typesFile)
+		if err != nil {
+			return err
+		}
+		for _, name := range listed {
+			if !slices.Contains(cfg.Types, name) {
+				cfg.Types = append(cfg.Types, name)
+			}
+		}
+	}
+	if *profile != "" {
+		p, err := generator.LookupProfile(*profile)
+		if err != nil {
+			return err
+		}
+		for _, name := range p.Types {
+			if !slices.Contains(cfg.Types, name) {
+				cfg.Types = append(cfg.Types, name)
+			}
+		}
+	}
+
+	if *methods != "" {
+		for method := range strings.SplitSeq(*methods, ",") {
+			if method = strings.TrimSpace(method); method != "" {
+				cfg.Methods = append(cfg.Methods, method)
+			}
+		}
+		methodTypes, err := generator.MethodTypes(result.Model, cfg.Methods)
+		if err != nil {
+			return err
+		}
+		for _, name := range methodTypes {
+			if !slices.Contains(cfg.Types, name) {
+				cfg.Types = append(cfg.Types, name)
+			}
+		}
+	}
+
+	if *sinceRef != "" || *sinceSpec != "" {
+		logger.Info("fetching previous LSP specification", "ref", *sinceRef, "spec", *sinceSpec)
+		old, err := fetch.Fetch(ctx, fetch.Options{
+			Ref:             *sinceRef,
+			LocalPath:       *sinceSpec,
+			Repo:            *specRepo,
+			CacheDir:        *cacheDir,
+			Offline:         *offline,
+			Timeout:         90 * time.Second,
+			Logger:          logger,
+			DropLineNumbers: *dropLineField,
+		})
+		if err != nil {
+			return fmt.Errorf("fetch previous specification: %w", err)
+		}
+
+		diff := model.Diff(old.Model, result.Model)
+		logger.Info("computed type delta",
+			"added", len(diff.Added),
+			"changed", len(diff.Changed),
+			"removed", len(diff.Removed))
+		cfg.Types = diff.Types()
+		if len(cfg.Types) == 0 {
+			logger.Warn("no types added or changed", "since", old.Source)
+			return nil
+		}
+	}
+
+	if *depDepth < 0 {
+		return fmt.Errorf("invalid --dep-depth %d: want a number of references", *depDepth)
+	}
+	if *depDepth > 0 {
+		if len(cfg.Types) == 0 {
+			return fmt.Errorf("--dep-depth requires -t or --types-file")
+		}
+		cfg.Options["dep_depth"] = strconv.Itoa(*depDepth)
+	}
+
+	if *depsOnly {
+		if len(cfg.Types) == 0 {
+			return fmt.Errorf("--deps-only requires -t or --types-file")
+		}
+		roots := cfg.Types
+		cfg.Types = generator.DepsOnly(result.Model, roots, cfg.IncludeProposed)
+		// The dependencies are already resolved; resolving them again
+		// would bring back roots that a dependency refers to.
+		cfg.ResolveDeps = false
+		if len(cfg.Types) == 0 {
+			logger.Warn("named types have no dependencies", "types", roots)
+			return nil
+		}
+	}
 
 	// Generate code
 	out, err := gen.Generate(ctx, result.Model, cfg)
 	if err != nil {
+		if line := generator.ErrorLine(err); line > 0 {
+			return fmt.Errorf("generate code: %w (metaModel.json line %d, from %s)", err, line, result.Source)
+		}
 		return fmt.Errorf("generate code: %w", err)
 	}
+	if list := warnings.List(); len(list) > 0 {
+		return fmt.Errorf("%d generator warnings with --fail-on-warn:\n  %s", len(list), strings.Join(list, "\n  "))
+	}
+	if *formatter != "" {
+		logger.Info("formatting output", "formatter", *formatter)
+		if err := formatOutput(ctx, *formatter, out); err != nil {
+			return err
+		}
+	}
+	if *filenameTemplate != "" {
+		if err := renameOutputFiles(out, *filenameTemplate); err != nil {
+			return err
+		}
+	}
+	if *summaryJSON != "" {
+		if err := writeSummary(*summaryJSON, newSummary(result, *target, cfg, out)); err != nil {
+			return err
+		}
+	}
 
 	// Output
-	if *dryRun || *output == "" {
-		// Dry run or stdout: print first file
-		for _, content := range out.Files {
-			fmt.Println(string(content))
-			break
+	if *outTxtar != "" {
+		if err := writeTxtar(*outTxtar, out); err != nil {
+			return err
+		}
+		logger.Info("wrote archive", "path", *outTxtar, "files", len(out.Files))
+		return nil
+	}
+	if *patch {
+		return writePatch(os.Stdout, out, outputPath, toDir)
+	}
+	if *dryRun || outputPath == "" {
+		content, err := singleFile(out)
+		if err != nil {
+			return err
 		}
+		fmt.Println(string(content))
 		return nil
 	}
 
 	// Write files
-	outputPath := *output
-	if strings.HasSuffix(outputPath, "/") || isDir(outputPath) {
+	if toDir {
 		// Directory output
 		if err := os.MkdirAll(outputPath, 0o755); err != nil {
 			return fmt.Errorf("create output directory: %w", err)
@@ -214,12 +1056,13 @@ Examples:
 
 		for filename, content := range out.Files {
 			path := filepath.Join(outputPath, filename)
+			if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+				return fmt.Errorf("create directory for %s: %w", filename, err)
+			}
 			if err := os.WriteFile(path, content, 0o644); err != nil {
 				return fmt.Errorf("write %s: %w", filename, err)
 			}
-			if *verbose {
-				fmt.Fprintf(os.Stderr, "Wrote %s\n", path)
-			}
+			logger.Info("wrote file", "path", path)
 		}
 	} else {
 		// Single file output - use the output path as the filename
@@ -227,22 +1070,167 @@ Examples:
 			return fmt.Errorf("create output directory: %w", err)
 		}
 
-		// Write combined or first file
-		for _, content := range out.Files {
-			if err := os.WriteFile(outputPath, content, 0o644); err != nil {
-				return fmt.Errorf("write output: %w", err)
-			}
-			break
+		content, err := singleFile(out)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(outputPath, content, 0o644); err != nil {
+			return fmt.Errorf("write output: %w", err)
 		}
 
-		if *verbose {
-			fmt.Fprintf(os.Stderr, "Wrote %s\n", outputPath)
+		logger.Info("wrote file", "path", outputPath)
+	}
+
+	return nil
+}
+
+// singleFile returns the content of a single-file output. Generators only
+// split into several files when writing to a directory, so more than one
+// file here is a generator bug rather than something to silently truncate.
+func singleFile(out *generator.Output) ([]byte, error) {
+	if len(out.Files) != 1 {
+		return nil, fmt.Errorf("generator produced %d files; use a directory output (-o dir/)", len(out.Files))
+	}
+	for _, content := range out.Files {
+		return content, nil
+	}
+	return nil, nil
+}
+
+// writePatch writes to w a unified diff per file of out that differs from
+// the file at its output path. Files that don't exist yet are diffed
+// against /dev/null. Nothing is written when the output is up to date.
+func writePatch(w io.Writer, out *generator.Output, outputPath string, toDir bool) error {
+	paths := make(map[string][]byte)
+	if toDir {
+		for filename, content := range out.Files {
+			paths[filepath.Join(outputPath, filename)] = content
+		}
+	} else {
+		content, err := singleFile(out)
+		if err != nil {
+			return err
+		}
+		paths[outputPath] = content
+	}
+
+	for _, path := range slices.Sorted(maps.Keys(paths)) {
+		name := filepath.ToSlash(path)
+		oldName := name
+		old, err := os.ReadFile(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			oldName = "/dev/null"
+		} else if err != nil {
+			return fmt.Errorf("read %s: %w", path, err)
+		}
+		if _, err := io.WriteString(w, textdiff.Unified(oldName, name, string(old), string(paths[path]))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeTxtar writes the files of out, sorted by name, to a txtar archive
+// at path.
+func writeTxtar(path string, out *generator.Output) error {
+	ar := &txtar.Archive{}
+	for _, name := range slices.Sorted(maps.Keys(out.Files)) {
+		ar.Files = append(ar.Files, txtar.File{Name: name, Data: out.Files[name]})
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create output directory: %w", err)
+	}
+	if err := os.WriteFile(path, txtar.Format(ar), 0o644); err != nil {
+		return fmt.Errorf("write archive: %w", err)
+	}
+	return nil
+}
+
+// readTypesFile reads type names from path, one per line. Blank lines are
+// ignored and "#" starts a comment that runs to the end of the line.
+func readTypesFile(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read types file: %w", err)
+	}
+	var names []string
+	for line := range strings.SplitSeq(string(data), "\n") {
+		line, _, _ = strings.Cut(line, "#")
+		if line = strings.TrimSpace(line); line != "" {
+			names = append(names, line)
+		}
+	}
+	return names, nil
+}
+
+// typeFilterFlags returns the names of the flags selecting types that were
+// given a value: -t, --types-file, --profile, and --methods.
+func typeFilterFlags(types, typesFile, profile, methods string) []string {
+	var names []string
+	for _, f := range []struct{ name, value string }{
+		{"-t", types},
+		{"--types-file", typesFile},
+		{"--profile", profile},
+		{"--methods", methods},
+	} {
+		if f.value != "" {
+			names = append(names, f.name)
 		}
 	}
+	return names
+}
 
+// parseIndent returns the indentation unit of the --indent flag: a tab
+// for "tab", n spaces for a number n from 1 to 8, and "" for "".
+func parseIndent(s string) (string, error) {
+	switch s {
+	case "":
+		return "", nil
+	case "tab":
+		return "\t", nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 1 || n > 8 {
+		return "", fmt.Errorf("invalid --indent %q: want tab or a number of spaces from 1 to 8", s)
+	}
+	return strings.Repeat(" ", n), nil
+}
+
+// newLogger returns a logger writing to w at the given level and format.
+func newLogger(w io.Writer, level, format string) (*slog.Logger, error) {
+	var lvl slog.Level
+	if err := lvl.UnmarshalText([]byte(level)); err != nil {
+		return nil, fmt.Errorf("invalid --log-level %q: want debug, info, warn, or error", level)
+	}
+	opts := &slog.HandlerOptions{Level: lvl}
+	switch format {
+	case "text":
+		return slog.New(slog.NewTextHandler(w, opts)), nil
+	case "json":
+		return slog.New(slog.NewJSONHandler(w, opts)), nil
+	default:
+		return nil, fmt.Errorf("invalid --log-format %q: want text or json", format)
+	}
+}
+
+// stringList collects the values of a repeated flag.
+type stringList []string
+
+func (l *stringList) String() string { return strings.Join(*l, ",") }
+
+func (l *stringList) Set(s string) error {
+	*l = append(*l, s)
 	return nil
 }
 
+// first returns the first value, or "" if the flag is not given.
+func (l stringList) first() string {
+	if len(l) == 0 {
+		return ""
+	}
+	return l[0]
+}
+
 func isDir(path string) bool {
 	info, err := os.Stat(path)
 	return err == nil && info.IsDir()
@@ -0,0 +1,223 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/albertocavalcante/lspls/fetch"
+	"github.com/albertocavalcante/lspls/generator"
+	"github.com/albertocavalcante/lspls/model"
+)
+
+// selftestVariant is one configuration every generator is run with.
+type selftestVariant struct {
+	name     string
+	proposed bool
+
+	// outputDir, when set, selects directory output (split files for Go).
+	outputDir string
+
+	// options are passed to every generator; targets ignore options they
+	// don't know.
+	options map[string]string
+}
+
+// selftestVariants cover the stable and proposed protocol and, for Go,
+// split output with every optional feature enabled.
+var selftestVariants = []selftestVariant{
+	{name: "stable"},
+	{name: "proposed", proposed: true},
+	{name: "all-options", proposed: true, outputDir: "selftest", options: map[string]string{
+		"equal":                   "true",
+		"discriminated_unions":    "true",
+		"dedup_literals":          "true",
+		"strict_required":         "true",
+		"iota_enums":              "true",
+		"bitmask_enums":           "true",
+		"flag_value_enums":        "true",
+		"enum_json":               "true",
+		"binary":                  "true",
+		"handler_struct":          "true",
+		"async_client":            "true",
+		"conn":                    "true",
+		"sort_helpers":            "true",
+		"position_helpers":        "true",
+		"progress_helpers":        "true",
+		"capability_accessors":    "true",
+		"unknown_as_interface":    "true",
+		"enum_values":             "true",
+		"registry":                "true",
+		"jsonrpc2":                "sourcegraph",
+		"embed_schemas":           "true",
+		"gen_tests":               "true",
+		"semantic_tokens_helpers": "true",
+		"workspace_edit_helpers":  "true",
+		"error_type":              "true",
+		"server_info_helper":      "true",
+		"raw_any":                 "true",
+		"tristate":                "true",
+		"type_overrides":          "decimal=encoding/json.Number",
+		"renames":                 "SemanticTokensEdits=SemanticTokensDelta",
+		"builders":                "3",
+		"sealed_interfaces":       "true",
+		"field_numbering":         "stable",
+	}},
+}
+
+// selftestResult is the outcome of one generator and variant.
+type selftestResult struct {
+	generator string
+	variant   string
+	files     int
+	elapsed   time.Duration
+	err       error
+}
+
+// runSelftest implements "lspls selftest". It runs every registered
+// generator over the full specification in each variant and checks that
+// generation succeeds and that Go output is gofmt-valid and JSON output
+// parses. No external toolchain is needed.
+func runSelftest(args []string) error {
+	fs := flag.NewFlagSet("selftest", flag.ContinueOnError)
+	lspVersion := fs.String("v", "", "LSP version or git ref (default: $"+fetch.RefEnv+", then "+fetch.DefaultRef+")")
+	specPath := fs.String("spec", "", "Path to local metaModel.json")
+	repoDir := fs.String("repo", "", "Path to local vscode-languageserver-node clone")
+	specRepo := fs.String("spec-repo", "", "Git remote to clone (default: "+fetch.VSCodeRepo+")")
+	cacheDir := fs.String("cache-dir", "", "Directory for reusable clones")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	result, err := fetch.Fetch(ctx, fetch.Options{
+		Ref:       *lspVersion,
+		LocalPath: *specPath,
+		RepoDir:   *repoDir,
+		Repo:      *specRepo,
+		CacheDir:  *cacheDir,
+		Timeout:   90 * time.Second,
+	})
+	if err != nil {
+		return fmt.Errorf("fetch specification: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "self-testing %s against %s\n", strings.Join(generator.List(), ", "), result.Source)
+
+	var results []selftestResult
+	for _, name := range generator.List() {
+		gen, _ := generator.Get(name)
+		for _, v := range selftestVariants {
+			results = append(results, selftestOne(ctx, gen, name, v, result))
+		}
+	}
+
+	if err := writeSelftestTable(os.Stdout, results); err != nil {
+		return err
+	}
+	failed := 0
+	for _, r := range results {
+		if r.err != nil {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("selftest: %d of %d checks failed", failed, len(results))
+	}
+	return nil
+}
+
+// selftestOne runs gen in variant v over the fetched model and checks its
+// output.
+func selftestOne(ctx context.Context, gen generator.Generator, name string, v selftestVariant, spec *fetch.Result) selftestResult {
+	cfg := generator.Config{
+		OutputDir:       v.outputDir,
+		ResolveDeps:     true,
+		IncludeProposed: v.proposed,
+		GenerateClient:  true,
+		GenerateServer:  true,
+		Source:          spec.Source,
+		Ref:             spec.Ref,
+		CommitHash:      spec.CommitHash,
+		LSPVersion:      spec.Model.Version.Version,
+		Options:         v.options,
+	}
+
+	start := time.Now()
+	out, err := generate(ctx, gen, spec.Model, cfg)
+	r := selftestResult{generator: name, variant: v.name, elapsed: time.Since(start), err: err}
+	if err != nil {
+		return r
+	}
+	r.files = len(out.Files)
+	if r.files == 0 {
+		r.err = errors.New("no files generated")
+		return r
+	}
+	for _, file := range slices.Sorted(maps.Keys(out.Files)) {
+		if err := checkGenerated(file, out.Files[file]); err != nil {
+			r.err = fmt.Errorf("%s: %w", file, err)
+			return r
+		}
+	}
+	return r
+}
+
+// generate calls gen.Generate, turning a panic into an error so that one
+// broken generator doesn't stop the remaining checks.
+func generate(ctx context.Context, gen generator.Generator, m *model.Model, cfg generator.Config) (out *generator.Output, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("panic: %v", p)
+		}
+	}()
+	return gen.Generate(ctx, m, cfg)
+}
+
+// checkGenerated validates the syntax of a generated file where that needs
+// no external toolchain: Go files must be gofmt-valid and JSON must parse.
+func checkGenerated(name string, content []byte) error {
+	switch filepath.Ext(name) {
+	case ".go":
+		if _, err := format.Source(content); err != nil {
+			return fmt.Errorf("invalid Go: %w", err)
+		}
+	case ".json":
+		if !json.Valid(content) {
+			return errors.New("invalid JSON")
+		}
+	}
+	return nil
+}
+
+// writeSelftestTable prints one row per generator and variant.
+func writeSelftestTable(w io.Writer, results []selftestResult) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "generator\tvariant\tresult\tfiles\ttime")
+	for _, r := range results {
+		status := "ok"
+		if r.err != nil {
+			status = "FAIL: " + r.err.Error()
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\n", r.generator, r.variant, status, r.files, r.elapsed.Round(time.Millisecond))
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,93 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/albertocavalcante/lspls/fetch"
+	"github.com/albertocavalcante/lspls/generator"
+)
+
+// summary is the provenance record that --summary-json writes: where the
+// specification came from, what was asked for, and what was generated.
+type summary struct {
+	Source         string          `json:"source"`
+	Ref            string          `json:"ref"`
+	CommitHash     string          `json:"commitHash"`
+	LSPVersion     string          `json:"lspVersion"`
+	Target         string          `json:"target"`
+	TypesRequested []string        `json:"typesRequested"`
+	TypesGenerated int             `json:"typesGenerated"`
+	Stats          generator.Stats `json:"stats"`
+	Files          []summaryFile   `json:"files"`
+}
+
+// summaryFile describes one generated file of a summary.
+type summaryFile struct {
+	Name   string `json:"name"`
+	SHA256 string `json:"sha256"`
+	Bytes  int    `json:"bytes"`
+}
+
+// newSummary returns the summary of generating out with the target
+// generator and cfg from the specification of result. Files are sorted by
+// name; typesGenerated counts the named types in out.Stats, so it is zero
+// for targets that do not count them.
+func newSummary(result *fetch.Result, target string, cfg generator.Config, out *generator.Output) summary {
+	s := summary{
+		Source:         result.Source,
+		Ref:            result.Ref,
+		CommitHash:     result.CommitHash,
+		LSPVersion:     cfg.LSPVersion,
+		Target:         target,
+		TypesRequested: slices.Clone(cfg.Types),
+		TypesGenerated: out.Stats.Structures + out.Stats.Enumerations + out.Stats.TypeAliases,
+		Stats:          out.Stats,
+		Files:          []summaryFile{},
+	}
+	if s.TypesRequested == nil {
+		s.TypesRequested = []string{}
+	}
+	for _, name := range slices.Sorted(maps.Keys(out.Files)) {
+		sum := sha256.Sum256(out.Files[name])
+		s.Files = append(s.Files, summaryFile{
+			Name:   name,
+			SHA256: hex.EncodeToString(sum[:]),
+			Bytes:  len(out.Files[name]),
+		})
+	}
+	return s
+}
+
+// writeSummary writes s as indented JSON to path, or to stdout if path is
+// "-".
+func writeSummary(path string, s summary) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	if path == "-" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create summary directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("write summary: %w", err)
+	}
+	return nil
+}
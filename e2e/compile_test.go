@@ -122,6 +122,76 @@ go 1.22
 	})
 }
 
+// TestJSONRPC2OutputCompiles verifies that the jsonrpc2 Handler adapters
+// compile against the packages they target. The x-tools flavor builds
+// against go.lsp.dev/jsonrpc2, a public fork with the same API, since
+// golang.org/x/tools/internal/jsonrpc2 cannot be imported from outside
+// x/tools.
+func TestJSONRPC2OutputCompiles(t *testing.T) {
+	requireTool(t, "go")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	moduleRoot, err := findModuleRoot()
+	if err != nil {
+		t.Fatalf("find module root: %v", err)
+	}
+
+	tmpDir := t.TempDir()
+	binaryPath := filepath.Join(tmpDir, "lspls")
+	if err := buildBinaryFull(ctx, moduleRoot, binaryPath); err != nil {
+		t.Fatalf("build binary: %v", err)
+	}
+
+	tests := []struct {
+		flavor     string
+		importPath string
+	}{
+		{flavor: "sourcegraph", importPath: "github.com/sourcegraph/jsonrpc2"},
+		{flavor: "x-tools", importPath: "go.lsp.dev/jsonrpc2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.flavor, func(t *testing.T) {
+			goModDir := filepath.Join(tmpDir, tt.flavor)
+			if err := os.MkdirAll(goModDir, 0755); err != nil {
+				t.Fatalf("mkdir: %v", err)
+			}
+			goModContent := "module lsptest\n\ngo 1.22\n"
+			if err := os.WriteFile(filepath.Join(goModDir, "go.mod"), []byte(goModContent), 0644); err != nil {
+				t.Fatalf("write go.mod: %v", err)
+			}
+
+			cmd := exec.CommandContext(ctx, binaryPath,
+				"--target=go",
+				"--methods", "initialize,initialized,shutdown,textDocument/hover,window/logMessage",
+				"--jsonrpc2", tt.flavor,
+				"--jsonrpc2-import", tt.importPath,
+				"-o", filepath.Join(goModDir, "protocol.go"),
+				"-p", "lsptest",
+			)
+			var stderr bytes.Buffer
+			cmd.Stderr = &stderr
+			if err := cmd.Run(); err != nil {
+				t.Fatalf("lspls generate: %v\n%s", err, stderr.String())
+			}
+
+			for _, args := range [][]string{
+				{"get", tt.importPath},
+				{"build", "./..."},
+				{"vet", "./..."},
+			} {
+				cmd := exec.CommandContext(ctx, "go", args...)
+				cmd.Dir = goModDir
+				output, err := cmd.CombinedOutput()
+				if err != nil {
+					t.Fatalf("go %s failed: %v\n%s", strings.Join(args, " "), err, output)
+				}
+			}
+		})
+	}
+}
+
 // TestProtoOutputValid verifies that generated proto is valid using buf and protoc.
 func TestProtoOutputValid(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
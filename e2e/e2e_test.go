@@ -139,10 +139,19 @@ func runTestCase(t *testing.T, file, name string) {
 		t.Fatalf("write input.json: %v", err)
 	}
 
+	// Write extra input files; flags refer to them through $WORK.
+	for name, data := range tc.files {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), data, 0o644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+
 	// Build command arguments.
 	args := make([]string, 0, 3+len(tc.flags))
 	args = append(args, "--spec", inputPath, "--dry-run")
-	args = append(args, tc.flags...)
+	for _, f := range tc.flags {
+		args = append(args, strings.ReplaceAll(f, "$WORK", tmpDir))
+	}
 
 	// Execute the CLI.
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -186,6 +195,7 @@ type e2eCase struct {
 	description string
 	flags       []string
 	input       []byte
+	files       map[string][]byte // extra inputs, written next to input.json
 	want        map[string][]byte
 }
 
@@ -195,6 +205,7 @@ func parseE2ECase(name string, ar *txtar.Archive) (*e2eCase, error) {
 		name:        name,
 		description: string(ar.Comment),
 		want:        make(map[string][]byte),
+		files:       make(map[string][]byte),
 	}
 
 	// Parse flags from description.
@@ -208,8 +219,10 @@ func parseE2ECase(name string, ar *txtar.Archive) (*e2eCase, error) {
 		case strings.HasPrefix(f.Name, "want/"):
 			relPath := strings.TrimPrefix(f.Name, "want/")
 			c.want[relPath] = f.Data
+		case strings.HasPrefix(f.Name, "files/"):
+			c.files[strings.TrimPrefix(f.Name, "files/")] = f.Data
 		default:
-			return nil, fmt.Errorf("unexpected file in archive: %q (expected input.json or want/*)", f.Name)
+			return nil, fmt.Errorf("unexpected file in archive: %q (expected input.json, files/* or want/*)", f.Name)
 		}
 	}
 
@@ -315,11 +328,10 @@ func updateE2EArchive(ar *txtar.Archive, got map[string][]byte) *txtar.Archive {
 		Comment: ar.Comment,
 	}
 
-	// Keep input.json.
+	// Keep input.json and extra input files.
 	for _, f := range ar.Files {
-		if f.Name == "input.json" {
+		if f.Name == "input.json" || strings.HasPrefix(f.Name, "files/") {
 			result.Files = append(result.Files, f)
-			break
 		}
 	}
 
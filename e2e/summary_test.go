@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: MIT
+
+package e2e
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"golang.org/x/tools/txtar"
+)
+
+// TestSummaryJSON checks the shape of --summary-json and that the hashes
+// and sizes it records match the files written to the directory output.
+func TestSummaryJSON(t *testing.T) {
+	ar, err := txtar.ParseFile(filepath.Join("testdata", "single_file.txtar"))
+	if err != nil {
+		t.Fatalf("parse txtar: %v", err)
+	}
+	tmpDir := t.TempDir()
+	inputPath := filepath.Join(tmpDir, "input.json")
+	for _, f := range ar.Files {
+		if f.Name == "input.json" {
+			if err := os.WriteFile(inputPath, f.Data, 0o644); err != nil {
+				t.Fatalf("write input.json: %v", err)
+			}
+		}
+	}
+
+	outDir := filepath.Join(tmpDir, "out") + "/"
+	summaryPath := filepath.Join(tmpDir, "summary.json")
+	runLspls(t, "--spec", inputPath, "-t", "Hover", "-o", outDir, "--summary-json", summaryPath)
+
+	data, err := os.ReadFile(summaryPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var shape map[string]json.RawMessage
+	if err := json.Unmarshal(data, &shape); err != nil {
+		t.Fatalf("summary is not a JSON object: %v\n%s", err, data)
+	}
+	for _, key := range []string{"source", "ref", "commitHash", "lspVersion", "target", "typesRequested", "typesGenerated", "stats", "files"} {
+		if _, ok := shape[key]; !ok {
+			t.Errorf("summary lacks %q:\n%s", key, data)
+		}
+	}
+
+	var summary struct {
+		Source         string
+		LSPVersion     string
+		Target         string
+		TypesRequested []string
+		TypesGenerated int
+		Stats          struct{ Structures int }
+		Files          []struct {
+			Name   string
+			SHA256 string
+			Bytes  int
+		}
+	}
+	if err := json.Unmarshal(data, &summary); err != nil {
+		t.Fatal(err)
+	}
+	if summary.Source != "file://"+inputPath || summary.Target != "go" || summary.LSPVersion == "" {
+		t.Errorf("provenance = %q, %q, %q, want the spec file, go, and its version", summary.Source, summary.Target, summary.LSPVersion)
+	}
+	if !slices.Equal(summary.TypesRequested, []string{"Hover"}) {
+		t.Errorf("typesRequested = %v, want [Hover]", summary.TypesRequested)
+	}
+	// Hover and the MarkupContent it depends on.
+	if summary.TypesGenerated != 2 || summary.Stats.Structures != 2 {
+		t.Errorf("typesGenerated = %d, stats.structures = %d, want 2", summary.TypesGenerated, summary.Stats.Structures)
+	}
+
+	written := readTree(t, outDir)
+	if len(summary.Files) != len(written) {
+		t.Errorf("summary lists %d files, directory output has %d", len(summary.Files), len(written))
+	}
+	for _, f := range summary.Files {
+		content, ok := written[f.Name]
+		if !ok {
+			t.Errorf("summary lists %s, which was not written", f.Name)
+			continue
+		}
+		sum := sha256.Sum256([]byte(content))
+		if f.SHA256 != hex.EncodeToString(sum[:]) || f.Bytes != len(content) {
+			t.Errorf("%s: summary has sha256 %s and %d bytes, file has %x and %d", f.Name, f.SHA256, f.Bytes, sum, len(content))
+		}
+	}
+}
@@ -0,0 +1,210 @@
+// SPDX-License-Identifier: MIT
+
+package e2e
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/tools/txtar"
+)
+
+// TestOutTxtar checks that --out-txtar archives the same files that
+// directory output writes, and that they extract back unchanged.
+func TestOutTxtar(t *testing.T) {
+	ar, err := txtar.ParseFile(filepath.Join("testdata", "single_file.txtar"))
+	if err != nil {
+		t.Fatalf("parse txtar: %v", err)
+	}
+	tmpDir := t.TempDir()
+	inputPath := filepath.Join(tmpDir, "input.json")
+	for _, f := range ar.Files {
+		if f.Name == "input.json" {
+			if err := os.WriteFile(inputPath, f.Data, 0o644); err != nil {
+				t.Fatalf("write input.json: %v", err)
+			}
+		}
+	}
+
+	dirOut := filepath.Join(tmpDir, "dir") + "/"
+	runLspls(t, "--spec", inputPath, "-o", dirOut)
+	archivePath := filepath.Join(tmpDir, "snapshot", "protocol.txtar")
+	runLspls(t, "--spec", inputPath, "--out-txtar", archivePath)
+
+	archive, err := txtar.ParseFile(archivePath)
+	if err != nil {
+		t.Fatalf("parse archive: %v", err)
+	}
+	if len(archive.Files) < 2 {
+		t.Fatalf("archive has %d files, want the split files of directory output", len(archive.Files))
+	}
+
+	// Extract the archive and compare it with the directory output.
+	extracted := filepath.Join(tmpDir, "extracted")
+	for _, f := range archive.Files {
+		path := filepath.Join(extracted, f.Name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(path, f.Data, 0o644); err != nil {
+			t.Fatalf("write %s: %v", f.Name, err)
+		}
+	}
+	if diff := cmp.Diff(readTree(t, dirOut), readTree(t, extracted)); diff != "" {
+		t.Errorf("extracted archive differs from directory output (-want +got):\n%s", diff)
+	}
+}
+
+// TestFilenameTemplate checks that --filename-template renames the files
+// of directory output, keeping their content and the _test suffix of the
+// round-trip test.
+func TestFilenameTemplate(t *testing.T) {
+	ar, err := txtar.ParseFile(filepath.Join("testdata", "single_file.txtar"))
+	if err != nil {
+		t.Fatalf("parse txtar: %v", err)
+	}
+	tmpDir := t.TempDir()
+	inputPath := filepath.Join(tmpDir, "input.json")
+	for _, f := range ar.Files {
+		if f.Name == "input.json" {
+			if err := os.WriteFile(inputPath, f.Data, 0o644); err != nil {
+				t.Fatalf("write input.json: %v", err)
+			}
+		}
+	}
+
+	plain := filepath.Join(tmpDir, "plain") + "/"
+	runLspls(t, "--spec", inputPath, "--gen-tests", "-o", plain)
+	templated := filepath.Join(tmpDir, "templated") + "/"
+	runLspls(t, "--spec", inputPath, "--gen-tests", "--filename-template", "lsp_{name}.go", "-o", templated)
+
+	want := make(map[string]string)
+	for name, content := range readTree(t, plain) {
+		if stem, ok := strings.CutSuffix(name, "_test.go"); ok {
+			want["lsp_"+stem+"_test.go"] = content
+		} else {
+			want["lsp_"+name] = content
+		}
+	}
+	if _, ok := want["lsp_protocol_roundtrip_test.go"]; !ok {
+		t.Fatalf("directory output has no round-trip test: %v", want)
+	}
+	if diff := cmp.Diff(want, readTree(t, templated)); diff != "" {
+		t.Errorf("templated output mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// TestPatch checks that --patch prints the unified diff between the files
+// generated from an older specification and a newer one, leaving the files
+// as they are.
+func TestPatch(t *testing.T) {
+	ar, err := txtar.ParseFile(filepath.Join("testdata", "since_spec.txtar"))
+	if err != nil {
+		t.Fatalf("parse txtar: %v", err)
+	}
+	tmpDir := t.TempDir()
+	specs := map[string]string{"input.json": "new.json", "files/old.json": "old.json"}
+	for _, f := range ar.Files {
+		if name, ok := specs[f.Name]; ok {
+			if err := os.WriteFile(filepath.Join(tmpDir, name), f.Data, 0o644); err != nil {
+				t.Fatalf("write %s: %v", name, err)
+			}
+		}
+	}
+
+	// Run from tmpDir so that the paths in the patch are relative.
+	t.Chdir(tmpDir)
+	runLspls(t, "--spec", "old.json", "-o", "out/protocol.go")
+	before, err := os.ReadFile(filepath.Join("out", "protocol.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := runLspls(t, "--spec", "new.json", "-o", "out/protocol.go", "--patch")
+	want := `--- out/protocol.go
++++ out/protocol.go
+@@ -1,6 +1,6 @@
+ // Code generated by lspls. DO NOT EDIT.
+-// Source: file://old.json
+-// LSP Version: 3.17.0
++// Source: file://new.json
++// LSP Version: 3.18.0
+ // Generator: lspls dev
+ package protocol
+ 
+@@ -11,6 +11,7 @@
+ 
+ type Range struct {
+ 	Start Position ` + "`json:\"start\"`" + `
++	End   Position ` + "`json:\"end\"`" + `
+ }
+ 
+ type TextDocumentIdentifier struct {
+`
+	if diff := cmp.Diff(want, string(got)); diff != "" {
+		t.Errorf("--patch output mismatch (-want +got):\n%s", diff)
+	}
+
+	after, err := os.ReadFile(filepath.Join("out", "protocol.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(after, before) {
+		t.Error("--patch rewrote the output file")
+	}
+	if got := runLspls(t, "--spec", "old.json", "-o", "out/protocol.go", "--patch"); len(got) > 0 {
+		t.Errorf("--patch of up-to-date output = %q, want nothing", got)
+	}
+}
+
+// runLspls runs the lspls binary with args, failing the test on error, and
+// returns its standard output.
+func runLspls(t *testing.T, args ...string) []byte {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, binary, args...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("lspls %v: %v\n%s", args, err, stderr.String())
+	}
+	return stdout.Bytes()
+}
+
+// readTree returns the contents of the files under dir, keyed by their
+// slash-separated path relative to dir.
+func readTree(t *testing.T, dir string) map[string]string {
+	t.Helper()
+
+	files := make(map[string]string)
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = string(data)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("read %s: %v", dir, err)
+	}
+	return files
+}
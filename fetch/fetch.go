@@ -8,14 +8,22 @@
 package fetch
 
 import (
+	"archive/tar"
+	"archive/zip"
 	"bytes"
+	"compress/gzip"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"os"
 	"os/exec"
+	"path"
 	"path/filepath"
 	"strings"
 	"time"
@@ -32,24 +40,76 @@ const (
 
 	// MetaModelPath is the path to metaModel.json within the repository.
 	MetaModelPath = "protocol/metaModel.json"
+
+	// RefEnv names the environment variable holding the git reference to
+	// use when Options.Ref is empty, before falling back to DefaultRef.
+	RefEnv = "LSPLS_REF"
+
+	// SpecEnv names the environment variable holding a path to use as
+	// Options.LocalPath when none of LocalPath, RepoDir, and Ref is set.
+	SpecEnv = "LSPLS_SPEC"
 )
 
+// ErrOffline is returned by Fetch when Options.Offline is set and neither
+// LocalPath nor RepoDir is, and by FetchRaw whenever Options.Offline is set.
+var ErrOffline = errors.New("offline: the specification must be read from LocalPath or RepoDir")
+
+// ErrChecksumMismatch is returned by Fetch and FetchRaw when the fetched
+// metaModel.json does not hash to Options.ExpectedSHA256.
+var ErrChecksumMismatch = errors.New("metaModel.json checksum mismatch")
+
 // Options configures how to fetch the LSP specification.
 type Options struct {
 	// Ref is the git reference (tag or branch) to use.
-	// If empty, DefaultRef is used.
+	// If empty, the value of RefEnv is used, and then DefaultRef.
 	Ref string
 
 	// LocalPath is a path to a local metaModel.json file.
 	// If set, the file is read directly instead of fetching from git.
+	// A path ending in .tar.gz, .tgz, or .zip is read as an archive
+	// holding MetaModelPath instead. If LocalPath, RepoDir, and Ref are
+	// all empty, the value of SpecEnv is used.
 	LocalPath string
 
 	// RepoDir is a path to an existing clone of vscode-languageserver-node.
 	// If set, the repository is used instead of cloning.
 	RepoDir string
 
+	// Repo is the git remote to clone, such as a fork or internal mirror.
+	// If empty, VSCodeRepo is used.
+	Repo string
+
+	// MetaModelPath is the slash-separated path to metaModel.json within
+	// the repository. If empty, MetaModelPath is used.
+	MetaModelPath string
+
+	// CacheDir holds reusable clones of Repo, one per remote. If set, the
+	// first fetch clones into it and later fetches check out the requested
+	// ref in the existing clone, which only downloads what changed.
+	CacheDir string
+
+	// Offline forbids network access: Fetch fails with ErrOffline instead
+	// of cloning or fetching, so LocalPath or RepoDir must be set. FetchRaw
+	// always fails with ErrOffline.
+	Offline bool
+
+	// ExpectedSHA256 is the hex-encoded SHA-256 of metaModel.json. If set,
+	// the fetched file is hashed before it is parsed, and a mismatch fails
+	// with ErrChecksumMismatch, guarding against a changed or compromised
+	// upstream.
+	ExpectedSHA256 string
+
+	// DropLineNumbers parses metaModel.json as is, leaving the Line fields
+	// of the model zero. By default each object records the line it starts
+	// on, which locates warnings but makes models parsed from the same spec
+	// text unequal once it is reformatted.
+	DropLineNumbers bool
+
 	// Timeout for network operations.
 	Timeout time.Duration
+
+	// Logger receives progress at debug level. If nil, nothing is logged.
+	Logger *slog.Logger
 }
 
 // Result contains the fetched specification and metadata.
@@ -72,58 +132,215 @@ func Fetch(ctx context.Context, opts Options) (*Result, error) {
 	if opts.Timeout == 0 {
 		opts.Timeout = 60 * time.Second
 	}
-
-	// Priority: LocalPath > RepoDir > Clone
-	if opts.LocalPath != "" {
-		return fetchFromFile(opts.LocalPath)
+	if opts.Logger == nil {
+		opts.Logger = slog.New(slog.DiscardHandler)
 	}
+	opts = opts.withDefaults()
+
+	var (
+		result *Result
+		err    error
+	)
 
-	if opts.RepoDir != "" {
-		return fetchFromRepo(opts.RepoDir, opts.Ref)
+	// Priority: LocalPath > RepoDir > CacheDir > Clone. Offline stops
+	// before the sources that need the network.
+	switch {
+	case opts.LocalPath != "" && isArchive(opts.LocalPath):
+		opts.Logger.Debug("reading specification from archive", "path", opts.LocalPath, "member", opts.MetaModelPath)
+		result, err = fetchFromArchive(opts)
+	case opts.LocalPath != "":
+		opts.Logger.Debug("reading specification", "path", opts.LocalPath)
+		result, err = fetchFromFile(opts)
+	case opts.RepoDir != "":
+		opts.Logger.Debug("reading specification from repository", "repo", opts.RepoDir)
+		result, err = fetchFromRepo(opts)
+	case opts.Offline:
+		return nil, ErrOffline
+	case opts.CacheDir != "":
+		result, err = fetchFromCache(ctx, opts)
+	default:
+		result, err = fetchFromGit(ctx, opts)
 	}
+	if err != nil {
+		return nil, err
+	}
+
+	opts.Logger.Debug("parsed specification",
+		"version", result.Model.Version.Version,
+		"structures", len(result.Model.Structures),
+		"enumerations", len(result.Model.Enumerations),
+		"typeAliases", len(result.Model.TypeAliases))
+	return result, nil
+}
 
-	return fetchFromGit(ctx, opts)
+// withDefaults fills in the default remote and metaModel.json path, and
+// the ref and local path from the environment. An explicit source beats
+// SpecEnv, so a ref set in Options is not shadowed by a path from the
+// environment.
+func (o Options) withDefaults() Options {
+	if o.LocalPath == "" && o.RepoDir == "" && o.Ref == "" {
+		o.LocalPath = os.Getenv(SpecEnv)
+	}
+	if o.Ref == "" {
+		o.Ref = os.Getenv(RefEnv)
+	}
+	if o.Repo == "" {
+		o.Repo = VSCodeRepo
+	}
+	if o.MetaModelPath == "" {
+		o.MetaModelPath = MetaModelPath
+	}
+	return o
 }
 
-// fetchFromFile reads the specification from a local file.
-func fetchFromFile(path string) (*Result, error) {
-	data, err := os.ReadFile(path)
+// fetchFromFile reads the specification from opts.LocalPath.
+func fetchFromFile(opts Options) (*Result, error) {
+	data, err := os.ReadFile(opts.LocalPath)
 	if err != nil {
 		return nil, fmt.Errorf("read file: %w", err)
 	}
+	if err := verifySHA256(data, opts.ExpectedSHA256); err != nil {
+		return nil, err
+	}
+
+	m, err := parseModel(data, !opts.DropLineNumbers)
+	if err != nil {
+		return nil, fmt.Errorf("parse model: %w", err)
+	}
+
+	return &Result{
+		Model:  m,
+		Source: fmt.Sprintf("file://%s", opts.LocalPath),
+	}, nil
+}
+
+// isArchive reports whether path names an archive fetchFromArchive reads.
+func isArchive(path string) bool {
+	for _, ext := range []string{".tar.gz", ".tgz", ".zip"} {
+		if strings.HasSuffix(strings.ToLower(path), ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// fetchFromArchive reads the specification from the archive at
+// opts.LocalPath without extracting it to disk. The member read is
+// opts.MetaModelPath, either at the root of the archive or under a single
+// top-level directory, as in the archives GitHub serves for a ref.
+func fetchFromArchive(opts Options) (*Result, error) {
+	var (
+		data []byte
+		err  error
+	)
+	if strings.HasSuffix(strings.ToLower(opts.LocalPath), ".zip") {
+		data, err = readZipMember(opts.LocalPath, opts.MetaModelPath)
+	} else {
+		data, err = readTarGzMember(opts.LocalPath, opts.MetaModelPath)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read archive: %w", err)
+	}
+	if err := verifySHA256(data, opts.ExpectedSHA256); err != nil {
+		return nil, err
+	}
 
-	m, err := parseModel(data)
+	m, err := parseModel(data, !opts.DropLineNumbers)
 	if err != nil {
 		return nil, fmt.Errorf("parse model: %w", err)
 	}
 
 	return &Result{
 		Model:  m,
-		Source: fmt.Sprintf("file://%s", path),
+		Source: fmt.Sprintf("archive://%s", opts.LocalPath),
 	}, nil
 }
 
-// fetchFromRepo reads the specification from an existing repository clone.
-func fetchFromRepo(repoDir, ref string) (*Result, error) {
-	path := filepath.Join(repoDir, MetaModelPath)
-	data, err := os.ReadFile(path)
+// readTarGzMember returns the contents of the member of the gzipped tar
+// archive at path that archiveMember matches.
+func readTarGzMember(path, member string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil, fmt.Errorf("%s not found in %s", member, path)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if hdr.Typeflag == tar.TypeReg && archiveMember(hdr.Name, member) {
+			return io.ReadAll(tr)
+		}
+	}
+}
+
+// readZipMember returns the contents of the member of the zip archive at
+// path that archiveMember matches.
+func readZipMember(path, member string) ([]byte, error) {
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = zr.Close() }()
+
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() || !archiveMember(f.Name, member) {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = rc.Close() }()
+		return io.ReadAll(rc)
+	}
+	return nil, fmt.Errorf("%s not found in %s", member, path)
+}
+
+// archiveMember reports whether the archive entry called name is member,
+// either at the root of the archive or under one top-level directory.
+func archiveMember(name, member string) bool {
+	name = strings.TrimPrefix(name, "./")
+	if name == member {
+		return true
+	}
+	_, rest, ok := strings.Cut(name, "/")
+	return ok && rest == member
+}
+
+// fetchFromRepo reads the specification from the clone at opts.RepoDir.
+func fetchFromRepo(opts Options) (*Result, error) {
+	data, err := os.ReadFile(filepath.Join(opts.RepoDir, filepath.FromSlash(opts.MetaModelPath)))
 	if err != nil {
 		return nil, fmt.Errorf("read from repo: %w", err)
 	}
+	if err := verifySHA256(data, opts.ExpectedSHA256); err != nil {
+		return nil, err
+	}
 
-	m, err := parseModel(data)
+	m, err := parseModel(data, !opts.DropLineNumbers)
 	if err != nil {
 		return nil, fmt.Errorf("parse model: %w", err)
 	}
 
 	// Try to get commit hash
-	hash := getGitHash(repoDir)
+	hash := getGitHash(opts.RepoDir)
 
 	return &Result{
 		Model:      m,
-		Ref:        ref,
+		Ref:        opts.Ref,
 		CommitHash: hash,
-		Source:     fmt.Sprintf("repo://%s", repoDir),
+		Source:     fmt.Sprintf("repo://%s", opts.RepoDir),
 	}, nil
 }
 
@@ -145,6 +362,8 @@ func fetchFromGit(ctx context.Context, opts Options) (*Result, error) {
 	cloneCtx, cancel := context.WithTimeout(ctx, opts.Timeout)
 	defer cancel()
 
+	opts.Logger.Debug("cloning repository", "repo", opts.Repo, "ref", ref, "dir", tmpDir)
+
 	cmd := exec.CommandContext(cloneCtx, "git", "clone",
 		"--quiet",
 		"--depth=1",
@@ -152,29 +371,33 @@ func fetchFromGit(ctx context.Context, opts Options) (*Result, error) {
 		"--sparse",
 		"--branch="+ref,
 		"--single-branch",
-		VSCodeRepo,
+		opts.Repo,
 		tmpDir,
 	)
 	var stderr bytes.Buffer
 	cmd.Stderr = &stderr
 	if err := cmd.Run(); err != nil {
-		return nil, fmt.Errorf("git clone %s: %w (stderr: %s)", VSCodeRepo, err, strings.TrimSpace(stderr.String()))
+		return nil, fmt.Errorf("git clone %s: %w (stderr: %s)", opts.Repo, err, strings.TrimSpace(stderr.String()))
 	}
 
-	// Sparse checkout just the protocol directory
-	cmd = exec.CommandContext(cloneCtx, "git", "-C", tmpDir, "sparse-checkout", "set", "protocol")
+	// Sparse checkout just the directory holding metaModel.json
+	sparseDir := path.Dir(opts.MetaModelPath)
+	opts.Logger.Debug("sparse checkout", "path", sparseDir)
+	cmd = exec.CommandContext(cloneCtx, "git", "-C", tmpDir, "sparse-checkout", "set", sparseDir)
 	if err := cmd.Run(); err != nil {
 		return nil, fmt.Errorf("sparse checkout: %w", err)
 	}
 
 	// Read the file
-	path := filepath.Join(tmpDir, MetaModelPath)
-	data, err := os.ReadFile(path)
+	data, err := os.ReadFile(filepath.Join(tmpDir, filepath.FromSlash(opts.MetaModelPath)))
 	if err != nil {
 		return nil, fmt.Errorf("read metaModel.json: %w", err)
 	}
+	if err := verifySHA256(data, opts.ExpectedSHA256); err != nil {
+		return nil, err
+	}
 
-	m, err := parseModel(data)
+	m, err := parseModel(data, !opts.DropLineNumbers)
 	if err != nil {
 		return nil, fmt.Errorf("parse model: %w", err)
 	}
@@ -185,14 +408,131 @@ func fetchFromGit(ctx context.Context, opts Options) (*Result, error) {
 		Model:      m,
 		Ref:        ref,
 		CommitHash: hash,
-		Source:     fmt.Sprintf("%s@%s", VSCodeRepo, ref),
+		Source:     fmt.Sprintf("%s@%s", opts.Repo, ref),
+	}, nil
+}
+
+// fetchFromCache fetches the requested ref into the cached clone of
+// opts.Repo and reads the specification from it. The clone is created on
+// first use. Local changes in the clone are discarded, so a cache left dirty
+// or on another ref by an earlier run is reused as is.
+func fetchFromCache(ctx context.Context, opts Options) (*Result, error) {
+	ref := opts.Ref
+	if ref == "" {
+		ref = DefaultRef
+	}
+	dir := filepath.Join(opts.CacheDir, cacheKey(opts.Repo))
+
+	fetchCtx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+
+	// A directory that is not the top of its own repository, such as one
+	// left by an interrupted first run, is cloned again. Checking for
+	// ".git" keeps git from resolving an enclosing repository instead.
+	if gitDir, err := runGit(fetchCtx, dir, "rev-parse", "--git-dir"); err != nil || gitDir != ".git" {
+		opts.Logger.Debug("creating cached clone", "repo", opts.Repo, "dir", dir)
+		if err := os.RemoveAll(dir); err != nil {
+			return nil, fmt.Errorf("reset cache: %w", err)
+		}
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("create cache dir: %w", err)
+		}
+		if _, err := runGit(fetchCtx, dir, "init", "--quiet"); err != nil {
+			return nil, err
+		}
+		if _, err := runGit(fetchCtx, dir, "remote", "add", "origin", opts.Repo); err != nil {
+			return nil, err
+		}
+	} else if url, _ := runGit(fetchCtx, dir, "remote", "get-url", "origin"); url != opts.Repo {
+		if _, err := runGit(fetchCtx, dir, "remote", "set-url", "origin", opts.Repo); err != nil {
+			return nil, err
+		}
+	}
+
+	opts.Logger.Debug("fetching into cached clone", "repo", opts.Repo, "ref", ref, "dir", dir)
+	steps := [][]string{
+		{"sparse-checkout", "set", path.Dir(opts.MetaModelPath)},
+		{"fetch", "--quiet", "--depth=1", "--filter=blob:none", "origin", ref},
+		{"checkout", "--quiet", "--force", "--detach", "FETCH_HEAD"},
+		{"clean", "--quiet", "-d", "--force", "-x"},
+	}
+	for _, args := range steps {
+		if _, err := runGit(fetchCtx, dir, args...); err != nil {
+			return nil, err
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(opts.MetaModelPath)))
+	if err != nil {
+		return nil, fmt.Errorf("read metaModel.json: %w", err)
+	}
+	if err := verifySHA256(data, opts.ExpectedSHA256); err != nil {
+		return nil, err
+	}
+
+	m, err := parseModel(data, !opts.DropLineNumbers)
+	if err != nil {
+		return nil, fmt.Errorf("parse model: %w", err)
+	}
+
+	return &Result{
+		Model:      m,
+		Ref:        ref,
+		CommitHash: getGitHash(dir),
+		Source:     fmt.Sprintf("%s@%s", opts.Repo, ref),
 	}, nil
 }
 
-// parseModel parses metaModel.json with line number injection for debugging.
-func parseModel(data []byte) (*model.Model, error) {
-	// Inject line numbers into JSON for debugging
-	data = injectLineNumbers(data)
+// cacheKey returns the directory name of repo's clone within a cache
+// directory, such as "github.com_microsoft_vscode-languageserver-node".
+func cacheKey(repo string) string {
+	if _, rest, ok := strings.Cut(repo, "://"); ok {
+		repo = rest
+	}
+	repo = strings.TrimSuffix(strings.TrimSuffix(repo, "/"), ".git")
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '.':
+			return r
+		}
+		return '_'
+	}, repo)
+}
+
+// runGit runs git in dir and returns its trimmed standard output.
+func runGit(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", dir}, args...)...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("git %s: %w (stderr: %s)", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// verifySHA256 returns an error wrapping ErrChecksumMismatch unless data
+// hashes to want, the hex-encoded SHA-256. An empty want accepts any data.
+func verifySHA256(data []byte, want string) error {
+	if want == "" {
+		return nil
+	}
+	if len(want) != 2*sha256.Size || !isHex(want) {
+		return fmt.Errorf("expected SHA-256 %q is not %d hex digits", want, 2*sha256.Size)
+	}
+	sum := sha256.Sum256(data)
+	if got := hex.EncodeToString(sum[:]); !strings.EqualFold(got, want) {
+		return fmt.Errorf("%w: got SHA-256 %s, want %s", ErrChecksumMismatch, got, want)
+	}
+	return nil
+}
+
+// parseModel parses metaModel.json, with line number injection for
+// debugging if lines is set.
+func parseModel(data []byte, lines bool) (*model.Model, error) {
+	if lines {
+		data = injectLineNumbers(data)
+	}
 
 	var m model.Model
 	if err := json.Unmarshal(data, &m); err != nil {
@@ -203,20 +543,28 @@ func parseModel(data []byte) (*model.Model, error) {
 
 // injectLineNumbers adds a "line" field to each JSON object.
 // This helps with debugging by tracking source locations.
+// Braces inside string values are not object openings and are left alone.
 func injectLineNumbers(data []byte) []byte {
 	var result []byte
 	lineNum := 1
+	inString, escaped := false, false
 
 	for i := range len(data) {
 		result = append(result, data[i])
-		switch data[i] {
-		case '{':
-			// Only inject if followed by newline (not inline objects in strings)
+		switch {
+		case data[i] == '\n':
+			lineNum++
+		case escaped:
+			escaped = false
+		case inString && data[i] == '\\':
+			escaped = true
+		case data[i] == '"':
+			inString = !inString
+		case !inString && data[i] == '{':
+			// Only inject if followed by newline (not inline objects)
 			if i+1 < len(data) && data[i+1] == '\n' {
 				result = append(result, fmt.Sprintf(`"line":%d,`, lineNum)...)
 			}
-		case '\n':
-			lineNum++
 		}
 	}
 	return result
@@ -269,11 +617,27 @@ func isHex(s string) bool {
 // Raw fetches the raw metaModel.json content via HTTP (for quick access).
 // This is faster than cloning but doesn't provide commit hash.
 func Raw(ctx context.Context, ref string) ([]byte, error) {
+	return FetchRaw(ctx, Options{Ref: ref})
+}
+
+// FetchRaw is like Raw but honors opts.Repo, opts.MetaModelPath, and
+// opts.ExpectedSHA256. An empty ref falls back to RefEnv and then
+// DefaultRef. Only GitHub remotes are supported. FetchRaw returns
+// ErrOffline if opts.Offline is set.
+func FetchRaw(ctx context.Context, opts Options) ([]byte, error) {
+	opts = opts.withDefaults()
+	if opts.Offline {
+		return nil, ErrOffline
+	}
+	ref := opts.Ref
 	if ref == "" {
 		ref = DefaultRef
 	}
 
-	url := fmt.Sprintf("https://raw.githubusercontent.com/microsoft/vscode-languageserver-node/%s/%s", ref, MetaModelPath)
+	url, err := rawURL(opts.Repo, ref, opts.MetaModelPath)
+	if err != nil {
+		return nil, err
+	}
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
 	if err != nil {
@@ -290,5 +654,23 @@ func Raw(ctx context.Context, ref string) ([]byte, error) {
 		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Status)
 	}
 
-	return io.ReadAll(resp.Body)
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifySHA256(data, opts.ExpectedSHA256); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// rawURL returns the raw.githubusercontent.com URL of file at ref in a
+// GitHub repository.
+func rawURL(repo, ref, file string) (string, error) {
+	slug, ok := strings.CutPrefix(repo, "https://github.com/")
+	if !ok {
+		return "", fmt.Errorf("raw fetch needs a https://github.com/ remote, got %q", repo)
+	}
+	slug = strings.TrimSuffix(strings.TrimSuffix(slug, "/"), ".git")
+	return fmt.Sprintf("https://raw.githubusercontent.com/%s/%s/%s", slug, ref, file), nil
 }
@@ -7,8 +7,22 @@
 package fetch
 
 import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"maps"
+	"net/http"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"reflect"
+	"slices"
 	"strings"
 	"testing"
 )
@@ -132,6 +146,21 @@ func TestInjectLineNumbers(t *testing.T) {
 			input: "\n\n\n{\n\"key\": 1\n}",
 			want:  "\n\n\n{\"line\":4,\n\"key\": 1\n}",
 		},
+		{
+			name:  "brace and newline inside string",
+			input: "{\n\"documentation\": \"Example: {\n  a\n}\"\n}",
+			want:  "{\"line\":1,\n\"documentation\": \"Example: {\n  a\n}\"\n}",
+		},
+		{
+			name:  "escaped quote before brace in string",
+			input: "{\n\"documentation\": \"say \\\"{\n\\\" here\",\n\"next\": {\n\"a\": 1\n}\n}",
+			want:  "{\"line\":1,\n\"documentation\": \"say \\\"{\n\\\" here\",\n\"next\": {\"line\":4,\n\"a\": 1\n}\n}",
+		},
+		{
+			name:  "escaped backslash ends string",
+			input: "[\"a\\\\\", {\n\"b\": 1\n}]",
+			want:  "[\"a\\\\\", {\"line\":1,\n\"b\": 1\n}]",
+		},
 	}
 
 	for _, tt := range tests {
@@ -163,7 +192,7 @@ func TestParseModel(t *testing.T) {
 }`,
 			wantErr: false,
 			check: func(t *testing.T, input string) {
-				m, err := parseModel([]byte(input))
+				m, err := parseModel([]byte(input), true)
 				if err != nil {
 					t.Fatalf("unexpected error: %v", err)
 				}
@@ -184,7 +213,7 @@ func TestParseModel(t *testing.T) {
 }`,
 			wantErr: false,
 			check: func(t *testing.T, input string) {
-				m, err := parseModel([]byte(input))
+				m, err := parseModel([]byte(input), true)
 				if err != nil {
 					t.Fatalf("unexpected error: %v", err)
 				}
@@ -208,7 +237,7 @@ func TestParseModel(t *testing.T) {
 }`,
 			wantErr: false,
 			check: func(t *testing.T, input string) {
-				m, err := parseModel([]byte(input))
+				m, err := parseModel([]byte(input), true)
 				if err != nil {
 					t.Fatalf("unexpected error: %v", err)
 				}
@@ -251,7 +280,7 @@ func TestParseModel(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, err := parseModel([]byte(tt.input))
+			_, err := parseModel([]byte(tt.input), true)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("parseModel() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -263,6 +292,63 @@ func TestParseModel(t *testing.T) {
 	}
 }
 
+func TestParseModelLineNumbers(t *testing.T) {
+	input := `{
+"metaData": {"version": "3.17.0"},
+"structures": [
+  {
+    "name": "Position",
+    "properties": [
+      {
+        "name": "line",
+        "type": {"kind": "base", "name": "uinteger"}
+      }
+    ]
+  }
+],
+"enumerations": [],
+"typeAliases": []
+}`
+	withLines, err := parseModel([]byte(input), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	without, err := parseModel([]byte(input), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := withLines.Structures[0].Line; got != 4 {
+		t.Errorf("with line numbers: structure line = %d, want 4", got)
+	}
+	if got := without.Structures[0].Line; got != 0 {
+		t.Errorf("without line numbers: structure line = %d, want 0", got)
+	}
+	if reflect.DeepEqual(withLines, without) {
+		t.Error("line numbers did not change the model")
+	}
+	withLines.Line = 0
+	withLines.Structures[0].Line = 0
+	withLines.Structures[0].Properties[0].Line = 0
+	if !reflect.DeepEqual(withLines, without) {
+		t.Errorf("models differ in more than line numbers:\n%+v\n%+v", withLines, without)
+	}
+
+	// Without line numbers, the model depends only on the JSON value, not
+	// on how it is formatted.
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, []byte(input)); err != nil {
+		t.Fatal(err)
+	}
+	reformatted, err := parseModel(compact.Bytes(), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(reformatted, without) {
+		t.Errorf("reformatted spec parsed differently:\n%+v\n%+v", reformatted, without)
+	}
+}
+
 func TestFetchFromFile(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -398,7 +484,7 @@ func TestFetchFromFile(t *testing.T) {
 			dir := t.TempDir()
 			path := tt.setup(dir)
 
-			result, err := fetchFromFile(path)
+			result, err := fetchFromFile(Options{LocalPath: path})
 			if (err != nil) != tt.wantErr {
 				t.Errorf("fetchFromFile() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -556,6 +642,132 @@ func TestGetGitHash(t *testing.T) {
 	}
 }
 
+func TestFetchFromArchive(t *testing.T) {
+	spec := []byte(`{
+"metaData": {"version": "3.17.0"},
+"structures": [],
+"enumerations": [],
+"typeAliases": []
+}`)
+	dir := t.TempDir()
+
+	writeTarGz := func(name string, files map[string][]byte) string {
+		t.Helper()
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		tw := tar.NewWriter(gz)
+		for _, file := range slices.Sorted(maps.Keys(files)) {
+			hdr := &tar.Header{Name: file, Mode: 0o644, Size: int64(len(files[file])), Typeflag: tar.TypeReg}
+			if err := tw.WriteHeader(hdr); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := tw.Write(files[file]); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := tw.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if err := gz.Close(); err != nil {
+			t.Fatal(err)
+		}
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	writeZip := func(name string, files map[string][]byte) string {
+		t.Helper()
+		var buf bytes.Buffer
+		zw := zip.NewWriter(&buf)
+		for _, file := range slices.Sorted(maps.Keys(files)) {
+			w, err := zw.Create(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := w.Write(files[file]); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := zw.Close(); err != nil {
+			t.Fatal(err)
+		}
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	notGzip := filepath.Join(dir, "bad.tar.gz")
+	if err := os.WriteFile(notGzip, []byte("not gzip"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		opts    Options
+		wantErr bool
+	}{
+		{
+			name: "tar.gz under a top-level directory",
+			path: writeTarGz("spec.tar.gz", map[string][]byte{
+				"vscode-languageserver-node-main/README.md":               []byte("readme"),
+				"vscode-languageserver-node-main/protocol/metaModel.json": spec,
+			}),
+		},
+		{
+			name: "tgz at the root",
+			path: writeTarGz("spec.tgz", map[string][]byte{"protocol/metaModel.json": spec}),
+		},
+		{
+			name: "zip with a custom member path",
+			path: writeZip("spec.zip", map[string][]byte{"mirror/spec/lsp/metaModel.json": spec}),
+			opts: Options{MetaModelPath: "spec/lsp/metaModel.json"},
+		},
+		{
+			name:    "missing member",
+			path:    writeZip("other.zip", map[string][]byte{"metaModel.json": spec}),
+			wantErr: true,
+		},
+		{
+			name:    "nested too deep",
+			path:    writeTarGz("deep.tar.gz", map[string][]byte{"a/b/protocol/metaModel.json": spec}),
+			wantErr: true,
+		},
+		{
+			name:    "not an archive",
+			path:    notGzip,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.opts.LocalPath = tt.path
+			result, err := Fetch(context.Background(), tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Fetch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := result.Model.Version.Version; got != "3.17.0" {
+				t.Errorf("version = %q, want %q", got, "3.17.0")
+			}
+			if want := "archive://" + tt.path; result.Source != want {
+				t.Errorf("source = %q, want %q", result.Source, want)
+			}
+		})
+	}
+
+	path := writeZip("pinned.zip", map[string][]byte{"protocol/metaModel.json": spec})
+	_, err := Fetch(context.Background(), Options{LocalPath: path, ExpectedSHA256: strings.Repeat("0", 64)})
+	if !errors.Is(err, ErrChecksumMismatch) {
+		t.Errorf("Fetch() with mismatched checksum error = %v, want ErrChecksumMismatch", err)
+	}
+}
+
 func TestFetchFromRepo(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -677,7 +889,7 @@ func TestFetchFromRepo(t *testing.T) {
 			dir := t.TempDir()
 			tt.setup(dir)
 
-			result, err := fetchFromRepo(dir, tt.ref)
+			result, err := fetchFromRepo(Options{RepoDir: dir, Ref: tt.ref, MetaModelPath: MetaModelPath})
 			if (err != nil) != tt.wantErr {
 				t.Errorf("fetchFromRepo() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -693,3 +905,365 @@ func TestFetchFromRepo(t *testing.T) {
 		})
 	}
 }
+
+func TestFetchFromGitLocalRemote(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not in PATH")
+	}
+
+	// Build a minimal "mirror" with the spec at a non-default path.
+	remote := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", remote}, args...)...)
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	specDir := filepath.Join(remote, "spec", "lsp")
+	if err := os.MkdirAll(specDir, 0755); err != nil {
+		t.Fatalf("failed to create spec dir: %v", err)
+	}
+	content := `{
+"metaData": {"version": "3.18.0-fork"},
+"requests": [],
+"notifications": [],
+"structures": [],
+"enumerations": [],
+"typeAliases": []
+}`
+	if err := os.WriteFile(filepath.Join(specDir, "metaModel.json"), []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write metaModel.json: %v", err)
+	}
+	git("init", "--quiet", "--initial-branch=fork")
+	git("add", ".")
+	git("commit", "--quiet", "-m", "spec")
+
+	repo := "file://" + filepath.ToSlash(remote)
+	result, err := Fetch(context.Background(), Options{
+		Ref:           "fork",
+		Repo:          repo,
+		MetaModelPath: "spec/lsp/metaModel.json",
+	})
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if result.Model.Version.Version != "3.18.0-fork" {
+		t.Errorf("version = %q, want %q", result.Model.Version.Version, "3.18.0-fork")
+	}
+	if result.Source != repo+"@fork" {
+		t.Errorf("source = %q, want %q", result.Source, repo+"@fork")
+	}
+	if len(result.CommitHash) != 40 {
+		t.Errorf("commitHash = %q, want a 40-character hash", result.CommitHash)
+	}
+
+	sum := sha256.Sum256([]byte(content))
+	opts := Options{
+		Ref:            "fork",
+		Repo:           repo,
+		MetaModelPath:  "spec/lsp/metaModel.json",
+		ExpectedSHA256: hex.EncodeToString(sum[:]),
+	}
+	if _, err := Fetch(context.Background(), opts); err != nil {
+		t.Errorf("Fetch() with matching checksum error = %v", err)
+	}
+	opts.ExpectedSHA256 = strings.Repeat("0", 64)
+	if _, err := Fetch(context.Background(), opts); !errors.Is(err, ErrChecksumMismatch) {
+		t.Errorf("Fetch() with mismatched checksum error = %v, want ErrChecksumMismatch", err)
+	}
+}
+
+func TestVerifySHA256(t *testing.T) {
+	data := []byte(`{"metaData": {"version": "3.17.0"}}`)
+	sum := sha256.Sum256(data)
+	want := hex.EncodeToString(sum[:])
+	tests := []struct {
+		name     string
+		want     string
+		mismatch bool
+		wantErr  bool
+	}{
+		{"unset", "", false, false},
+		{"match", want, false, false},
+		{"match upper case", strings.ToUpper(want), false, false},
+		{"mismatch", strings.Repeat("ab", 32), true, true},
+		{"too short", want[:63], false, true},
+		{"not hex", strings.Repeat("g", 64), false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := verifySHA256(data, tt.want)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("verifySHA256() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := errors.Is(err, ErrChecksumMismatch); got != tt.mismatch {
+				t.Errorf("errors.Is(err, ErrChecksumMismatch) = %v, want %v", got, tt.mismatch)
+			}
+		})
+	}
+
+	// LocalPath is verified too.
+	spec := filepath.Join(t.TempDir(), "metaModel.json")
+	if err := os.WriteFile(spec, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	_, err := Fetch(context.Background(), Options{LocalPath: spec, ExpectedSHA256: strings.Repeat("ab", 32)})
+	if !errors.Is(err, ErrChecksumMismatch) {
+		t.Errorf("Fetch(LocalPath) error = %v, want ErrChecksumMismatch", err)
+	}
+}
+
+func TestRawURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		repo    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "default repo",
+			repo: VSCodeRepo,
+			want: "https://raw.githubusercontent.com/microsoft/vscode-languageserver-node/main/protocol/metaModel.json",
+		},
+		{
+			name: "fork with .git suffix",
+			repo: "https://github.com/acme/vscode-languageserver-node.git",
+			want: "https://raw.githubusercontent.com/acme/vscode-languageserver-node/main/protocol/metaModel.json",
+		},
+		{
+			name:    "non-GitHub mirror",
+			repo:    "https://git.example.com/mirrors/vscode-languageserver-node",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := rawURL(tt.repo, "main", MetaModelPath)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("rawURL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("rawURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFetchFromCache(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not in PATH")
+	}
+
+	// A working repository pushes to a bare remote; Fetch only sees the
+	// bare remote.
+	work := t.TempDir()
+	remote := t.TempDir()
+	git := func(dir string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	commitSpec := func(version string) {
+		t.Helper()
+		content := `{"metaData": {"version": "` + version + `"}}`
+		if err := os.MkdirAll(filepath.Join(work, "protocol"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(work, "protocol", "metaModel.json"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		git(work, "add", ".")
+		git(work, "commit", "--quiet", "-m", version)
+		git(work, "push", "--quiet", "--tags", "origin", "main")
+	}
+	git(remote, "init", "--quiet", "--bare")
+	git(work, "init", "--quiet", "--initial-branch=main")
+	git(work, "remote", "add", "origin", remote)
+	commitSpec("3.17.0")
+	git(work, "tag", "v3.17")
+	git(work, "push", "--quiet", "origin", "v3.17")
+
+	repo := "file://" + filepath.ToSlash(remote)
+	cacheDir := t.TempDir()
+	fetch := func(ref, want string) string {
+		t.Helper()
+		result, err := Fetch(context.Background(), Options{Ref: ref, Repo: repo, CacheDir: cacheDir})
+		if err != nil {
+			t.Fatalf("Fetch(%q) error = %v", ref, err)
+		}
+		if got := result.Model.Version.Version; got != want {
+			t.Errorf("Fetch(%q) version = %q, want %q", ref, got, want)
+		}
+		if result.Source != repo+"@"+ref {
+			t.Errorf("Fetch(%q) source = %q, want %q", ref, result.Source, repo+"@"+ref)
+		}
+		if len(result.CommitHash) != 40 {
+			t.Errorf("Fetch(%q) commitHash = %q, want a 40-character hash", ref, result.CommitHash)
+		}
+		return result.CommitHash
+	}
+
+	first := fetch("v3.17", "3.17.0")
+
+	clone := filepath.Join(cacheDir, cacheKey(repo))
+	if _, err := os.Stat(filepath.Join(clone, ".git")); err != nil {
+		t.Fatalf("cached clone not created: %v", err)
+	}
+
+	// Leave the cache dirty, then fetch a ref pushed after it was created.
+	if err := os.WriteFile(filepath.Join(clone, "protocol", "metaModel.json"), []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(clone, "protocol", "stray.json"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	commitSpec("3.18.0")
+	if second := fetch("main", "3.18.0"); second == first {
+		t.Errorf("commitHash did not change after fetching a new ref")
+	}
+	if _, err := os.Stat(filepath.Join(clone, "protocol", "stray.json")); !os.IsNotExist(err) {
+		t.Errorf("untracked file survived fetch: %v", err)
+	}
+
+	// Going back to the older ref reuses the same clone.
+	if again := fetch("v3.17", "3.17.0"); again != first {
+		t.Errorf("commitHash = %q, want %q", again, first)
+	}
+	entries, err := os.ReadDir(cacheDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("cache holds %d entries, want 1", len(entries))
+	}
+}
+
+func TestFetchOffline(t *testing.T) {
+	dir := t.TempDir()
+	cacheDir := filepath.Join(dir, "cache")
+	tests := []struct {
+		name string
+		opts Options
+	}{
+		{"no source", Options{}},
+		{"cache dir", Options{CacheDir: cacheDir}},
+		{"custom remote", Options{Repo: "https://example.invalid/spec.git", Ref: "main"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.opts.Offline = true
+			_, err := Fetch(context.Background(), tt.opts)
+			if !errors.Is(err, ErrOffline) {
+				t.Fatalf("Fetch() error = %v, want ErrOffline", err)
+			}
+		})
+	}
+	if _, err := os.Stat(cacheDir); !os.IsNotExist(err) {
+		t.Errorf("offline Fetch touched the cache dir: %v", err)
+	}
+
+	spec := filepath.Join(dir, "metaModel.json")
+	if err := os.WriteFile(spec, []byte(`{"metaData": {"version": "3.17.0"}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	result, err := Fetch(context.Background(), Options{LocalPath: spec, CacheDir: cacheDir, Offline: true})
+	if err != nil {
+		t.Fatalf("Fetch(LocalPath) error = %v", err)
+	}
+	if got := result.Model.Version.Version; got != "3.17.0" {
+		t.Errorf("version = %q, want %q", got, "3.17.0")
+	}
+}
+
+// roundTripFunc adapts a function to http.RoundTripper.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestFetchRawOffline(t *testing.T) {
+	transport := http.DefaultClient.Transport
+	t.Cleanup(func() { http.DefaultClient.Transport = transport })
+	http.DefaultClient.Transport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		t.Errorf("offline FetchRaw requested %s", req.URL)
+		return nil, errors.New("network access")
+	})
+
+	_, err := FetchRaw(context.Background(), Options{Ref: "main", Offline: true})
+	if !errors.Is(err, ErrOffline) {
+		t.Fatalf("FetchRaw() error = %v, want ErrOffline", err)
+	}
+}
+
+func TestFetchEnv(t *testing.T) {
+	spec := filepath.Join(t.TempDir(), "metaModel.json")
+	if err := os.WriteFile(spec, []byte(`{"metaData": {"version": "3.17.0"}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(SpecEnv, spec)
+	t.Setenv(RefEnv, "release/protocol/3.18.0")
+
+	result, err := Fetch(context.Background(), Options{Offline: true})
+	if err != nil {
+		t.Fatalf("Fetch() with %s error = %v", SpecEnv, err)
+	}
+	if got, want := result.Source, "file://"+spec; got != want {
+		t.Errorf("source = %q, want %q", got, want)
+	}
+
+	// An explicit ref or repository beats the spec from the environment.
+	for _, opts := range []Options{{Ref: "main"}, {RepoDir: t.TempDir()}} {
+		opts.Offline = true
+		if got := opts.withDefaults().LocalPath; got != "" {
+			t.Errorf("withDefaults(%+v).LocalPath = %q, want empty", opts, got)
+		}
+	}
+}
+
+func TestWithDefaultsRef(t *testing.T) {
+	tests := []struct {
+		name string
+		env  string
+		ref  string
+		want string
+	}{
+		{"flag beats env", "release/protocol/3.18.0", "main", "main"},
+		{"env beats default", "release/protocol/3.18.0", "", "release/protocol/3.18.0"},
+		{"no env", "", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(RefEnv, tt.env)
+			t.Setenv(SpecEnv, "")
+			if got := (Options{Ref: tt.ref}).withDefaults().Ref; got != tt.want {
+				t.Errorf("withDefaults().Ref = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCacheKey(t *testing.T) {
+	tests := []struct {
+		repo string
+		want string
+	}{
+		{VSCodeRepo, "github.com_microsoft_vscode-languageserver-node"},
+		{"https://github.com/acme/vscode-languageserver-node.git", "github.com_acme_vscode-languageserver-node"},
+		{"git@example.com:mirrors/lsp.git", "git_example.com_mirrors_lsp"},
+		{"file:///tmp/remote/", "_tmp_remote"},
+	}
+	for _, tt := range tests {
+		if got := cacheKey(tt.repo); got != tt.want {
+			t.Errorf("cacheKey(%q) = %q, want %q", tt.repo, got, tt.want)
+		}
+	}
+}
@@ -6,6 +6,11 @@
 
 package generator
 
+import (
+	"log/slog"
+	"time"
+)
+
 // Config contains generator configuration.
 type Config struct {
 	// OutputDir is the output directory.
@@ -17,12 +22,51 @@ type Config struct {
 	// Types filters to specific type names (empty = all).
 	Types []string
 
+	// Methods limits the Server and Client interfaces, for targets that
+	// generate them, to these requests and notifications. Callers also add
+	// the types of the methods, from MethodTypes, to Types.
+	Methods []string
+
+	// TypePrefix and TypeSuffix are added to generated type names, for
+	// targets that support them. JSON property names are unchanged.
+	TypePrefix string
+	TypeSuffix string
+
+	// Acronyms are words cased the same way wherever they appear in an
+	// identifier derived from an LSP name, such as "URI" for DocumentURI
+	// rather than DocumentUri, or document_uri rather than document_u_r_i,
+	// for the Go, Kotlin, Groovy, Zig, and Proto targets.
+	Acronyms []string
+
+	// NameOverrides maps an LSP type, property, enumeration value, or
+	// method name to the identifier generated for it, for the same targets.
+	// Snake case names, such as Proto fields, are not overridden.
+	NameOverrides map[string]string
+
 	// ResolveDeps includes transitive dependencies when filtering.
 	ResolveDeps bool
 
 	// IncludeProposed includes @proposed features.
 	IncludeProposed bool
 
+	// MinifyDocs omits documentation comments, keeping only
+	// @since and @deprecated annotations.
+	MinifyDocs bool
+
+	// StrictJSONNames fails generation when more than one property of a
+	// structure has the same JSON name once its extends and mixins are
+	// flattened into it, or embedded in it for Go. Otherwise the targets
+	// keep the most-derived property and log a warning.
+	StrictJSONNames bool
+
+	// Indent is the indentation unit, such as a tab or two spaces, of the
+	// Kotlin, Groovy, and Proto targets. Empty keeps the target's own.
+	Indent string
+
+	// Index adds an index of the generated types to directory output:
+	// doc.go for Go, IndexFile for other targets.
+	Index bool
+
 	// GenerateClient generates client interface.
 	GenerateClient bool
 
@@ -41,8 +85,18 @@ type Config struct {
 	// LSPVersion is the protocol version.
 	LSPVersion string
 
+	// ToolVersion is the version of lspls, for headers.
+	ToolVersion string
+
+	// Timestamp is the generation time, for headers. Zero omits it, which
+	// keeps the output reproducible.
+	Timestamp time.Time
+
 	// Options contains target-specific options.
 	Options map[string]string
+
+	// Logger receives progress and warnings. If nil, nothing is logged.
+	Logger *slog.Logger
 }
 
 // Option returns a target-specific option with default.
@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package generator
+
+import (
+	"fmt"
+
+	"github.com/albertocavalcante/lspls/internal/lspbase"
+	"github.com/albertocavalcante/lspls/model"
+)
+
+// ResolveEnumBases returns m with the type of every enumeration whose base
+// is a reference replaced by the base type that the reference resolves to
+// through type aliases, so that generators only see base types. It fails if
+// such a reference does not resolve to a string or integer base type, with
+// an *UnresolvedTypeError. m is not modified; if no enumeration has a
+// reference base, m is returned.
+func ResolveEnumBases(m *model.Model) (*model.Model, error) {
+	aliases := make(map[string]*model.TypeAlias, len(m.TypeAliases))
+	for _, a := range m.TypeAliases {
+		aliases[a.Name] = a
+	}
+	var resolved *model.Model
+	for i, e := range m.Enumerations {
+		if e.Type == nil || e.Type.Kind != "reference" {
+			continue
+		}
+		base, err := resolveEnumBase(aliases, e)
+		if err != nil {
+			return nil, err
+		}
+		if resolved == nil {
+			clone := *m
+			clone.Enumerations = append([]*model.Enumeration(nil), m.Enumerations...)
+			resolved = &clone
+		}
+		enum := *e
+		enum.Type = base
+		resolved.Enumerations[i] = &enum
+	}
+	if resolved == nil {
+		return m, nil
+	}
+	return resolved, nil
+}
+
+// resolveEnumBase follows the reference base of enumeration e through type
+// aliases to a string or integer base type.
+func resolveEnumBase(aliases map[string]*model.TypeAlias, e *model.Enumeration) (*model.Type, error) {
+	t := e.Type
+	seen := make(map[string]bool)
+	for t != nil && t.Kind == "reference" {
+		a, ok := aliases[t.Name]
+		if !ok {
+			return nil, fmt.Errorf("enumeration %s: base %w", e.Name, &UnresolvedTypeError{Name: t.Name, Line: t.Line, Reason: "is not a type alias"})
+		}
+		if seen[a.Name] {
+			return nil, fmt.Errorf("enumeration %s: base %w", e.Name, &UnresolvedTypeError{Name: a.Name, Line: a.Line, Reason: "refers to itself"})
+		}
+		seen[a.Name] = true
+		t = a.Type
+	}
+	if t == nil || t.Kind != "base" || !isEnumBase(t.Name) {
+		return nil, fmt.Errorf("enumeration %s: base %w", e.Name, &UnresolvedTypeError{Name: e.Type.Name, Line: e.Type.Line, Reason: "does not resolve to a string or integer"})
+	}
+	return t, nil
+}
+
+// isEnumBase reports whether enumerations can have the named base type.
+func isEnumBase(name string) bool {
+	return lspbase.IsStringLike(name) || name == lspbase.TypeInteger || name == lspbase.TypeUinteger
+}
@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: MIT
+
+package generator
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/albertocavalcante/lspls/model"
+)
+
+func TestResolveEnumBases(t *testing.T) {
+	base := func(name string) *model.Type { return &model.Type{Kind: "base", Name: name} }
+	ref := func(name string) *model.Type { return &model.Type{Kind: "reference", Name: name} }
+	m := &model.Model{
+		Enumerations: []*model.Enumeration{
+			{Name: "Kind", Type: base("string")},
+			{Name: "Level", Type: ref("Severity")},
+		},
+		TypeAliases: []*model.TypeAlias{
+			{Name: "Severity", Type: ref("Number")},
+			{Name: "Number", Type: base("uinteger")},
+		},
+	}
+
+	got, err := ResolveEnumBases(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if typ := got.Enumerations[1].Type; typ.Kind != "base" || typ.Name != "uinteger" {
+		t.Errorf("Level base = %s %s, want base uinteger", typ.Kind, typ.Name)
+	}
+	if got.Enumerations[0] != m.Enumerations[0] {
+		t.Error("enumeration with a base type was copied")
+	}
+	if m.Enumerations[1].Type.Kind != "reference" {
+		t.Error("ResolveEnumBases modified its input")
+	}
+
+	for _, tc := range []struct {
+		name    string
+		aliases []*model.TypeAlias
+		want    string
+	}{
+		{"unknown", nil, "is not a type alias"},
+		{"boolean", []*model.TypeAlias{{Name: "Severity", Type: base("boolean")}}, "does not resolve to a string or integer"},
+		{"cycle", []*model.TypeAlias{{Name: "Severity", Type: ref("Severity")}}, "refers to itself"},
+	} {
+		m := &model.Model{
+			Enumerations: []*model.Enumeration{{Name: "Level", Type: ref("Severity")}},
+			TypeAliases:  tc.aliases,
+		}
+		_, err := ResolveEnumBases(m)
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: ResolveEnumBases error = %v, want %q", tc.name, err, tc.want)
+		}
+		var unresolved *UnresolvedTypeError
+		if !errors.As(err, &unresolved) || unresolved.Name != "Severity" {
+			t.Errorf("%s: ResolveEnumBases error = %#v, want *UnresolvedTypeError for Severity", tc.name, err)
+		}
+	}
+}
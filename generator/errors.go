@@ -0,0 +1,81 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package generator
+
+import (
+	"errors"
+	"fmt"
+)
+
+// UnresolvedTypeError reports a reference to a type that does not resolve
+// to a type the generator can use, such as an undefined type or a type
+// alias that refers to itself. Callers find it with errors.As.
+type UnresolvedTypeError struct {
+	// Name is the referenced type name.
+	Name string
+
+	// Line is the line of the reference in metaModel.json, or 0 if the
+	// model was parsed without line numbers.
+	Line int
+
+	// Reason says why the reference does not resolve. If empty, the type
+	// is not defined.
+	Reason string
+}
+
+func (e *UnresolvedTypeError) Error() string {
+	reason := e.Reason
+	if reason == "" {
+		reason = "is not defined"
+	}
+	return fmt.Sprintf("type %s %s", e.Name, reason)
+}
+
+// UnsupportedKindError reports a type kind that the generator cannot
+// represent in its target language. Callers find it with errors.As.
+type UnsupportedKindError struct {
+	// Kind is the kind of the type, such as "tuple".
+	Kind string
+
+	// Line is the line of the type in metaModel.json, or 0 if the model
+	// was parsed without line numbers.
+	Line int
+}
+
+func (e *UnsupportedKindError) Error() string {
+	return "unsupported type kind: " + e.Kind
+}
+
+// DuplicateJSONNameError reports a JSON name that more than one property
+// of a structure has once its extends and mixins are flattened into it or,
+// for Go, embedded in it. Callers find it with errors.As.
+type DuplicateJSONNameError struct {
+	// Structure is the name of the structure.
+	Structure string
+
+	// Name is the JSON name of the properties.
+	Name string
+}
+
+func (e *DuplicateJSONNameError) Error() string {
+	return fmt.Sprintf("structure %s: more than one property has JSON name %q", e.Structure, e.Name)
+}
+
+// ErrorLine returns the metaModel.json line recorded by the
+// UnresolvedTypeError or UnsupportedKindError in err's chain, or 0 if
+// there is none.
+func ErrorLine(err error) int {
+	var unresolved *UnresolvedTypeError
+	if errors.As(err, &unresolved) {
+		return unresolved.Line
+	}
+	var unsupported *UnsupportedKindError
+	if errors.As(err, &unsupported) {
+		return unsupported.Line
+	}
+	return 0
+}
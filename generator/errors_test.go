@@ -0,0 +1,33 @@
+// SPDX-License-Identifier: MIT
+
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestErrorLine(t *testing.T) {
+	tests := []struct {
+		err      error
+		wantMsg  string
+		wantLine int
+	}{
+		{
+			fmt.Errorf("enumeration Level: base %w", &UnresolvedTypeError{Name: "Severity", Line: 12, Reason: "is not a type alias"}),
+			"enumeration Level: base type Severity is not a type alias", 12,
+		},
+		{&UnresolvedTypeError{Name: "Missing"}, "type Missing is not defined", 0},
+		{fmt.Errorf("map key: %w", &UnsupportedKindError{Kind: "tuple", Line: 7}), "map key: unsupported type kind: tuple", 7},
+		{errors.New("other"), "other", 0},
+	}
+	for _, tt := range tests {
+		if got := tt.err.Error(); got != tt.wantMsg {
+			t.Errorf("Error() = %q, want %q", got, tt.wantMsg)
+		}
+		if got := ErrorLine(tt.err); got != tt.wantLine {
+			t.Errorf("ErrorLine(%q) = %d, want %d", tt.err, got, tt.wantLine)
+		}
+	}
+}
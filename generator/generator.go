@@ -39,3 +39,40 @@ type Metadata struct {
 	// URL is the homepage/documentation URL (optional).
 	URL string
 }
+
+// Describer is implemented by generators that document what they accept
+// and produce. It is optional: callers type-assert for it and fall back
+// to Metadata alone.
+type Describer interface {
+	// Describe returns the generator's options, outputs, and requirements.
+	Describe() Description
+}
+
+// Description documents a generator beyond its Metadata.
+type Description struct {
+	// Options lists the generator-specific keys read from Config.Options.
+	Options []OptionInfo
+
+	// Outputs lists the files the generator can produce, with a note on
+	// when each is written (e.g., "server.go: directory output").
+	Outputs []string
+
+	// Requirements lists what the generated code assumes of its consumer
+	// (e.g., "Kotlin 1.9+", "kotlinx.serialization").
+	Requirements []string
+}
+
+// OptionInfo describes one key of Config.Options.
+type OptionInfo struct {
+	// Key is the Config.Options key (e.g., "equal").
+	Key string
+
+	// Flag is the lspls flag that sets the option, if any (e.g., "--equal").
+	Flag string
+
+	// Default is the value used when the key is unset.
+	Default string
+
+	// Description is a one-line summary.
+	Description string
+}
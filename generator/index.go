@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package generator
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/albertocavalcante/lspls/model"
+)
+
+// IndexFile is the name of the Markdown index added to directory output
+// when Config.Index is set, for targets without a native equivalent.
+const IndexFile = "index.md"
+
+// IndexEntry describes one generated type in an index file.
+type IndexEntry struct {
+	// Name is the type name as it appears in the specification.
+	Name string
+
+	// Kind is "structure", "enumeration", or "type alias".
+	Kind string
+
+	// Summary is the first paragraph of the type's documentation, joined
+	// into a single line.
+	Summary string
+
+	// Since is the version that introduced the type, if known.
+	Since string
+
+	// Line is the type's line in metaModel.json, or 0 if unknown.
+	Line int
+}
+
+// Index returns an entry for every type of m that cfg selects, sorted by
+// name. It applies the same type filter, dependency resolution, and
+// proposed filtering as the generators.
+func Index(m *model.Model, cfg Config) []IndexEntry {
+	var filter map[string]bool
+	if len(cfg.Types) > 0 {
+		filter = make(map[string]bool, len(cfg.Types))
+		for _, t := range cfg.Types {
+			filter[t] = true
+		}
+		if cfg.ResolveDeps {
+			filter = ResolveDeps(m, filter, cfg.IncludeProposed)
+		}
+	}
+	include := func(name string, proposed bool) bool {
+		if proposed && !cfg.IncludeProposed {
+			return false
+		}
+		return filter == nil || filter[name]
+	}
+
+	var entries []IndexEntry
+	for _, s := range m.Structures {
+		if include(s.Name, s.Proposed) {
+			entries = append(entries, IndexEntry{s.Name, "structure", Summary(s.Documentation), s.Since, s.Line})
+		}
+	}
+	for _, e := range m.Enumerations {
+		if include(e.Name, e.Proposed) {
+			entries = append(entries, IndexEntry{e.Name, "enumeration", Summary(e.Documentation), e.Since, e.Line})
+		}
+	}
+	for _, a := range m.TypeAliases {
+		if include(a.Name, a.Proposed) {
+			entries = append(entries, IndexEntry{a.Name, "type alias", Summary(a.Documentation), a.Since, a.Line})
+		}
+	}
+	slices.SortFunc(entries, func(a, b IndexEntry) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+	return entries
+}
+
+// MarkdownIndex renders entries as a Markdown document with the given
+// title, one table row per type.
+func MarkdownIndex(title string, entries []IndexEntry) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "<!-- Code generated by lspls. DO NOT EDIT. -->\n\n# %s\n\n", title)
+	buf.WriteString("| Type | Kind | Since | Line | Summary |\n")
+	buf.WriteString("|------|------|-------|------|---------|\n")
+	for _, e := range entries {
+		line := ""
+		if e.Line > 0 {
+			line = fmt.Sprint(e.Line)
+		}
+		summary := strings.ReplaceAll(e.Summary, "|", `\|`)
+		fmt.Fprintf(&buf, "| `%s` | %s | %s | %s | %s |\n", e.Name, e.Kind, e.Since, line, summary)
+	}
+	return buf.Bytes()
+}
+
+// Summary returns the first paragraph of doc joined into a single line, as
+// in the Summary of an IndexEntry.
+func Summary(doc string) string {
+	var words []string
+	for line := range strings.SplitSeq(strings.TrimSpace(doc), "\n") {
+		if strings.TrimSpace(line) == "" {
+			break
+		}
+		words = append(words, strings.Fields(line)...)
+	}
+	return strings.Join(words, " ")
+}
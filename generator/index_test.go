@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: MIT
+
+package generator
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/lspls/model"
+)
+
+func TestIndex(t *testing.T) {
+	m := &model.Model{
+		Structures: []*model.Structure{
+			{
+				Name:          "Range",
+				Documentation: "A range in a text document expressed as\n(zero-based) start and end positions.\n\nMore detail.",
+				Line:          30,
+				Properties: []model.Property{
+					{Name: "start", Type: &model.Type{Kind: "reference", Name: "Position"}},
+				},
+			},
+			{Name: "Position", Line: 12},
+			{Name: "InlineCompletionList", Proposed: true},
+			{Name: "Unrelated"},
+		},
+		Enumerations: []*model.Enumeration{
+			{Name: "InlayHintKind", Documentation: "Inlay hint kinds.", Since: "3.17.0", Line: 410},
+		},
+	}
+
+	got := Index(m, Config{Types: []string{"Range", "InlayHintKind", "InlineCompletionList"}, ResolveDeps: true})
+	want := []IndexEntry{
+		{Name: "InlayHintKind", Kind: "enumeration", Summary: "Inlay hint kinds.", Since: "3.17.0", Line: 410},
+		{Name: "Position", Kind: "structure", Line: 12},
+		{Name: "Range", Kind: "structure", Summary: "A range in a text document expressed as (zero-based) start and end positions.", Line: 30},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Index returned %d entries, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("entry %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestMarkdownIndex(t *testing.T) {
+	got := string(MarkdownIndex("LSP Types", []IndexEntry{
+		{Name: "InlayHintKind", Kind: "enumeration", Summary: "Inlay hint kinds.", Since: "3.17.0", Line: 410},
+		{Name: "LSPAny", Kind: "type alias", Summary: "object | array"},
+	}))
+	want := "<!-- Code generated by lspls. DO NOT EDIT. -->\n\n" +
+		"# LSP Types\n\n" +
+		"| Type | Kind | Since | Line | Summary |\n" +
+		"|------|------|-------|------|---------|\n" +
+		"| `InlayHintKind` | enumeration | 3.17.0 | 410 | Inlay hint kinds. |\n" +
+		"| `LSPAny` | type alias |  |  | object \\| array |\n"
+	if got != want {
+		t.Errorf("MarkdownIndex mismatch:\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package generator
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/albertocavalcante/lspls/model"
+)
+
+// MethodTypes returns the sorted names of the types that the params,
+// result, partial result, and error data of the named requests and
+// notifications refer to, for use as Config.Types. Their dependencies are
+// left to ResolveDeps. It fails if a method is not in m.
+func MethodTypes(m *model.Model, methods []string) ([]string, error) {
+	names := make(map[string]bool)
+	add := func(name string) { names[name] = true }
+	for _, method := range methods {
+		if req := findRequest(m, method); req != nil {
+			for _, t := range []*model.Type{req.Params, req.Result, req.PartialResult, req.ErrorData} {
+				typeRefs(t, add)
+			}
+			continue
+		}
+		if notif := findNotification(m, method); notif != nil {
+			typeRefs(notif.Params, add)
+			continue
+		}
+		return nil, fmt.Errorf("unknown method: %s", method)
+	}
+	return slices.Sorted(maps.Keys(names)), nil
+}
+
+func findRequest(m *model.Model, method string) *model.Request {
+	for _, req := range m.Requests {
+		if req.Method == method {
+			return req
+		}
+	}
+	return nil
+}
+
+func findNotification(m *model.Model, method string) *model.Notification {
+	for _, notif := range m.Notifications {
+		if notif.Method == method {
+			return notif
+		}
+	}
+	return nil
+}
+
+// typeRefs calls add with the name of every type t refers to directly,
+// without following the referenced types.
+func typeRefs(t *model.Type, add func(string)) {
+	if t == nil {
+		return
+	}
+	switch t.Kind {
+	case "reference":
+		add(t.Name)
+	case "array":
+		typeRefs(t.Element, add)
+	case "map":
+		typeRefs(t.Key, add)
+		if vt, ok := t.Value.(*model.Type); ok {
+			typeRefs(vt, add)
+		}
+	case "or", "and", "tuple":
+		for _, item := range t.Items {
+			typeRefs(item, add)
+		}
+	case "literal":
+		if lit, ok := t.Value.(model.Literal); ok {
+			for _, prop := range lit.Properties {
+				typeRefs(prop.Type, add)
+			}
+		}
+	}
+}
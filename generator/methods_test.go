@@ -0,0 +1,45 @@
+// SPDX-License-Identifier: MIT
+
+package generator
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/albertocavalcante/lspls/model"
+)
+
+func TestMethodTypes(t *testing.T) {
+	ref := func(name string) *model.Type { return &model.Type{Kind: "reference", Name: name} }
+	m := &model.Model{
+		Requests: []*model.Request{
+			{
+				Method: "textDocument/hover",
+				Params: ref("HoverParams"),
+				Result: &model.Type{Kind: "or", Items: []*model.Type{ref("Hover"), {Kind: "base", Name: "null"}}},
+			},
+			{
+				Method:        "textDocument/references",
+				Params:        ref("ReferenceParams"),
+				Result:        &model.Type{Kind: "array", Element: ref("Location")},
+				PartialResult: &model.Type{Kind: "array", Element: ref("Location")},
+			},
+			{Method: "shutdown"},
+		},
+		Notifications: []*model.Notification{
+			{Method: "initialized", Params: ref("InitializedParams")},
+		},
+	}
+
+	got, err := MethodTypes(m, []string{"textDocument/hover", "initialized", "shutdown"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"Hover", "HoverParams", "InitializedParams"}; !slices.Equal(got, want) {
+		t.Errorf("MethodTypes = %v, want %v", got, want)
+	}
+
+	if _, err := MethodTypes(m, []string{"textDocument/hovr"}); err == nil {
+		t.Error("MethodTypes with an unknown method succeeded")
+	}
+}
@@ -10,6 +10,39 @@ package generator
 type Output struct {
 	// Files maps filename to content.
 	Files map[string][]byte
+
+	// Stats counts what was generated. Generators that do not count
+	// leave it zero.
+	Stats Stats
+}
+
+// Stats counts the symbols a generator produced, for tools that report
+// on a run.
+type Stats struct {
+	// Structures, Enumerations, and TypeAliases count the named types of
+	// the model that were generated, by kind.
+	Structures   int `json:"structures"`
+	Enumerations int `json:"enumerations"`
+	TypeAliases  int `json:"typeAliases"`
+
+	// Unions counts the helper types generated for anonymous unions, such
+	// as Go's Or_* types.
+	Unions int `json:"unions"`
+
+	// Methods counts the requests and notifications generated.
+	Methods int `json:"methods"`
+
+	// AnyFallbacks counts the types that could not be expressed and were
+	// generated as the target's dynamic type, such as any in Go.
+	AnyFallbacks int `json:"anyFallbacks"`
+
+	// UnknownKinds counts the type kinds the generator did not recognize
+	// and generated as placeholder types rather than as AnyFallbacks.
+	UnknownKinds int `json:"unknownKinds"`
+
+	// Skipped counts the model items left out because the generator
+	// cannot represent them.
+	Skipped int `json:"skipped"`
 }
 
 // NewOutput creates a new Output.
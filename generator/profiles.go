@@ -0,0 +1,93 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package generator
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Profile is a named, predefined list of types to generate, selected with
+// the --profile flag in place of spelling the types out with -t.
+type Profile struct {
+	// Name selects the profile, such as "minimal".
+	Name string
+
+	// Description says what the profile is for.
+	Description string
+
+	// Types are the type names the profile selects. Their dependencies are
+	// resolved like those of -t.
+	Types []string
+}
+
+var (
+	profileMu sync.RWMutex
+	profiles  = map[string]Profile{
+		"minimal": {
+			Name:        "minimal",
+			Description: "Commonly used core types for small tools that don't need the whole protocol",
+			Types: []string{
+				"Position",
+				"Range",
+				"Location",
+				"TextEdit",
+				"TextDocumentIdentifier",
+				"VersionedTextDocumentIdentifier",
+				"TextDocumentItem",
+				"TextDocumentPositionParams",
+				"Diagnostic",
+				"MarkupContent",
+			},
+		},
+	}
+)
+
+// RegisterProfile adds a profile, so that programs embedding lspls can
+// offer their own type lists next to the predefined ones. It panics if a
+// profile with the same name is already registered.
+func RegisterProfile(p Profile) {
+	profileMu.Lock()
+	defer profileMu.Unlock()
+	if _, exists := profiles[p.Name]; exists {
+		panic(fmt.Sprintf("profile %q already registered", p.Name))
+	}
+	p.Types = slices.Clone(p.Types)
+	profiles[p.Name] = p
+}
+
+// LookupProfile returns the profile with the given name. It fails for an
+// unknown name with an error listing the known ones.
+func LookupProfile(name string) (Profile, error) {
+	profileMu.RLock()
+	p, ok := profiles[name]
+	profileMu.RUnlock()
+	if !ok {
+		var names []string
+		for _, p := range Profiles() {
+			names = append(names, p.Name)
+		}
+		return Profile{}, fmt.Errorf("unknown profile %q (available: %s)", name, strings.Join(names, ", "))
+	}
+	p.Types = slices.Clone(p.Types)
+	return p, nil
+}
+
+// Profiles returns all registered profiles, sorted by name.
+func Profiles() []Profile {
+	profileMu.RLock()
+	defer profileMu.RUnlock()
+	list := make([]Profile, 0, len(profiles))
+	for _, p := range profiles {
+		p.Types = slices.Clone(p.Types)
+		list = append(list, p)
+	}
+	slices.SortFunc(list, func(a, b Profile) int { return strings.Compare(a.Name, b.Name) })
+	return list
+}
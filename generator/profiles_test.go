@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package generator
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestProfiles(t *testing.T) {
+	minimal, err := LookupProfile("minimal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"Position", "Range", "Location", "TextEdit", "Diagnostic"} {
+		if !slices.Contains(minimal.Types, name) {
+			t.Errorf("minimal profile lacks %s", name)
+		}
+	}
+
+	if _, err := LookupProfile("nonexistent"); err == nil || !strings.Contains(err.Error(), "minimal") {
+		t.Errorf("LookupProfile(nonexistent) = %v, want an error listing minimal", err)
+	}
+
+	defer func() {
+		profileMu.Lock()
+		delete(profiles, "test")
+		profileMu.Unlock()
+	}()
+	RegisterProfile(Profile{Name: "test", Types: []string{"Range"}})
+	got, err := LookupProfile("test")
+	if err != nil || !slices.Equal(got.Types, []string{"Range"}) {
+		t.Errorf("LookupProfile(test) = %v, %v, want the registered types", got, err)
+	}
+	var names []string
+	for _, p := range Profiles() {
+		names = append(names, p.Name)
+	}
+	if !slices.Equal(names, []string{"minimal", "test"}) {
+		t.Errorf("Profiles() = %v, want [minimal test]", names)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("registering a duplicate profile did not panic")
+		}
+	}()
+	RegisterProfile(Profile{Name: "minimal"})
+}
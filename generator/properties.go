@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package generator
+
+import (
+	"log/slog"
+	"slices"
+
+	"github.com/albertocavalcante/lspls/model"
+)
+
+// DedupProperties returns props, the properties of a structure with those
+// of its extends and mixins flattened in before its own, with each JSON
+// name once, and the names that occurred more than once. The last property
+// of a name, which is the most derived, takes the place of the first, so
+// the order of the names is kept. props is not modified.
+func DedupProperties(props []model.Property) ([]model.Property, []string) {
+	index := make(map[string]int, len(props))
+	var deduped []model.Property
+	var dups []string
+	for _, p := range props {
+		i, ok := index[p.Name]
+		if !ok {
+			index[p.Name] = len(deduped)
+			deduped = append(deduped, p)
+			continue
+		}
+		if !slices.Contains(dups, p.Name) {
+			dups = append(dups, p.Name)
+		}
+		deduped[i] = p
+	}
+	return deduped, dups
+}
+
+// CheckJSONNames reports dups, the JSON names that flattening gives more
+// than one property of structure. With strict, it fails with a
+// *DuplicateJSONNameError for the first; otherwise it logs a warning for
+// each to logger, which may be nil, and returns nil.
+func CheckJSONNames(logger *slog.Logger, structure string, dups []string, strict bool) error {
+	if len(dups) == 0 {
+		return nil
+	}
+	if strict {
+		return &DuplicateJSONNameError{Structure: structure, Name: dups[0]}
+	}
+	if logger == nil {
+		return nil
+	}
+	for _, name := range dups {
+		logger.Warn("duplicate JSON name after flattening, keeping the most-derived property", "structure", structure, "name", name)
+	}
+	return nil
+}
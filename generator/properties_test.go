@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package generator
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/albertocavalcante/lspls/model"
+)
+
+func TestDedupProperties(t *testing.T) {
+	props := []model.Property{
+		{Name: "uri", Documentation: "parent"},
+		{Name: "version"},
+		{Name: "uri", Documentation: "child"},
+		{Name: "text"},
+	}
+	got, dups := DedupProperties(props)
+	var names []string
+	for _, p := range got {
+		names = append(names, p.Name)
+	}
+	if !slices.Equal(names, []string{"uri", "version", "text"}) {
+		t.Errorf("names = %v, want [uri version text]", names)
+	}
+	if got[0].Documentation != "child" {
+		t.Errorf("kept the %s uri, want the child's", got[0].Documentation)
+	}
+	if !slices.Equal(dups, []string{"uri"}) {
+		t.Errorf("dups = %v, want [uri]", dups)
+	}
+	if props[0].Documentation != "parent" {
+		t.Error("DedupProperties modified its argument")
+	}
+}
+
+func TestCheckJSONNames(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+	if err := CheckJSONNames(logger, "Child", []string{"uri"}, false); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "structure=Child name=uri") {
+		t.Errorf("warning = %q, want the structure and name", buf.String())
+	}
+
+	err := CheckJSONNames(logger, "Child", []string{"uri"}, true)
+	var dup *DuplicateJSONNameError
+	if !errors.As(err, &dup) || dup.Structure != "Child" || dup.Name != "uri" {
+		t.Errorf("strict error = %v, want a DuplicateJSONNameError for Child.uri", err)
+	}
+}
@@ -6,7 +6,12 @@
 
 package generator
 
-import "github.com/albertocavalcante/lspls/model"
+import (
+	"maps"
+	"slices"
+
+	"github.com/albertocavalcante/lspls/model"
+)
 
 // ResolveDeps expands a type filter to include all transitively
 // referenced types from the model. Returns nil if filter is nil
@@ -15,49 +20,123 @@ import "github.com/albertocavalcante/lspls/model"
 // The includeProposed parameter controls whether proposed properties
 // are followed during dependency walking.
 func ResolveDeps(m *model.Model, filter map[string]bool, includeProposed bool) map[string]bool {
+	return ResolveDepsDepth(m, filter, includeProposed, 0)
+}
+
+// ResolveDepsDepth is like ResolveDeps, but follows at most maxDepth
+// references from the types of filter: with a maxDepth of 1, only the
+// types they reference directly are added. A maxDepth of 0 or less
+// follows references without limit.
+func ResolveDepsDepth(m *model.Model, filter map[string]bool, includeProposed bool, maxDepth int) map[string]bool {
 	if filter == nil {
 		return nil
 	}
 
-	expanded := make(map[string]bool)
+	w := newDepWalk(m, includeProposed)
+	w.maxDepth = maxDepth
 	for name := range filter {
-		collectDeps(m, name, expanded, includeProposed)
+		w.collectDeps(name, 0)
+	}
+	return w.visited
+}
+
+// DepsOnly returns the sorted names of the types that roots transitively
+// reference, leaving out the roots themselves even when they reference
+// each other. It selects the shared dependencies of some types, to
+// generate them in one package and the roots in another.
+func DepsOnly(m *model.Model, roots []string, includeProposed bool) []string {
+	filter := make(map[string]bool, len(roots))
+	for _, root := range roots {
+		filter[root] = true
+	}
+	deps := ResolveDeps(m, filter, includeProposed)
+	for _, root := range roots {
+		delete(deps, root)
+	}
+	return slices.Sorted(maps.Keys(deps))
+}
+
+// DepGraph returns the dependency graph ResolveDeps walks from roots: every
+// type it reaches, mapped to the sorted names of the types it references
+// directly. Types that reference nothing map to an empty slice.
+func DepGraph(m *model.Model, roots []string, includeProposed bool) map[string][]string {
+	w := newDepWalk(m, includeProposed)
+	w.edges = make(map[string]map[string]bool)
+	for _, root := range roots {
+		w.collectDeps(root, 0)
+	}
+	graph := make(map[string][]string, len(w.visited))
+	for name := range w.visited {
+		graph[name] = slices.Sorted(maps.Keys(w.edges[name]))
+	}
+	return graph
+}
+
+// depWalk is the state of a walk over the types reachable from some roots.
+type depWalk struct {
+	m               *model.Model
+	includeProposed bool
+
+	// maxDepth, if positive, is the number of references followed from
+	// the roots.
+	maxDepth int
+
+	// visited holds the types reached so far.
+	visited map[string]bool
+
+	// depth holds the fewest references from a root to each visited type.
+	depth map[string]int
+
+	// edges, if not nil, records the types each type references.
+	edges map[string]map[string]bool
+}
+
+func newDepWalk(m *model.Model, includeProposed bool) *depWalk {
+	return &depWalk{
+		m:               m,
+		includeProposed: includeProposed,
+		visited:         make(map[string]bool),
+		depth:           make(map[string]int),
 	}
-	return expanded
 }
 
-// collectDeps recursively collects all types referenced by typeName.
-func collectDeps(m *model.Model, typeName string, visited map[string]bool, includeProposed bool) {
-	if visited[typeName] {
-		return // Already processed or cycle
+// collectDeps recursively collects all types referenced by typeName,
+// which is depth references away from a root.
+func (w *depWalk) collectDeps(typeName string, depth int) {
+	if w.maxDepth > 0 && depth > w.maxDepth {
+		return
 	}
-	visited[typeName] = true
+	if d, ok := w.depth[typeName]; ok && (w.maxDepth <= 0 || d <= depth) {
+		return // Already processed, closer to a root if depth matters, or cycle
+	}
+	w.visited[typeName] = true
+	w.depth[typeName] = depth
 
 	// Check structures
-	for _, s := range m.Structures {
+	for _, s := range w.m.Structures {
 		if s.Name == typeName {
 			for _, prop := range s.Properties {
 				// Skip proposed properties when not including proposed types
-				if prop.Proposed && !includeProposed {
+				if prop.Proposed && !w.includeProposed {
 					continue
 				}
-				collectTypeRefs(m, prop.Type, visited, includeProposed)
+				w.collectTypeRefs(typeName, prop.Type, depth)
 			}
 			// Also check extends and mixins
 			for _, ext := range s.Extends {
-				collectTypeRefs(m, ext, visited, includeProposed)
+				w.collectTypeRefs(typeName, ext, depth)
 			}
 			for _, mix := range s.Mixins {
-				collectTypeRefs(m, mix, visited, includeProposed)
+				w.collectTypeRefs(typeName, mix, depth)
 			}
 			return
 		}
 	}
 
 	// Check type aliases
-	for _, a := range m.TypeAliases {
+	for _, a := range w.m.TypeAliases {
 		if a.Name == typeName {
-			collectTypeRefs(m, a.Type, visited, includeProposed)
+			w.collectTypeRefs(typeName, a.Type, depth)
 			return
 		}
 	}
@@ -65,39 +144,46 @@ func collectDeps(m *model.Model, typeName string, visited map[string]bool, inclu
 	// Enums don't reference other types, nothing to do
 }
 
-// collectTypeRefs extracts type references from a Type and recursively
+// collectTypeRefs extracts type references from a Type used by the named
+// type, which is depth references away from a root, and recursively
 // collects their dependencies.
-func collectTypeRefs(m *model.Model, t *model.Type, visited map[string]bool, includeProposed bool) {
+func (w *depWalk) collectTypeRefs(from string, t *model.Type, depth int) {
 	if t == nil {
 		return
 	}
 	switch t.Kind {
 	case "reference":
-		collectDeps(m, t.Name, visited, includeProposed)
+		if w.edges != nil {
+			if w.edges[from] == nil {
+				w.edges[from] = make(map[string]bool)
+			}
+			w.edges[from][t.Name] = true
+		}
+		w.collectDeps(t.Name, depth+1)
 	case "array":
-		collectTypeRefs(m, t.Element, visited, includeProposed)
+		w.collectTypeRefs(from, t.Element, depth)
 	case "map":
-		collectTypeRefs(m, t.Key, visited, includeProposed)
+		w.collectTypeRefs(from, t.Key, depth)
 		if vt, ok := t.Value.(*model.Type); ok {
-			collectTypeRefs(m, vt, visited, includeProposed)
+			w.collectTypeRefs(from, vt, depth)
 		}
 	case "or":
 		for _, item := range t.Items {
-			collectTypeRefs(m, item, visited, includeProposed)
+			w.collectTypeRefs(from, item, depth)
 		}
 	case "and":
 		for _, item := range t.Items {
-			collectTypeRefs(m, item, visited, includeProposed)
+			w.collectTypeRefs(from, item, depth)
 		}
 	case "tuple":
 		for _, item := range t.Items {
-			collectTypeRefs(m, item, visited, includeProposed)
+			w.collectTypeRefs(from, item, depth)
 		}
 	case "literal":
 		// Literal types have inline properties
 		if lit, ok := t.Value.(model.Literal); ok {
 			for _, prop := range lit.Properties {
-				collectTypeRefs(m, prop.Type, visited, includeProposed)
+				w.collectTypeRefs(from, prop.Type, depth)
 			}
 		}
 	}
@@ -3,6 +3,8 @@
 package generator
 
 import (
+	"maps"
+	"slices"
 	"sort"
 	"testing"
 
@@ -434,3 +436,169 @@ func TestResolveDeps(t *testing.T) {
 		})
 	}
 }
+
+func TestDepsOnly(t *testing.T) {
+	ref := func(name string) *model.Type { return &model.Type{Kind: "reference", Name: name} }
+	m := &model.Model{
+		Structures: []*model.Structure{
+			{Name: "Position"},
+			{
+				Name: "Range",
+				Properties: []model.Property{
+					{Name: "start", Type: ref("Position")},
+					{Name: "end", Type: ref("Position")},
+				},
+			},
+			{
+				Name: "Location",
+				Properties: []model.Property{
+					{Name: "uri", Type: ref("DocumentUri")},
+					{Name: "range", Type: ref("Range")},
+				},
+			},
+		},
+		TypeAliases: []*model.TypeAlias{
+			{Name: "DocumentUri", Type: &model.Type{Kind: "base", Name: "string"}},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		roots []string
+		want  []string
+	}{
+		{name: "single root", roots: []string{"Location"}, want: []string{"DocumentUri", "Position", "Range"}},
+		{name: "root reached from another root", roots: []string{"Location", "Range"}, want: []string{"DocumentUri", "Position"}},
+		{name: "leaf", roots: []string{"Position"}, want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DepsOnly(m, tt.roots, false)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("DepsOnly(%v) = %v, want %v", tt.roots, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveDepsDepth(t *testing.T) {
+	ref := func(name string) *model.Type { return &model.Type{Kind: "reference", Name: name} }
+	m := &model.Model{
+		Structures: []*model.Structure{
+			{Name: "Position"},
+			{
+				Name: "Range",
+				Properties: []model.Property{
+					{Name: "start", Type: ref("Position")},
+				},
+			},
+			{
+				Name: "Location",
+				Properties: []model.Property{
+					{Name: "range", Type: ref("Range")},
+				},
+			},
+			{
+				Name: "Diagnostic",
+				Properties: []model.Property{
+					{Name: "location", Type: ref("Location")},
+					{Name: "range", Type: ref("Range")},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		maxDepth int
+		want     []string
+	}{
+		{name: "direct references", maxDepth: 1, want: []string{"Diagnostic", "Location", "Range"}},
+		{name: "two references", maxDepth: 2, want: []string{"Diagnostic", "Location", "Position", "Range"}},
+		{name: "unlimited", maxDepth: 0, want: []string{"Diagnostic", "Location", "Position", "Range"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ResolveDepsDepth(m, map[string]bool{"Diagnostic": true}, false, tt.maxDepth)
+			if names := slices.Sorted(maps.Keys(got)); !slices.Equal(names, tt.want) {
+				t.Errorf("ResolveDepsDepth(%d) = %v, want %v", tt.maxDepth, names, tt.want)
+			}
+		})
+	}
+}
+
+func TestDepGraph(t *testing.T) {
+	ref := func(name string) *model.Type { return &model.Type{Kind: "reference", Name: name} }
+	m := &model.Model{
+		Structures: []*model.Structure{
+			{Name: "Position"},
+			{
+				Name: "Range",
+				Properties: []model.Property{
+					{Name: "start", Type: ref("Position")},
+					{Name: "end", Type: ref("Position")},
+				},
+			},
+			{
+				Name: "Location",
+				Properties: []model.Property{
+					{Name: "uri", Type: ref("DocumentUri")},
+					{Name: "range", Type: ref("Range")},
+					{Name: "next", Type: &model.Type{Kind: "array", Element: ref("Location")}},
+					{Name: "tag", Type: ref("Tag"), Proposed: true},
+				},
+			},
+		},
+		Enumerations: []*model.Enumeration{{Name: "Tag"}},
+		TypeAliases: []*model.TypeAlias{
+			{Name: "DocumentUri", Type: &model.Type{Kind: "base", Name: "string"}},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		roots           []string
+		includeProposed bool
+		want            map[string][]string
+	}{
+		{
+			name:  "transitive edges",
+			roots: []string{"Location"},
+			want: map[string][]string{
+				"DocumentUri": {},
+				"Location":    {"DocumentUri", "Location", "Range"},
+				"Position":    {},
+				"Range":       {"Position"},
+			},
+		},
+		{
+			name:            "proposed property",
+			roots:           []string{"Location"},
+			includeProposed: true,
+			want: map[string][]string{
+				"DocumentUri": {},
+				"Location":    {"DocumentUri", "Location", "Range", "Tag"},
+				"Position":    {},
+				"Range":       {"Position"},
+				"Tag":         {},
+			},
+		},
+		{
+			name:  "several roots",
+			roots: []string{"Range", "DocumentUri"},
+			want: map[string][]string{
+				"DocumentUri": {},
+				"Position":    {},
+				"Range":       {"Position"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DepGraph(m, tt.roots, tt.includeProposed)
+			if !maps.EqualFunc(got, tt.want, slices.Equal) {
+				t.Errorf("DepGraph(%v) = %v, want %v", tt.roots, got, tt.want)
+			}
+		})
+	}
+}
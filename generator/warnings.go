@@ -0,0 +1,80 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package generator
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Warnings records the warnings generators log through Config.Logger,
+// such as skipped members and types degraded to the target's dynamic
+// type, for callers that treat any degradation as an error.
+type Warnings struct {
+	mu      sync.Mutex
+	entries []string
+}
+
+// Handler returns a handler that passes records to next and records those
+// at warning level or above in w, whatever level next is enabled for.
+func (w *Warnings) Handler(next slog.Handler) slog.Handler {
+	return &warningsHandler{next: next, w: w}
+}
+
+// List returns the recorded warnings in the order they were logged, each
+// formatted as the message followed by its key=value attributes.
+func (w *Warnings) List() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return slices.Clone(w.entries)
+}
+
+// warningsHandler is the slog.Handler of Warnings.Handler.
+type warningsHandler struct {
+	next  slog.Handler
+	w     *Warnings
+	attrs []slog.Attr
+}
+
+func (h *warningsHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= slog.LevelWarn || h.next.Enabled(ctx, level)
+}
+
+func (h *warningsHandler) Handle(ctx context.Context, r slog.Record) error {
+	if r.Level >= slog.LevelWarn {
+		var b strings.Builder
+		b.WriteString(r.Message)
+		writeAttr := func(a slog.Attr) bool {
+			fmt.Fprintf(&b, " %s=%v", a.Key, a.Value)
+			return true
+		}
+		for _, a := range h.attrs {
+			writeAttr(a)
+		}
+		r.Attrs(writeAttr)
+
+		h.w.mu.Lock()
+		h.w.entries = append(h.w.entries, b.String())
+		h.w.mu.Unlock()
+	}
+	if !h.next.Enabled(ctx, r.Level) {
+		return nil
+	}
+	return h.next.Handle(ctx, r)
+}
+
+func (h *warningsHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &warningsHandler{next: h.next.WithAttrs(attrs), w: h.w, attrs: slices.Concat(h.attrs, attrs)}
+}
+
+func (h *warningsHandler) WithGroup(name string) slog.Handler {
+	return &warningsHandler{next: h.next.WithGroup(name), w: h.w, attrs: h.attrs}
+}
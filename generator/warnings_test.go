@@ -0,0 +1,34 @@
+// SPDX-License-Identifier: MIT
+
+package generator
+
+import (
+	"bytes"
+	"log/slog"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestWarnings(t *testing.T) {
+	var out bytes.Buffer
+	var w Warnings
+	next := slog.NewTextHandler(&out, &slog.HandlerOptions{Level: slog.LevelError})
+	log := slog.New(w.Handler(next))
+
+	log.Info("generating structure", "name", "Position")
+	log.With("target", "proto").Warn("skipped field", "field", "data", "line", 12)
+	log.Error("failed", "err", "boom")
+
+	want := []string{
+		"skipped field target=proto field=data line=12",
+		"failed err=boom",
+	}
+	if got := w.List(); !slices.Equal(got, want) {
+		t.Errorf("List() = %q, want %q", got, want)
+	}
+	// The next handler still gets only the levels it is enabled for.
+	if got := out.String(); strings.Contains(got, "skipped field") || !strings.Contains(got, "failed") {
+		t.Errorf("next handler got %q, want only the error", got)
+	}
+}
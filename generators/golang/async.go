@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package golang
+
+import (
+	"fmt"
+)
+
+// writeAsync writes an Async<name> struct embedding the name interface,
+// with a <Method>Async variant per method that calls the method in a new
+// goroutine and passes its result to a callback. The embedded interface
+// keeps the synchronous methods available on the same value.
+func (g *Generator) writeAsync(f *goFile, name string, methods *orderedMap[methodInfo]) {
+	keys := methods.keys()
+	if len(keys) == 0 {
+		return
+	}
+	g.useContext(f)
+
+	async := "Async" + name
+	buf := &f.body
+
+	fmt.Fprintf(buf, "// %s wraps a %s, such as a connection to the peer, with a\n", async, name)
+	buf.WriteString("// non-blocking <Method>Async variant of each method for callers that\n")
+	buf.WriteString("// cannot wait, like editor UI threads. The callback runs on the new\n")
+	buf.WriteString("// goroutine and may be nil to discard the result.\n")
+	fmt.Fprintf(buf, "type %s struct {\n", async)
+	fmt.Fprintf(buf, "\t%s\n", name)
+	buf.WriteString("}\n\n")
+
+	for _, key := range keys {
+		info := methods.get(key)
+		args := g.methodArgs(info, "params")
+		params := g.methodParams(info, true)
+		if params != "" {
+			params += ", "
+		}
+		if info.isNotification {
+			fmt.Fprintf(buf, "// %sAsync calls %s in a new goroutine and passes its error to done.\n", info.name, info.name)
+			fmt.Fprintf(buf, "func (a %s) %sAsync(%sdone func(error)) {\n", async, info.name, params)
+			buf.WriteString("\tgo func() {\n")
+			fmt.Fprintf(buf, "\t\terr := a.%s.%s(%s)\n", name, info.name, args)
+			buf.WriteString("\t\tif done != nil {\n")
+			buf.WriteString("\t\t\tdone(err)\n")
+		} else {
+			fmt.Fprintf(buf, "// %sAsync calls %s in a new goroutine and passes its result to done.\n", info.name, info.name)
+			fmt.Fprintf(buf, "func (a %s) %sAsync(%sdone func(%s, error)) {\n", async, info.name, params, info.resultType)
+			buf.WriteString("\tgo func() {\n")
+			fmt.Fprintf(buf, "\t\tresult, err := a.%s.%s(%s)\n", name, info.name, args)
+			buf.WriteString("\t\tif done != nil {\n")
+			buf.WriteString("\t\t\tdone(result, err)\n")
+		}
+		buf.WriteString("\t\t}\n")
+		buf.WriteString("\t}()\n")
+		buf.WriteString("}\n\n")
+	}
+}
@@ -0,0 +1,41 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package golang
+
+import (
+	"fmt"
+
+	"github.com/albertocavalcante/lspls/model"
+)
+
+// writeBinaryMethods writes MarshalBinary and UnmarshalBinary for the
+// structure s, so that a pointer to it satisfies encoding.BinaryMarshaler
+// and encoding.BinaryUnmarshaler. The binary form is the compact JSON
+// encoding, so values keep round-tripping when the protocol adds
+// properties, and gob encodes them through it as well.
+func (g *Generator) writeBinaryMethods(f *goFile, s *model.Structure) {
+	name := g.typeName(s.Name)
+	buf := &f.body
+	f.use("encoding/json")
+
+	buf.WriteString("// MarshalBinary implements encoding.BinaryMarshaler, encoding x as\n")
+	buf.WriteString("// compact JSON.\n")
+	fmt.Fprintf(buf, "func (x *%s) MarshalBinary() ([]byte, error) {\n", name)
+	buf.WriteString("\treturn json.Marshal(x)\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// UnmarshalBinary implements encoding.BinaryUnmarshaler, replacing x with\n")
+	buf.WriteString("// the value that MarshalBinary encoded. x is left unchanged on error.\n")
+	fmt.Fprintf(buf, "func (x *%s) UnmarshalBinary(data []byte) error {\n", name)
+	fmt.Fprintf(buf, "\tvar v %s\n", name)
+	buf.WriteString("\tif err := json.Unmarshal(data, &v); err != nil {\n")
+	buf.WriteString("\t\treturn err\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\t*x = v\n")
+	buf.WriteString("\treturn nil\n")
+	buf.WriteString("}\n\n")
+}
@@ -0,0 +1,70 @@
+// SPDX-License-Identifier: MIT
+
+package golang_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/lspls/generators/golang"
+)
+
+// binaryRuntimeTest checks that the structures generated for
+// testdata/binary.txtar round-trip through MarshalBinary and through gob,
+// which encodes them with MarshalBinary.
+const binaryRuntimeTest = `package protocol
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"testing"
+)
+
+var (
+	_ encoding.BinaryMarshaler   = (*VersionedTextDocumentIdentifier)(nil)
+	_ encoding.BinaryUnmarshaler = (*VersionedTextDocumentIdentifier)(nil)
+)
+
+func TestBinaryRoundTrip(t *testing.T) {
+	version := int32(3)
+	in := VersionedTextDocumentIdentifier{Uri: "file:///a.go", Version: &version}
+	data, err := in.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := ` + "`" + `{"uri":"file:///a.go","version":3}` + "`" + `; string(data) != want {
+		t.Errorf("MarshalBinary = %s, want %s", data, want)
+	}
+	got := VersionedTextDocumentIdentifier{Uri: "stale", Version: new(int32)}
+	if err := got.UnmarshalBinary([]byte(` + "`" + `{"uri":"file:///a.go"}` + "`" + `)); err != nil {
+		t.Fatal(err)
+	}
+	if got.Uri != "file:///a.go" || got.Version != nil {
+		t.Errorf("UnmarshalBinary kept stale fields: %+v", got)
+	}
+	if err := got.UnmarshalBinary([]byte("{")); err == nil {
+		t.Error("UnmarshalBinary accepted truncated data")
+	}
+}
+
+func TestBinaryGob(t *testing.T) {
+	in := &TextDocumentIdentifier{Uri: "file:///a.go"}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(in); err != nil {
+		t.Fatal(err)
+	}
+	var got TextDocumentIdentifier
+	if err := gob.NewDecoder(&buf).Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+	if got != *in {
+		t.Errorf("gob round trip = %+v, want %+v", got, *in)
+	}
+}
+`
+
+func TestBinaryRuntime(t *testing.T) {
+	cfg := golang.DefaultConfig()
+	cfg.Binary = true
+	runGenerated(t, "binary.txtar", cfg, binaryRuntimeTest)
+}
@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package golang
+
+import (
+	"fmt"
+
+	"github.com/albertocavalcante/lspls/model"
+)
+
+// isBitmask reports whether the integer enumeration e is a set of bit
+// flags: it has at least two values, and they are distinct powers of two,
+// such as WatchKind's Create = 1, Change = 2, and Delete = 4.
+func (g *Generator) isBitmask(e *model.Enumeration) bool {
+	if g.defaultBaseType(e.Type) == "string" || len(e.Values) < 2 {
+		return false
+	}
+	seen := make(map[int64]bool)
+	for _, v := range e.Values {
+		f, ok := v.Value.(float64)
+		n := int64(f)
+		if !ok || f != float64(n) || n <= 0 || n&(n-1) != 0 || seen[n] {
+			return false
+		}
+		seen[n] = true
+	}
+	return true
+}
+
+// writeBitmaskMethods writes Has, Set, Clear, and String methods for the
+// bit flag enumeration e.
+func (g *Generator) writeBitmaskMethods(f *goFile, e *model.Enumeration) {
+	name := g.typeName(e.Name)
+	buf := &f.body
+
+	buf.WriteString("// Has reports whether every flag of flags is set in x.\n")
+	fmt.Fprintf(buf, "func (x %s) Has(flags %s) bool {\n", name, name)
+	buf.WriteString("\treturn x&flags == flags\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// Set returns x with the flags of flags set.\n")
+	fmt.Fprintf(buf, "func (x %s) Set(flags %s) %s {\n", name, name, name)
+	buf.WriteString("\treturn x | flags\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// Clear returns x with the flags of flags cleared.\n")
+	fmt.Fprintf(buf, "func (x %s) Clear(flags %s) %s {\n", name, name, name)
+	buf.WriteString("\treturn x &^ flags\n")
+	buf.WriteString("}\n\n")
+
+	f.use("fmt", "strings")
+	buf.WriteString("// String returns the names of the flags set in x joined by \"|\", such as\n")
+	fmt.Fprintf(buf, "// %q, followed by any other bits in hexadecimal, or \"0\".\n", e.Values[0].Name+"|"+e.Values[len(e.Values)-1].Name)
+	fmt.Fprintf(buf, "func (x %s) String() string {\n", name)
+	buf.WriteString("\tvar names []string\n")
+	fmt.Fprintf(buf, "\tfor _, flag := range []struct {\n\t\tvalue %s\n\t\tname  string\n\t}{\n", name)
+	for _, v := range e.Values {
+		fmt.Fprintf(buf, "\t\t{%s, %q},\n", name+g.exportName(v.Name), v.Name)
+	}
+	buf.WriteString("\t} {\n")
+	buf.WriteString("\t\tif x&flag.value != 0 {\n")
+	buf.WriteString("\t\t\tnames = append(names, flag.name)\n")
+	buf.WriteString("\t\t\tx &^= flag.value\n")
+	buf.WriteString("\t\t}\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\tif x != 0 {\n")
+	buf.WriteString("\t\tnames = append(names, fmt.Sprintf(\"%#x\", uint64(x)))\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\tif len(names) == 0 {\n")
+	buf.WriteString("\t\treturn \"0\"\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\treturn strings.Join(names, \"|\")\n")
+	buf.WriteString("}\n\n")
+}
@@ -0,0 +1,51 @@
+// SPDX-License-Identifier: MIT
+
+package golang_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/lspls/generators/golang"
+)
+
+// bitmaskRuntimeTest combines the flags of the WatchKind generated for
+// testdata/bitmask_enums.txtar.
+const bitmaskRuntimeTest = `package protocol
+
+import "testing"
+
+func TestBitmask(t *testing.T) {
+	kind := WatchKindCreate.Set(WatchKindDelete)
+	if !kind.Has(WatchKindCreate) || !kind.Has(WatchKindDelete) || kind.Has(WatchKindChange) {
+		t.Errorf("Create|Delete: Has = %v, %v, %v, want true, true, false",
+			kind.Has(WatchKindCreate), kind.Has(WatchKindDelete), kind.Has(WatchKindChange))
+	}
+	if kind.Has(WatchKindCreate | WatchKindChange) {
+		t.Error("Create|Delete has Create|Change")
+	}
+	if got := kind.Clear(WatchKindCreate); got != WatchKindDelete {
+		t.Errorf("Clear(Create) = %d, want %d", got, WatchKindDelete)
+	}
+
+	tests := []struct {
+		kind WatchKind
+		want string
+	}{
+		{0, "0"},
+		{WatchKindChange, "Change"},
+		{kind, "Create|Delete"},
+		{kind | 8, "Create|Delete|0x8"},
+	}
+	for _, tt := range tests {
+		if got := tt.kind.String(); got != tt.want {
+			t.Errorf("WatchKind(%d).String() = %q, want %q", uint32(tt.kind), got, tt.want)
+		}
+	}
+}
+`
+
+func TestBitmaskEnumsRuntime(t *testing.T) {
+	cfg := golang.DefaultConfig()
+	cfg.BitmaskEnums = true
+	runGenerated(t, "bitmask_enums.txtar", cfg, bitmaskRuntimeTest)
+}
@@ -0,0 +1,27 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package golang
+
+import (
+	"fmt"
+	"go/build/constraint"
+)
+
+// buildConstraintLines returns the //go:build line of the build expression
+// expr followed by the matching // +build lines, for toolchains older than
+// Go 1.17.
+func buildConstraintLines(expr string) ([]string, error) {
+	x, err := constraint.Parse("//go:build " + expr)
+	if err != nil {
+		return nil, fmt.Errorf("build tags %q: %w", expr, err)
+	}
+	plus, err := constraint.PlusBuildLines(x)
+	if err != nil {
+		return nil, fmt.Errorf("build tags %q: %w", expr, err)
+	}
+	return append([]string{"//go:build " + x.String()}, plus...), nil
+}
@@ -0,0 +1,55 @@
+// SPDX-License-Identifier: MIT
+
+package golang_test
+
+import (
+	"bytes"
+	"go/build/constraint"
+	"go/format"
+	"strings"
+	"testing"
+
+	"github.com/albertocavalcante/lspls/generators/golang"
+	"github.com/albertocavalcante/lspls/model"
+)
+
+// TestBuildTags checks that the build constraint comes first in the file,
+// separated from the generated-code header by a blank line as the go
+// command requires, and that the file stays gofmt-formatted.
+func TestBuildTags(t *testing.T) {
+	cfg := golang.DefaultConfig()
+	cfg.BuildTags = "lsp"
+	cfg.LSPVersion = "3.17.0"
+	m := &model.Model{Structures: []*model.Structure{{
+		Name:       "Position",
+		Properties: []model.Property{{Name: "line", Type: &model.Type{Kind: "base", Name: "uinteger"}}},
+	}}}
+	out, err := golang.New(m, cfg).Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "//go:build lsp\n// +build lsp\n\n// Code generated by lspls. DO NOT EDIT.\n"
+	if !bytes.HasPrefix(out.Protocol, []byte(want)) {
+		t.Fatalf("protocol.go does not start with the build constraint:\n%s", out.Protocol)
+	}
+	line, _, _ := strings.Cut(string(out.Protocol), "\n")
+	if !constraint.IsGoBuild(line) {
+		t.Errorf("first line %q is not a //go:build line", line)
+	}
+	formatted, err := format.Source(out.Protocol)
+	if err != nil {
+		t.Fatalf("format.Source: %v", err)
+	}
+	if !bytes.Equal(formatted, out.Protocol) {
+		t.Errorf("format.Source changed the output:\n%s", formatted)
+	}
+}
+
+func TestBuildTagsInvalid(t *testing.T) {
+	cfg := golang.DefaultConfig()
+	cfg.BuildTags = "lsp &&"
+	if _, err := golang.New(&model.Model{}, cfg).Generate(); err == nil || !strings.Contains(err.Error(), `build tags "lsp &&"`) {
+		t.Errorf("Generate() error = %v, want a build tags error", err)
+	}
+}
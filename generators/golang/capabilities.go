@@ -0,0 +1,128 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package golang
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/albertocavalcante/lspls/model"
+)
+
+// capabilityRoots are the structures that get capability accessors.
+var capabilityRoots = []string{"ClientCapabilities", "ServerCapabilities"}
+
+// capabilityLeaf is a property reached from a capability root through
+// structure-valued fields, whose Go type tells whether it is set.
+type capabilityLeaf struct {
+	goPath   []string // Go field names from the root
+	jsonPath []string // JSON property names from the root
+	goType   string   // Go type of the field: *T or Optional[T]
+	optional bool     // whether goType is an Optional of Tristate
+}
+
+// writeCapabilityAccessors writes an accessor method on structure s, if it
+// is one of capabilityRoots, for every pointer or Optional property nested
+// in its structure-valued fields. Each returns the value and true, or false
+// if the receiver is nil or the property is absent. Properties of s itself
+// are left to a nil check.
+func (g *Generator) writeCapabilityAccessors(f *goFile, s *model.Structure) {
+	if !slices.Contains(capabilityRoots, s.Name) {
+		return
+	}
+	var leaves []capabilityLeaf
+	g.collectCapabilityLeaves(s, nil, nil, map[string]bool{s.Name: true}, &leaves)
+
+	fields := make(map[string]bool)
+	for _, p := range s.Properties {
+		fields[g.exportName(p.Name)] = true
+	}
+	recv := g.typeName(s.Name)
+	buf := &f.body
+	seen := make(map[string]bool)
+	for _, leaf := range leaves {
+		if len(leaf.goPath) < 2 {
+			continue
+		}
+		name := strings.Join(leaf.goPath, "")
+		if fields[name] || seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		field := "c." + strings.Join(leaf.goPath, ".")
+		valueType := strings.TrimPrefix(leaf.goType, "*")
+		absent := field + " == nil"
+		value := "*" + field
+		if leaf.optional {
+			valueType = leaf.goType[strings.Index(leaf.goType, "[")+1 : len(leaf.goType)-1]
+			absent = "!" + field + ".Set || " + field + ".Null"
+			value = field + ".Value"
+		}
+
+		fmt.Fprintf(buf, "// %s returns the\n", name)
+		fmt.Fprintf(buf, "// %s capability and true,\n", strings.Join(leaf.jsonPath, "."))
+		buf.WriteString("// or false if c is nil or the capability is absent.\n")
+		fmt.Fprintf(buf, "func (c *%s) %s() (value %s, ok bool) {\n", recv, name, valueType)
+		fmt.Fprintf(buf, "\tif c == nil || %s {\n", absent)
+		buf.WriteString("\t\treturn value, false\n")
+		buf.WriteString("\t}\n")
+		fmt.Fprintf(buf, "\treturn %s, true\n", value)
+		buf.WriteString("}\n\n")
+	}
+}
+
+// collectCapabilityLeaves appends to leaves the pointer and Optional
+// properties of s and, recursively, of the structures its extends, mixins,
+// and structure-valued properties refer to. active holds the structures on
+// the current path, which are not entered again.
+func (g *Generator) collectCapabilityLeaves(s *model.Structure, goPath, jsonPath []string, active map[string]bool, leaves *[]capabilityLeaf) {
+	for _, p := range s.Properties {
+		if !g.includeProperty(&p) {
+			continue
+		}
+		goType, _ := g.fieldType(&p)
+		goPath := append(slices.Clip(goPath), g.exportName(p.Name))
+		jsonPath := append(slices.Clip(jsonPath), p.Name)
+		switch {
+		case strings.HasPrefix(goType, "*"):
+			*leaves = append(*leaves, capabilityLeaf{goPath: goPath, jsonPath: jsonPath, goType: goType})
+		case g.tristate(&p):
+			*leaves = append(*leaves, capabilityLeaf{goPath: goPath, jsonPath: jsonPath, goType: goType, optional: true})
+		default:
+			if child, ok := g.capabilityStructure(p.Type, active); ok {
+				active[child.Name] = true
+				g.collectCapabilityLeaves(child, goPath, jsonPath, active, leaves)
+				delete(active, child.Name)
+			}
+		}
+	}
+	// Properties of embedded structures are promoted, so they share the
+	// path of s. They come last so that a property of s shadowing one of
+	// them claims the accessor name.
+	for _, ext := range slices.Concat(s.Extends, s.Mixins) {
+		if parent, ok := g.capabilityStructure(ext, active); ok {
+			active[parent.Name] = true
+			g.collectCapabilityLeaves(parent, goPath, jsonPath, active, leaves)
+			delete(active, parent.Name)
+		}
+	}
+}
+
+// capabilityStructure returns the generated structure t refers to, unless
+// it is on the current path.
+func (g *Generator) capabilityStructure(t *model.Type, active map[string]bool) (*model.Structure, bool) {
+	if t == nil || t.Kind != "reference" || active[t.Name] || g.omitted(t.Name) {
+		return nil, false
+	}
+	if _, ok := g.types.m[t.Name]; !ok {
+		return nil, false
+	}
+	s, ok := g.structures[t.Name]
+	return s, ok
+}
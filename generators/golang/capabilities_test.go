@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: MIT
+
+package golang_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/lspls/generators/golang"
+)
+
+// capabilitiesRuntimeTest decodes partially populated capabilities and
+// checks the accessors generated for testdata/capability_accessors.txtar.
+const capabilitiesRuntimeTest = `package protocol
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestAccessors(t *testing.T) {
+	var caps ClientCapabilities
+	data := ` + "`" + `{"textDocument": {"completion": {"completionItem": {"snippetSupport": true}, "contextSupport": false}}}` + "`" + `
+	if err := json.Unmarshal([]byte(data), &caps); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		get       func() (bool, bool)
+		value, ok bool
+	}{
+		{"snippetSupport", caps.TextDocumentCompletionCompletionItemSnippetSupport, true, true},
+		{"contextSupport", caps.TextDocumentCompletionContextSupport, false, true},
+		{"hover.dynamicRegistration", caps.TextDocumentHoverDynamicRegistration, false, false},
+	}
+	for _, tt := range tests {
+		if value, ok := tt.get(); value != tt.value || ok != tt.ok {
+			t.Errorf("%s = %v, %v, want %v, %v", tt.name, value, ok, tt.value, tt.ok)
+		}
+	}
+
+	var empty *ClientCapabilities
+	if value, ok := empty.TextDocumentCompletionCompletionItemSnippetSupport(); value || ok {
+		t.Errorf("nil capabilities: snippetSupport = %v, %v, want false, false", value, ok)
+	}
+}
+`
+
+func TestCapabilityAccessorsRuntime(t *testing.T) {
+	cfg := golang.DefaultConfig()
+	cfg.CapabilityAccessors = true
+	runGenerated(t, "capability_accessors.txtar", cfg, capabilitiesRuntimeTest)
+}
@@ -16,7 +16,13 @@ import (
 	"bytes"
 	"fmt"
 	"go/format"
+	"log/slog"
+	"maps"
+	"path"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/albertocavalcante/lspls/generator"
 	"github.com/albertocavalcante/lspls/internal/lspbase"
@@ -28,6 +34,19 @@ type Config struct {
 	// PackageName is the Go package name for generated code.
 	PackageName string
 
+	// TypePrefix and TypeSuffix are added to the name of every generated
+	// type, including Or_* unions, and of every Method* constant, such as
+	// LSPPosition for the prefix "LSP". JSON property names are unchanged.
+	TypePrefix string
+	TypeSuffix string
+
+	// Acronyms are words cased the same way wherever they appear in a
+	// generated identifier, such as "URI" for DocumentURI. NameOverrides
+	// maps an LSP type, property, enumeration value, or method name to its
+	// identifier, bypassing the other rules.
+	Acronyms      []string
+	NameOverrides map[string]string
+
 	// Types limits generation to specific type names.
 	// If empty, all types are generated.
 	Types []string
@@ -37,9 +56,30 @@ type Config struct {
 	// references will also be included. Default: true.
 	ResolveDeps bool
 
+	// DepDepth, when positive, limits ResolveDeps to types at most this
+	// many references away from the Types. References to types beyond the
+	// limit are typed as any, like references to types omitted by
+	// OmitDeprecated, so that a bounded slice of the protocol compiles.
+	DepDepth int
+
 	// IncludeProposed includes proposed (unstable) features.
 	IncludeProposed bool
 
+	// OnlyStableMethods leaves proposed requests and notifications out of
+	// the Server and Client interfaces and the Method* constants, even when
+	// IncludeProposed generates proposed types.
+	OnlyStableMethods bool
+
+	// OmitDeprecated leaves out deprecated structures, enumerations, type
+	// aliases, and properties. References to an omitted type become any.
+	OmitDeprecated bool
+
+	// OmitInternal leaves out the types whose LSP name starts with "_",
+	// such as _InitializeParams, which are otherwise generated with an "X"
+	// prefix. References to them become any, and structures extending them
+	// lose their properties.
+	OmitInternal bool
+
 	// GenerateClient generates the Client interface.
 	GenerateClient bool
 
@@ -49,10 +89,218 @@ type Config struct {
 	// GenerateJSON generates custom JSON marshaling code.
 	GenerateJSON bool
 
+	// GenerateEqual generates an Equal method on every structure and Or_*
+	// union type performing a deep, field-by-field comparison.
+	GenerateEqual bool
+
+	// DiscriminatedUnions generates a Kind method and an As<Member> getter
+	// per member on Or_* unions whose members are all structures with a
+	// distinct string literal "kind" property.
+	DiscriminatedUnions bool
+
+	// Methods limits the Server and Client interfaces, the Method*
+	// constants, and the Registry to these requests and notifications. The
+	// interfaces are generated even when Types is set, which should then
+	// hold the types of the methods.
+	Methods []string
+
+	// FilteredInterfaces generates the Server and Client interfaces even
+	// when Types is set, with only the requests and notifications whose
+	// params and result types are all generated. Without it, a type filter
+	// leaves out the interfaces.
+	FilteredInterfaces bool
+
+	// RawAny generates LSPAny, LSPObject, and LSPArray as types holding the
+	// JSON text they were decoded from, instead of a union, map, and slice
+	// of decoded values.
+	RawAny bool
+
+	// TypeOverrides maps LSP base type names, such as "decimal", to the Go
+	// types generated for them in place of the defaults, everywhere the
+	// base type appears: properties, arrays, maps, unions, and aliases. A
+	// type from another package is written with its import path, as in
+	// "encoding/json.Number", and the package is imported where the type
+	// is used; its package name must be the last element of the path.
+	// Or_* union names keep the default type names.
+	TypeOverrides map[string]string
+
+	// Renames maps former LSP type names to the current names of the
+	// types, generating each former name as a deprecated alias of the
+	// current type to ease migrating code across specification versions.
+	// Unlike TypeOverrides, it leaves the generated types unchanged.
+	Renames map[string]string
+
+	// HandlerStruct generates ServerHandlers and ClientHandlers structs with
+	// a func field per method, adaptable to the Server and Client interfaces.
+	HandlerStruct bool
+
+	// AsyncClient generates AsyncServer and AsyncClient structs that embed
+	// the Server and Client interfaces and add a callback-based
+	// <Method>Async variant of each method.
+	AsyncClient bool
+
+	// Conn generates a Conn interface abstracting the transport, and
+	// ServerConn and ClientConn functions returning a Server and a Client
+	// that send each method to the peer over a Conn.
+	Conn bool
+
+	// Registry generates a Registry map with a MethodSpec per request and
+	// notification, for transports that route methods generically; in
+	// registry.go with SplitFiles. It also generates a UnionTypes map
+	// listing the members of every Or_* union; in json.go with SplitFiles.
+	Registry bool
+
+	// EmbedSchemas generates a Schemas map holding the JSON Schema of every
+	// generated type, in schemas.go with SplitFiles, so that a server can
+	// validate params before decoding them.
+	EmbedSchemas bool
+
+	// BitmaskEnums generates Has, Set, Clear, and String methods on integer
+	// enumerations whose values are distinct powers of two, which are bit
+	// flags, such as WatchKind.
+	BitmaskEnums bool
+
+	// FlagValueEnums generates String and Set methods on string
+	// enumerations, so that they satisfy flag.Value.
+	FlagValueEnums bool
+
+	// EnumJSON generates MarshalJSON and UnmarshalJSON methods on
+	// enumerations that encode them as their declared base type, rejecting
+	// a JSON number for a string enumeration and a JSON string for an
+	// integer one.
+	EnumJSON bool
+
+	// Binary generates MarshalBinary and UnmarshalBinary methods on
+	// structures that encode them as compact JSON, so that they satisfy
+	// encoding.BinaryMarshaler and encoding.BinaryUnmarshaler.
+	Binary bool
+
+	// NoContext leaves the context.Context parameter out of the methods of
+	// the Server and Client interfaces and of the code calling them, along
+	// with the request ID helpers that use the context.
+	NoContext bool
+
+	// IotaEnums writes integer enumerations whose values are contiguous as
+	// iota const blocks instead of explicit values.
+	IotaEnums bool
+
+	// EnumValues generates an All<Name> slice per enumeration listing its
+	// constants in declaration order, in values.go with SplitFiles.
+	EnumValues bool
+
+	// SemanticTokensHelpers generates SemanticTokenTypesLegend and
+	// SemanticTokenModifiersLegend functions returning the values of those
+	// enumerations in declaration order.
+	SemanticTokensHelpers bool
+
+	// WorkspaceEditHelpers generates ApplyWorkspaceEdit and ApplyTextEdits,
+	// which apply the text edits of a WorkspaceEdit to documents. It needs
+	// the WorkspaceEdit, TextEdit, Range, and Position structures.
+	WorkspaceEditHelpers bool
+
+	// ServerInfoHelper generates an LSPVersion constant and NewClientInfo
+	// and NewServerInfo constructors, which fill in the version from the
+	// build information of the binary. It needs the ClientInfo or
+	// ServerInfo structure.
+	ServerInfoHelper bool
+
+	// ErrorType generates a ResponseError type implementing error, with a
+	// constructor per ErrorCodes and LSPErrorCodes value. It needs the
+	// ErrorCodes enumeration.
+	ErrorType bool
+
+	// SortHelpers generates Sort<Name> functions for structures ordered by
+	// a Range or Position property, for canonical ordering in tests.
+	SortHelpers bool
+
+	// PositionHelpers generates a Before method on Position and IsEmpty,
+	// Contains, and Overlaps methods on Range, when those structures have
+	// the expected fields.
+	PositionHelpers bool
+
+	// ProgressHelpers generates constructors for the WorkDoneProgressBegin,
+	// WorkDoneProgressReport, and WorkDoneProgressEnd payloads that set
+	// their kind, with a WithPercentage method capping the percentage, and
+	// for ProgressParams, when those structures have the expected fields.
+	ProgressHelpers bool
+
+	// CapabilityAccessors generates methods on ClientCapabilities and
+	// ServerCapabilities that return a nested optional capability, such as
+	// textDocument.completion.completionItem.snippetSupport, and whether it
+	// is set, without nil checks along the way.
+	CapabilityAccessors bool
+
+	// UnknownAsInterface generates a type kind that the generator does
+	// not recognize as a placeholder type named UnknownKind_<kind>, counted
+	// in Stats, instead of as any, so that it is easy to find and report.
+	UnknownAsInterface bool
+
+	// StrictRequired generates an UnmarshalJSON method on every structure
+	// that rejects input missing a required (non-optional) property.
+	StrictRequired bool
+
+	// DedupLiterals collapses structurally identical structures into a
+	// single type, emitting the others as aliases of it. Literal property
+	// values count, and members of the same union are kept apart.
+	DedupLiterals bool
+
+	// MinifyDocs omits documentation comments from the output.
+	// @since and Deprecated annotations are still emitted.
+	MinifyDocs bool
+
+	// StrictJSONNames fails generation when more than one property of a
+	// structure has the same JSON name through the structures it embeds,
+	// instead of logging a warning.
+	StrictJSONNames bool
+
+	// Index emits doc.go, whose package comment lists every generated
+	// type. Only used with SplitFiles.
+	Index bool
+
+	// GenTests emits protocol_roundtrip_test.go, which round-trips an
+	// example of every structure through JSON. Only used with SplitFiles.
+	GenTests bool
+
+	// Tristate generates properties that are both optional and nullable
+	// (T | null) as Optional[T], which tells an absent property from a
+	// null one, instead of *T. Not used with SplitPackages.
+	Tristate bool
+
+	// JSONRPC2 generates ServerHandler and ClientHandler functions adapting
+	// the interfaces to the Handler of a jsonrpc2 package: "x-tools" for the
+	// API of golang.org/x/tools/internal/jsonrpc2, or "sourcegraph" for
+	// github.com/sourcegraph/jsonrpc2. Not used with SplitPackages.
+	JSONRPC2 string
+
+	// JSONRPC2Import is the import path of the jsonrpc2 package, for a
+	// copy or fork with the API of the JSONRPC2 flavor. Empty uses the
+	// flavor's own package.
+	JSONRPC2Import string
+
+	// BuildTags is a build constraint expression, such as "lsp" or
+	// "lsp && !nolsp", gating every generated file with a //go:build line
+	// and the matching // +build lines. Empty generates no constraint.
+	BuildTags string
+
+	// GoVersion is the oldest Go release the output must compile with,
+	// such as "1.21". Options whose code needs a newer release are left
+	// out with a warning. Empty targets the current release.
+	GoVersion string
+
 	// SplitFiles emits separate files for server, client, and JSON types.
 	// When false (default), everything goes into Protocol for backward compat.
 	SplitFiles bool
 
+	// SplitPackages moves types used only by the methods of one namespace,
+	// such as textDocument, into a subpackage of that name. Shared types
+	// stay in the base package, which must be importable as ImportPath.
+	// The Server and Client interfaces are not generated in this mode.
+	SplitPackages bool
+
+	// ImportPath is the import path of the base package. Only used with
+	// SplitPackages.
+	ImportPath string
+
 	// Source describes where the spec came from (for header comment).
 	Source string
 
@@ -64,6 +312,17 @@ type Config struct {
 
 	// LSPVersion is the protocol version (for header comment).
 	LSPVersion string
+
+	// ToolVersion is the lspls version (for header comment).
+	ToolVersion string
+
+	// Timestamp is the generation time (for header comment). Zero omits
+	// it, keeping the output reproducible.
+	Timestamp time.Time
+
+	// Logger receives per-type progress at debug level and warnings about
+	// types that degrade to any. If nil, nothing is logged.
+	Logger *slog.Logger
 }
 
 // DefaultConfig returns sensible defaults for code generation.
@@ -84,12 +343,27 @@ type Output struct {
 	Client   []byte // Client interface and dispatcher
 	Server   []byte // Server interface and dispatcher
 	JSON     []byte // Custom JSON marshaling
+	Doc      []byte // Package comment indexing the types (Index only)
+	Values   []byte // All<Enum> slices (EnumValues only)
+	Registry []byte // Method registry (Registry only)
+	Schemas  []byte // JSON Schemas of the types (EmbedSchemas only)
+	Tests    []byte // Round-trip test of the structures (GenTests only)
+	JSONRPC2 []byte // jsonrpc2 Handler adapters (JSONRPC2 only)
+
+	// Packages holds the files of subpackages by slash-separated path
+	// relative to the base package, such as "textdocument/textdocument.go"
+	// (SplitPackages only).
+	Packages map[string][]byte
 }
 
 // Generator produces Go code from an LSP model.
 type Generator struct {
 	model  *model.Model
 	config Config
+	log    *slog.Logger
+
+	// casing derives identifiers from LSP names.
+	casing *lspbase.Casing
 
 	// Generated code buffers
 	types  *orderedMap[string]
@@ -98,6 +372,9 @@ type Generator struct {
 	// Type filter (nil = all types)
 	typeFilter map[string]bool
 
+	// Method filter (nil = all methods)
+	methodFilter map[string]bool
+
 	// orTypes tracks generated Or_* union types to avoid duplicates.
 	// Key is the type name (e.g., "Or_TextEdit_AnnotatedTextEdit"), value is the type definition.
 	orTypes *orderedMap[orTypeInfo]
@@ -105,20 +382,59 @@ type Generator struct {
 	// proposedTypes caches whether a type is proposed for O(1) lookup.
 	proposedTypes map[string]bool
 
+	// deprecatedTypes holds the names of deprecated types.
+	deprecatedTypes map[string]bool
+
+	// depthLimited is set when DepDepth bounded the resolved typeFilter,
+	// so that the types left out of it are omitted.
+	depthLimited bool
+
+	// structures, enums, and aliases index the model's named types.
+	structures map[string]*model.Structure
+	enums      map[string]*model.Enumeration
+	aliases    map[string]*model.TypeAlias
+
+	// dedupAliases maps structures merged by DedupLiterals to the type they
+	// now alias.
+	dedupAliases map[string]string
+
 	// serverMethods holds methods for the Server interface (clientToServer and both).
 	serverMethods *orderedMap[methodInfo]
 
 	// clientMethods holds methods for the Client interface (serverToClient and both).
 	clientMethods *orderedMap[methodInfo]
 
+	// registryMethods holds every request and notification for the
+	// Registry, keyed by LSP method.
+	registryMethods *orderedMap[methodInfo]
+
 	// methodConsts holds method name constants (e.g., MethodTextDocumentHover = "textDocument/hover").
 	methodConsts *orderedMap[string]
+
+	// lifecycleConsts is the number of methodConsts added only because
+	// they are lifecycleMethods, for Stats. It is -1 when the lifecycle
+	// methods are not guaranteed, and IsLifecycleMethod is not generated.
+	lifecycleConsts int
+
+	// degraded holds the types generated as any because they cannot be
+	// expressed, for Stats.
+	degraded map[*model.Type]bool
+
+	// unknownKinds maps the types.keys() key of each placeholder type
+	// generated by UnknownAsInterface to its type kind.
+	unknownKinds map[string]string
+
+	// usesOptional records that a field is generated as Optional, for
+	// Tristate.
+	usesOptional bool
 }
 
 // orTypeInfo holds information about a generated Or_* type.
 type orTypeInfo struct {
-	name      string   // Type name (e.g., "Or_TextEdit_AnnotatedTextEdit")
-	itemNames []string // Sorted Go type names of union members
+	name       string        // Type name (e.g., "Or_TextEdit_AnnotatedTextEdit")
+	itemNames  []string      // Sorted Go type names of union members
+	identNames []string      // Identifier-safe names of union members, parallel to itemNames
+	items      []*model.Type // Model types of union members, parallel to itemNames
 }
 
 // methodInfo holds information about an LSP method for interface generation.
@@ -128,21 +444,50 @@ type methodInfo struct {
 	paramsType     string // Go params type (e.g., "*HoverParams"), empty if no params
 	resultType     string // Go result type (e.g., "*Hover"), empty for notifications
 	documentation  string // Method documentation
+	direction      string // Message direction (e.g., "clientToServer")
 	isNotification bool   // true for notifications, false for requests
 }
 
 // New creates a new Generator.
 func New(m *model.Model, cfg Config) *Generator {
 	g := &Generator{
-		model:         m,
-		config:        cfg,
-		types:         newOrderedMap[string](),
-		consts:        newOrderedMap[string](),
-		orTypes:       newOrderedMap[orTypeInfo](),
-		proposedTypes: buildProposedCache(m),
-		serverMethods: newOrderedMap[methodInfo](),
-		clientMethods: newOrderedMap[methodInfo](),
-		methodConsts:  newOrderedMap[string](),
+		model:           m,
+		config:          cfg,
+		types:           newOrderedMap[string](),
+		consts:          newOrderedMap[string](),
+		orTypes:         newOrderedMap[orTypeInfo](),
+		proposedTypes:   buildProposedCache(m),
+		serverMethods:   newOrderedMap[methodInfo](),
+		clientMethods:   newOrderedMap[methodInfo](),
+		registryMethods: newOrderedMap[methodInfo](),
+		methodConsts:    newOrderedMap[string](),
+		lifecycleConsts: -1,
+		dedupAliases:    make(map[string]string),
+		degraded:        make(map[*model.Type]bool),
+		unknownKinds:    make(map[string]string),
+		casing:          lspbase.NewCasing(cfg.Acronyms, cfg.NameOverrides),
+	}
+
+	g.log = cfg.Logger
+	if g.log == nil {
+		g.log = slog.New(slog.DiscardHandler)
+	}
+
+	g.deprecatedTypes = make(map[string]bool)
+	g.structures = make(map[string]*model.Structure, len(m.Structures))
+	for _, s := range m.Structures {
+		g.structures[s.Name] = s
+		g.deprecatedTypes[s.Name] = s.Deprecated != ""
+	}
+	g.enums = make(map[string]*model.Enumeration, len(m.Enumerations))
+	for _, e := range m.Enumerations {
+		g.enums[e.Name] = e
+		g.deprecatedTypes[e.Name] = e.Deprecated != ""
+	}
+	g.aliases = make(map[string]*model.TypeAlias, len(m.TypeAliases))
+	for _, a := range m.TypeAliases {
+		g.aliases[a.Name] = a
+		g.deprecatedTypes[a.Name] = a.Deprecated != ""
 	}
 
 	if len(cfg.Types) > 0 {
@@ -151,6 +496,12 @@ func New(m *model.Model, cfg Config) *Generator {
 			g.typeFilter[t] = true
 		}
 	}
+	if len(cfg.Methods) > 0 {
+		g.methodFilter = make(map[string]bool)
+		for _, method := range cfg.Methods {
+			g.methodFilter[method] = true
+		}
+	}
 
 	return g
 }
@@ -172,9 +523,36 @@ func buildProposedCache(m *model.Model) map[string]bool {
 
 // Generate produces all output files.
 func (g *Generator) Generate() (*Output, error) {
+	if err := checkTypeOverrides(g.config.TypeOverrides); err != nil {
+		return nil, err
+	}
+	if err := g.checkRenames(); err != nil {
+		return nil, err
+	}
+	if err := g.checkGoVersion(); err != nil {
+		return nil, err
+	}
+	if g.config.BuildTags != "" {
+		if _, err := buildConstraintLines(g.config.BuildTags); err != nil {
+			return nil, err
+		}
+	}
+	if g.config.Tristate && g.config.SplitPackages {
+		g.log.Warn("tristate properties are not generated with split packages")
+		g.config.Tristate = false
+	}
+	if g.config.JSONRPC2 != "" && jsonrpc2Imports[g.config.JSONRPC2] == "" {
+		return nil, fmt.Errorf("jsonrpc2 flavor %q: want x-tools or sourcegraph", g.config.JSONRPC2)
+	}
+	if g.config.JSONRPC2 != "" && g.config.SplitPackages {
+		g.log.Warn("jsonrpc2 handlers are not generated with split packages")
+		g.config.JSONRPC2 = ""
+	}
+
 	// Resolve transitive dependencies if filtering
 	if g.typeFilter != nil && g.config.ResolveDeps {
-		g.typeFilter = generator.ResolveDeps(g.model, g.typeFilter, g.config.IncludeProposed)
+		g.typeFilter = generator.ResolveDepsDepth(g.model, g.typeFilter, g.config.IncludeProposed, g.config.DepDepth)
+		g.depthLimited = g.config.DepDepth > 0
 	}
 
 	// Process all structures
@@ -182,6 +560,10 @@ func (g *Generator) Generate() (*Output, error) {
 		if !g.shouldInclude(s.Name, s.Proposed) {
 			continue
 		}
+		g.log.Debug("generating structure", "name", s.Name)
+		if err := g.checkJSONNames(s); err != nil {
+			return nil, err
+		}
 		g.generateStructure(s)
 	}
 
@@ -190,6 +572,7 @@ func (g *Generator) Generate() (*Output, error) {
 		if !g.shouldInclude(e.Name, e.Proposed) {
 			continue
 		}
+		g.log.Debug("generating enumeration", "name", e.Name)
 		g.generateEnumeration(e)
 	}
 
@@ -198,21 +581,35 @@ func (g *Generator) Generate() (*Output, error) {
 		if !g.shouldInclude(a.Name, a.Proposed) {
 			continue
 		}
+		g.log.Debug("generating type alias", "name", a.Name)
 		g.generateTypeAlias(a)
 	}
 
-	// Process requests and notifications for interface generation.
-	// Skip when filtering specific types since interfaces would reference
-	// types not included in the filtered output.
-	if g.typeFilter == nil && (g.config.GenerateServer || g.config.GenerateClient) {
+	if g.config.DedupLiterals {
+		g.dedupStructures()
+	}
+
+	// Process requests and notifications for interface generation, the
+	// Registry, and the partition of SplitPackages. Skip when filtering specific
+	// types since interfaces would reference types not included in the
+	// filtered output, unless FilteredInterfaces asks for the methods whose
+	// types are all included or Methods selects the methods themselves.
+	if (g.typeFilter == nil || g.config.FilteredInterfaces || g.methodFilter != nil) && (g.config.GenerateServer || g.config.GenerateClient || g.config.Registry || g.config.SplitPackages) {
 		g.processRequests()
 		g.processNotifications()
+		if g.config.GenerateServer || g.config.GenerateClient {
+			g.addLifecycleMethods()
+		}
 	}
 
 	out := &Output{}
 	var err error
 
-	if g.config.SplitFiles {
+	if g.config.SplitPackages {
+		if err := g.generatePackages(out); err != nil {
+			return nil, err
+		}
+	} else if g.config.SplitFiles {
 		out.Protocol, err = g.generateTypesFile()
 		if err != nil {
 			return nil, fmt.Errorf("generate protocol: %w", err)
@@ -235,7 +632,46 @@ func (g *Generator) Generate() (*Output, error) {
 				return nil, fmt.Errorf("generate json: %w", err)
 			}
 		}
+		if g.config.Index {
+			out.Doc, err = g.generateDocFile()
+			if err != nil {
+				return nil, fmt.Errorf("generate doc: %w", err)
+			}
+		}
+		if g.config.EnumValues && g.hasEnums() {
+			out.Values, err = g.generateValuesFile()
+			if err != nil {
+				return nil, fmt.Errorf("generate values: %w", err)
+			}
+		}
+		if g.config.Registry && len(g.registryMethods.keys()) > 0 {
+			out.Registry, err = g.generateRegistryFile()
+			if err != nil {
+				return nil, fmt.Errorf("generate registry: %w", err)
+			}
+		}
+		if g.config.EmbedSchemas {
+			out.Schemas, err = g.generateSchemasFile()
+			if err != nil {
+				return nil, fmt.Errorf("generate schemas: %w", err)
+			}
+		}
+		if g.config.JSONRPC2 != "" && (len(g.serverMethods.keys()) > 0 || len(g.clientMethods.keys()) > 0) {
+			out.JSONRPC2, err = g.generateJSONRPC2File()
+			if err != nil {
+				return nil, fmt.Errorf("generate jsonrpc2: %w", err)
+			}
+		}
+		if g.config.GenTests {
+			out.Tests, err = g.generateRoundTripTestFile()
+			if err != nil {
+				return nil, fmt.Errorf("generate round-trip test: %w", err)
+			}
+		}
 	} else {
+		if g.config.GenTests {
+			g.log.Warn("the round-trip test is only generated with directory output")
+		}
 		out.Protocol, err = g.generateCombinedFile()
 		if err != nil {
 			return nil, fmt.Errorf("generate protocol: %w", err)
@@ -252,7 +688,43 @@ func (g *Generator) shouldInclude(name string, proposed bool) bool {
 	if g.typeFilter != nil && !g.typeFilter[name] {
 		return false
 	}
-	return true
+	return !g.omitted(name)
+}
+
+// omitted reports whether the named type is left out as deprecated, as
+// internal, or as beyond DepDepth, so that references to it are typed as
+// any.
+func (g *Generator) omitted(name string) bool {
+	return g.config.OmitDeprecated && g.deprecatedTypes[name] || g.internal(name) || g.beyondDepth(name)
+}
+
+// internal reports whether the named type is left out by OmitInternal.
+func (g *Generator) internal(name string) bool {
+	return g.config.OmitInternal && strings.HasPrefix(name, "_")
+}
+
+// beyondDepth reports whether the named type is left out as more than
+// DepDepth references away from the Types.
+func (g *Generator) beyondDepth(name string) bool {
+	return g.depthLimited && !g.typeFilter[name]
+}
+
+// includeProperty reports whether property p is generated: proposed
+// properties need IncludeProposed, and deprecated ones are left out under
+// OmitDeprecated.
+func (g *Generator) includeProperty(p *model.Property) bool {
+	if p.Proposed && !g.config.IncludeProposed {
+		return false
+	}
+	return !g.config.OmitDeprecated || p.Deprecated == ""
+}
+
+// docs returns doc, or the empty string when documentation is minified.
+func (g *Generator) docs(doc string) string {
+	if g.config.MinifyDocs {
+		return ""
+	}
+	return doc
 }
 
 // isProposed returns true if the type with the given name is proposed.
@@ -260,103 +732,348 @@ func (g *Generator) isProposed(name string) bool {
 	return g.proposedTypes[name]
 }
 
-// generateCombinedFile produces a single file with types, unions, constants,
-// and interfaces. This is the default (SplitFiles=false) mode.
-func (g *Generator) generateCombinedFile() ([]byte, error) {
+// goFile accumulates the body of one generated Go file together with the
+// packages it references, so that a file assembled from several sections
+// gets a single, deduplicated import block.
+type goFile struct {
+	// pkg is the package name, if not Config.PackageName.
+	pkg string
+
+	imports map[string]bool
+	body    bytes.Buffer
+}
+
+func newGoFile() *goFile {
+	return &goFile{imports: make(map[string]bool)}
+}
+
+// use records that the file body references the given packages.
+func (f *goFile) use(pkgs ...string) {
+	for _, pkg := range pkgs {
+		f.imports[pkg] = true
+	}
+}
+
+// render assembles the header, package clause, merged imports, and body,
+// and formats the result. Only the packages recorded with use are
+// imported.
+func (g *Generator) render(f *goFile) ([]byte, error) {
 	var buf bytes.Buffer
 
+	pkg := f.pkg
+	if pkg == "" {
+		pkg = g.config.PackageName
+	}
 	buf.WriteString(g.fileHeader())
-	buf.WriteString("package " + g.config.PackageName + "\n\n")
+	buf.WriteString("package " + pkg + "\n\n")
 
-	hasOrTypes := len(g.orTypes.keys()) > 0
-	hasInterfaces := len(g.serverMethods.keys()) > 0 || len(g.clientMethods.keys()) > 0
+	// Types from TypeOverrides are written into the body as strings, so
+	// their packages are found in the body rather than recorded with use.
+	for _, ov := range g.config.TypeOverrides {
+		if imp := overrideImport(ov); imp != "" && bytes.Contains(f.body.Bytes(), []byte(qualifiedType(ov))) {
+			f.use(imp)
+		}
+	}
 
-	if hasOrTypes || hasInterfaces {
+	// Standard library imports come first, then other packages such as
+	// the base package of SplitPackages, which may be named differently
+	// from the last element of its import path.
+	var std, other []string
+	for _, imp := range slices.Sorted(maps.Keys(f.imports)) {
+		switch {
+		case imp != g.config.ImportPath && !strings.Contains(strings.Split(imp, "/")[0], "."):
+			std = append(std, strconv.Quote(imp))
+		case imp != g.config.ImportPath:
+			other = append(other, strconv.Quote(imp))
+		case path.Base(imp) != g.config.PackageName:
+			other = append(other, g.config.PackageName+" "+strconv.Quote(imp))
+		default:
+			other = append(other, strconv.Quote(imp))
+		}
+	}
+	switch {
+	case len(std)+len(other) == 0:
+	case len(std)+len(other) == 1:
+		fmt.Fprintf(&buf, "import %s\n\n", slices.Concat(std, other)[0])
+	default:
 		buf.WriteString("import (\n")
-		if hasInterfaces {
-			buf.WriteString("\t\"context\"\n")
+		for _, spec := range std {
+			fmt.Fprintf(&buf, "\t%s\n", spec)
 		}
-		buf.WriteString("\t\"encoding/json\"\n")
-		if hasOrTypes {
-			buf.WriteString("\t\"fmt\"\n")
+		if len(std) > 0 && len(other) > 0 {
+			buf.WriteString("\n")
+		}
+		for _, spec := range other {
+			fmt.Fprintf(&buf, "\t%s\n", spec)
 		}
 		buf.WriteString(")\n\n")
-	} else {
-		buf.WriteString("import \"encoding/json\"\n\n")
-		buf.WriteString("var _ = json.RawMessage{} // suppress unused import\n\n")
 	}
 
-	g.writeTypes(&buf)
-	buf.WriteString(g.generateOrTypes())
-	g.writeConsts(&buf)
-	buf.WriteString(g.generateInterfaces())
+	buf.Write(f.body.Bytes())
 
 	return format.Source(buf.Bytes())
 }
 
-// generateTypesFile produces protocol.go: types, enums, and constants only.
-func (g *Generator) generateTypesFile() ([]byte, error) {
-	var buf bytes.Buffer
+// writeOrTypes writes all Or_* union types to f.
+func (g *Generator) writeOrTypes(f *goFile) {
+	if len(g.orTypes.keys()) == 0 {
+		return
+	}
+	for _, name := range g.orTypes.keys() {
+		g.writeOrType(f, g.orTypes.get(name))
+	}
+}
 
-	buf.WriteString(g.fileHeader())
-	buf.WriteString("package " + g.config.PackageName + "\n\n")
-	buf.WriteString("import \"encoding/json\"\n\n")
-	buf.WriteString("var _ = json.RawMessage{} // suppress unused import\n\n")
+// writeOrType writes the Or_* union type info to f, followed by its Equal
+// method when GenerateEqual is set and its kind accessors when
+// DiscriminatedUnions is.
+func (g *Generator) writeOrType(f *goFile, info orTypeInfo) {
+	f.use("encoding/json", "fmt")
+	g.generateOrType(&f.body, info)
+	if g.config.GenerateEqual {
+		g.writeOrEqualMethod(f, info)
+	}
+	if g.config.DiscriminatedUnions {
+		g.writeKindAccessors(f, info)
+	}
+}
+
+// generateCombinedFile produces a single file with types, unions, constants,
+// and interfaces. This is the default (SplitFiles=false) mode.
+func (g *Generator) generateCombinedFile() ([]byte, error) {
+	f := newGoFile()
+
+	g.writeTypes(f)
+	g.writeRenames(f)
+	g.writeOrTypes(f)
+	g.writeConsts(&f.body)
+	if g.config.EnumValues {
+		g.writeAllEnumValues(f)
+	}
+	if g.config.SemanticTokensHelpers {
+		g.writeSemanticTokensLegends(f)
+	}
+	if g.config.WorkspaceEditHelpers {
+		g.writeWorkspaceEditHelpers(f)
+	}
+	if g.config.ServerInfoHelper {
+		g.writeInfoHelpers(f)
+	}
+	if g.config.ErrorType {
+		g.writeResponseError(f)
+	}
+	g.writeProposedTables(&f.body)
+	if len(g.serverMethods.keys()) > 0 || len(g.clientMethods.keys()) > 0 {
+		g.useContext(f)
+	}
+	f.body.WriteString(g.generateInterfaces())
+	if g.config.HandlerStruct && (len(g.serverMethods.keys()) > 0 || len(g.clientMethods.keys()) > 0) {
+		g.writeHandlers(f, "Server", g.serverMethods)
+		g.writeHandlers(f, "Client", g.clientMethods)
+		g.writeErrMethodNotFound(f)
+	}
+	if g.config.AsyncClient {
+		g.writeAsync(f, "Server", g.serverMethods)
+		g.writeAsync(f, "Client", g.clientMethods)
+	}
+	if g.config.Conn && (len(g.serverMethods.keys()) > 0 || len(g.clientMethods.keys()) > 0) {
+		g.writeConnInterface(f)
+		g.writeConn(f, "Server", g.serverMethods)
+		g.writeConn(f, "Client", g.clientMethods)
+	}
+	if g.config.Registry {
+		g.writeRegistry(f)
+		g.writeUnionTypes(f)
+	}
+	if g.config.JSONRPC2 != "" {
+		g.writeJSONRPC2(f)
+	}
+	if g.config.EmbedSchemas {
+		if err := g.writeSchemas(f); err != nil {
+			return nil, err
+		}
+	}
 
-	g.writeTypes(&buf)
-	g.writeConsts(&buf)
+	return g.render(f)
+}
 
-	return format.Source(buf.Bytes())
+// generateTypesFile produces protocol.go: types, enums, and constants only.
+func (g *Generator) generateTypesFile() ([]byte, error) {
+	f := newGoFile()
+
+	g.writeTypes(f)
+	g.writeRenames(f)
+	g.writeConsts(&f.body)
+	// Method constants left with no server.go, client.go, or registry.go,
+	// such as the lifecycle methods when filtering leaves no other, go here.
+	if len(g.serverMethods.keys()) == 0 && len(g.clientMethods.keys()) == 0 && !(g.config.Registry && len(g.registryMethods.keys()) > 0) {
+		f.body.WriteString(g.generateMethodConstants())
+	}
+	if g.config.SemanticTokensHelpers {
+		g.writeSemanticTokensLegends(f)
+	}
+	if g.config.WorkspaceEditHelpers {
+		g.writeWorkspaceEditHelpers(f)
+	}
+	if g.config.ServerInfoHelper {
+		g.writeInfoHelpers(f)
+	}
+	if g.config.ErrorType {
+		g.writeResponseError(f)
+	}
+	g.writeProposedTables(&f.body)
+
+	return g.render(f)
 }
 
 // generateServerFile produces server.go: method constants and Server interface.
 func (g *Generator) generateServerFile() ([]byte, error) {
-	var buf bytes.Buffer
+	f := newGoFile()
+	g.useContext(f)
+
+	f.body.WriteString(g.generateMethodConstants())
+	f.body.WriteString(g.generateInterface("Server", g.serverMethods))
+	g.writeRequestIDHelpers(&f.body)
+	if g.config.HandlerStruct {
+		g.writeHandlers(f, "Server", g.serverMethods)
+		g.writeErrMethodNotFound(f)
+	}
+	if g.config.AsyncClient {
+		g.writeAsync(f, "Server", g.serverMethods)
+	}
+	if g.config.Conn {
+		g.writeConnInterface(f)
+		g.writeConn(f, "Server", g.serverMethods)
+	}
 
-	buf.WriteString(g.fileHeader())
-	buf.WriteString("package " + g.config.PackageName + "\n\n")
-	buf.WriteString("import \"context\"\n\n")
+	return g.render(f)
+}
 
-	buf.WriteString(g.generateMethodConstants())
-	buf.WriteString(g.generateInterface("Server", g.serverMethods))
+// generateClientFile produces client.go: the Client interface, with the
+// method constants when there is no server.go.
+func (g *Generator) generateClientFile() ([]byte, error) {
+	f := newGoFile()
+	g.useContext(f)
 
-	return format.Source(buf.Bytes())
+	// The method constants and helpers live in server.go when there is
+	// one.
+	if len(g.serverMethods.keys()) == 0 {
+		f.body.WriteString(g.generateMethodConstants())
+	}
+	f.body.WriteString(g.generateInterface("Client", g.clientMethods))
+	if len(g.serverMethods.keys()) == 0 {
+		g.writeRequestIDHelpers(&f.body)
+	}
+	if g.config.HandlerStruct {
+		g.writeHandlers(f, "Client", g.clientMethods)
+		if len(g.serverMethods.keys()) == 0 {
+			g.writeErrMethodNotFound(f)
+		}
+	}
+	if g.config.AsyncClient {
+		g.writeAsync(f, "Client", g.clientMethods)
+	}
+	if g.config.Conn {
+		if len(g.serverMethods.keys()) == 0 {
+			g.writeConnInterface(f)
+		}
+		g.writeConn(f, "Client", g.clientMethods)
+	}
+
+	return g.render(f)
 }
 
-// generateClientFile produces client.go: method constants and Client interface.
-func (g *Generator) generateClientFile() ([]byte, error) {
-	var buf bytes.Buffer
+// generateRegistryFile produces registry.go: the method registry, with the
+// method constants when neither server.go nor client.go carries them.
+func (g *Generator) generateRegistryFile() ([]byte, error) {
+	f := newGoFile()
 
-	buf.WriteString(g.fileHeader())
-	buf.WriteString("package " + g.config.PackageName + "\n\n")
-	buf.WriteString("import \"context\"\n\n")
+	if len(g.serverMethods.keys()) == 0 && len(g.clientMethods.keys()) == 0 {
+		f.body.WriteString(g.generateMethodConstants())
+	}
+	g.writeRegistry(f)
 
-	buf.WriteString(g.generateMethodConstants())
-	buf.WriteString(g.generateInterface("Client", g.clientMethods))
+	return g.render(f)
+}
 
-	return format.Source(buf.Bytes())
+// generateJSONRPC2File produces jsonrpc2.go: the jsonrpc2 Handler adapters.
+func (g *Generator) generateJSONRPC2File() ([]byte, error) {
+	f := newGoFile()
+
+	g.writeJSONRPC2(f)
+
+	return g.render(f)
 }
 
 // generateJSONFile produces json.go: Or_* union types with JSON marshal/unmarshal.
 func (g *Generator) generateJSONFile() ([]byte, error) {
-	var buf bytes.Buffer
-
-	buf.WriteString(g.fileHeader())
-	buf.WriteString("package " + g.config.PackageName + "\n\n")
-	buf.WriteString("import (\n")
-	buf.WriteString("\t\"encoding/json\"\n")
-	buf.WriteString("\t\"fmt\"\n")
-	buf.WriteString(")\n\n")
+	f := newGoFile()
 
-	buf.WriteString(g.generateOrTypes())
+	g.writeOrTypes(f)
+	if g.config.Registry {
+		g.writeUnionTypes(f)
+	}
 
-	return format.Source(buf.Bytes())
+	return g.render(f)
 }
 
-// writeTypes writes all type definitions to buf.
-func (g *Generator) writeTypes(buf *bytes.Buffer) {
+// writeTypes writes all type definitions to f, each structure followed by
+// its Equal method when GenerateEqual is set.
+func (g *Generator) writeTypes(f *goFile) {
 	for _, name := range g.types.keys() {
-		buf.WriteString(g.types.get(name))
+		g.writeType(f, name)
+	}
+	if g.config.GenerateEqual && len(g.types.keys()) > 0 {
+		f.body.WriteString(equalPtrHelper)
+	}
+	g.writeOptional(f)
+}
+
+// writeType writes the definition of the named type to f, followed by the
+// methods and helpers the configuration adds to structures.
+func (g *Generator) writeType(f *goFile, name string) {
+	f.body.WriteString(g.types.get(name))
+	if a, ok := g.aliases[name]; ok {
+		if ov, ok := g.override(a); ok {
+			f.use(ov.imports...)
+		}
+	}
+	if e, ok := g.enums[name]; ok && g.defaultBaseType(e.Type) == "string" {
+		g.writeTextMethods(f, e)
+		if g.config.FlagValueEnums {
+			g.writeFlagValueMethods(f, e)
+		}
+	}
+	if e, ok := g.enums[name]; ok && g.config.BitmaskEnums && g.isBitmask(e) {
+		g.writeBitmaskMethods(f, e)
+	}
+	if e, ok := g.enums[name]; ok && g.config.EnumJSON {
+		g.writeEnumJSONMethods(f, e)
+	}
+	s, ok := g.structures[name]
+	if _, merged := g.dedupAliases[name]; !ok || merged {
+		return
+	}
+	if g.config.StrictRequired {
+		g.writeStrictUnmarshal(f, s)
+	}
+	if g.config.GenerateEqual {
+		g.writeEqualMethod(f, s)
+	}
+	if g.config.Binary {
+		g.writeBinaryMethods(f, s)
+	}
+	if g.config.SortHelpers {
+		g.writeSortHelpers(f, s)
+	}
+	if g.config.PositionHelpers {
+		g.writePositionHelpers(f, s)
+	}
+	if g.config.ProgressHelpers {
+		g.writeProgressHelpers(f, s)
+	}
+	if g.config.CapabilityAccessors {
+		g.writeCapabilityAccessors(f, s)
 	}
 }
 
@@ -372,8 +1089,53 @@ func (g *Generator) writeConsts(buf *bytes.Buffer) {
 	}
 }
 
+// writeProposedTables writes the ProposedTypes and ProposedMethods lookup
+// tables to buf. They are only emitted when proposed features are included,
+// since otherwise no proposed type or method is generated.
+func (g *Generator) writeProposedTables(buf *bytes.Buffer) {
+	if !g.config.IncludeProposed {
+		return
+	}
+
+	var types, methods []string
+	for name, proposed := range g.proposedTypes {
+		if proposed {
+			types = append(types, name)
+		}
+	}
+	for _, r := range g.model.Requests {
+		if r.Proposed && g.includeMethod(r.Method, r.Proposed) {
+			methods = append(methods, r.Method)
+		}
+	}
+	for _, n := range g.model.Notifications {
+		if n.Proposed && g.includeMethod(n.Method, n.Proposed) {
+			methods = append(methods, n.Method)
+		}
+	}
+
+	buf.WriteString("// ProposedTypes reports which LSP type names are proposed (unstable).\n")
+	writeStringSet(buf, "ProposedTypes", slices.Sorted(slices.Values(types)))
+	buf.WriteString("// ProposedMethods reports which LSP method names are proposed (unstable).\n")
+	writeStringSet(buf, "ProposedMethods", slices.Sorted(slices.Values(methods)))
+}
+
+// writeStringSet writes a map[string]bool variable holding names.
+func writeStringSet(buf *bytes.Buffer, name string, names []string) {
+	fmt.Fprintf(buf, "var %s = map[string]bool{\n", name)
+	for _, n := range names {
+		fmt.Fprintf(buf, "\t%q: true,\n", n)
+	}
+	buf.WriteString("}\n\n")
+}
+
 func (g *Generator) fileHeader() string {
 	var lines []string
+	if g.config.BuildTags != "" {
+		// Generate has checked the expression.
+		constraint, _ := buildConstraintLines(g.config.BuildTags)
+		lines = append(append(lines, constraint...), "")
+	}
 	lines = append(lines, "// Code generated by lspls. DO NOT EDIT.")
 	if g.config.Source != "" {
 		lines = append(lines, fmt.Sprintf("// Source: %s", g.config.Source))
@@ -387,6 +1149,12 @@ func (g *Generator) fileHeader() string {
 	if g.config.LSPVersion != "" {
 		lines = append(lines, fmt.Sprintf("// LSP Version: %s", g.config.LSPVersion))
 	}
+	if g.config.ToolVersion != "" {
+		lines = append(lines, fmt.Sprintf("// Generator: lspls %s", g.config.ToolVersion))
+	}
+	if !g.config.Timestamp.IsZero() {
+		lines = append(lines, fmt.Sprintf("// Generated: %s", g.config.Timestamp.UTC().Format(time.RFC3339)))
+	}
 	lines = append(lines, "")
 	return strings.Join(lines, "\n")
 }
@@ -8,9 +8,11 @@ import (
 	"os"
 	"path/filepath"
 	"slices"
+	"strconv"
 	"strings"
 	"testing"
 
+	"github.com/albertocavalcante/lspls/generator"
 	"github.com/albertocavalcante/lspls/generators/golang"
 	"github.com/albertocavalcante/lspls/internal/testutil"
 	"github.com/albertocavalcante/lspls/model"
@@ -80,22 +82,107 @@ func runCodegen(input []byte, flags []string) (map[string][]byte, error) {
 
 	// Configure code generation
 	cfg := golang.Config{
-		PackageName:     "protocol",
-		ResolveDeps:     true, // Default to true to match CLI behavior
-		IncludeProposed: slices.Contains(flags, "proposed"),
-		GenerateServer:  slices.Contains(flags, "server"),
-		GenerateClient:  slices.Contains(flags, "client"),
-		SplitFiles:      slices.Contains(flags, "split-files"),
+		PackageName:           "protocol",
+		ResolveDeps:           true, // Default to true to match CLI behavior
+		IncludeProposed:       slices.Contains(flags, "proposed"),
+		GenerateServer:        slices.Contains(flags, "server"),
+		GenerateClient:        slices.Contains(flags, "client"),
+		SplitFiles:            slices.Contains(flags, "split-files"),
+		MinifyDocs:            slices.Contains(flags, "minify-docs"),
+		GenerateEqual:         slices.Contains(flags, "equal"),
+		DiscriminatedUnions:   slices.Contains(flags, "discriminated-unions"),
+		DedupLiterals:         slices.Contains(flags, "dedup-literals"),
+		StrictRequired:        slices.Contains(flags, "strict-required"),
+		IotaEnums:             slices.Contains(flags, "iota-enums"),
+		BitmaskEnums:          slices.Contains(flags, "bitmask-enums"),
+		FlagValueEnums:        slices.Contains(flags, "flag-value-enums"),
+		EnumJSON:              slices.Contains(flags, "enum-json"),
+		Binary:                slices.Contains(flags, "binary"),
+		HandlerStruct:         slices.Contains(flags, "handler-struct"),
+		NoContext:             slices.Contains(flags, "no-context"),
+		AsyncClient:           slices.Contains(flags, "async-client"),
+		Conn:                  slices.Contains(flags, "conn"),
+		SemanticTokensHelpers: slices.Contains(flags, "semantic-tokens-helpers"),
+		WorkspaceEditHelpers:  slices.Contains(flags, "workspace-edit-helpers"),
+		OnlyStableMethods:     slices.Contains(flags, "only-stable-methods"),
+		SortHelpers:           slices.Contains(flags, "sort-helpers"),
+		PositionHelpers:       slices.Contains(flags, "position-helpers"),
+		ProgressHelpers:       slices.Contains(flags, "progress-helpers"),
+		CapabilityAccessors:   slices.Contains(flags, "capability-accessors"),
+		OmitDeprecated:        slices.Contains(flags, "no-deprecated"),
+		OmitInternal:          slices.Contains(flags, "no-internal"),
+		EnumValues:            slices.Contains(flags, "enum-values"),
+		Registry:              slices.Contains(flags, "registry"),
+		ErrorType:             slices.Contains(flags, "error-type"),
+		ServerInfoHelper:      slices.Contains(flags, "server-info-helper"),
+		EmbedSchemas:          slices.Contains(flags, "embed-schemas"),
+		FilteredInterfaces:    slices.Contains(flags, "filtered-interfaces"),
+		RawAny:                slices.Contains(flags, "raw-any"),
+		Index:                 slices.Contains(flags, "index"),
+		GenTests:              slices.Contains(flags, "gen-tests"),
+		Tristate:              slices.Contains(flags, "tristate"),
 	}
 
 	// Parse type filter from flags
 	for _, f := range flags {
+		// Flags are comma-separated, so type lists use "+".
 		if typeList, ok := strings.CutPrefix(f, "types="); ok {
-			cfg.Types = strings.Split(typeList, ",")
+			cfg.Types = strings.Split(typeList, "+")
+		}
+		if depth, ok := strings.CutPrefix(f, "dep-depth="); ok {
+			n, err := strconv.Atoi(depth)
+			if err != nil {
+				return nil, err
+			}
+			cfg.DepDepth = n
+		}
+		if flavor, ok := strings.CutPrefix(f, "jsonrpc2="); ok {
+			cfg.JSONRPC2 = flavor
+		}
+		if importPath, ok := strings.CutPrefix(f, "jsonrpc2-import="); ok {
+			cfg.JSONRPC2Import = importPath
+		}
+		if tags, ok := strings.CutPrefix(f, "build-tags="); ok {
+			cfg.BuildTags = tags
+		}
+		if goVersion, ok := strings.CutPrefix(f, "go-version="); ok {
+			cfg.GoVersion = goVersion
+		}
+		if methodList, ok := strings.CutPrefix(f, "methods="); ok {
+			cfg.Methods = strings.Split(methodList, "+")
+			types, err := generator.MethodTypes(&m, cfg.Methods)
+			if err != nil {
+				return nil, err
+			}
+			cfg.Types = append(cfg.Types, types...)
 		}
 		if pkgName, ok := strings.CutPrefix(f, "package="); ok {
 			cfg.PackageName = pkgName
 		}
+		if prefix, ok := strings.CutPrefix(f, "type-prefix="); ok {
+			cfg.TypePrefix = prefix
+		}
+		if suffix, ok := strings.CutPrefix(f, "type-suffix="); ok {
+			cfg.TypeSuffix = suffix
+		}
+		if importPath, ok := strings.CutPrefix(f, "split-packages="); ok {
+			cfg.SplitPackages = true
+			cfg.ImportPath = importPath
+		}
+		if override, ok := strings.CutPrefix(f, "type-override="); ok {
+			name, typ, _ := strings.Cut(override, "=")
+			if cfg.TypeOverrides == nil {
+				cfg.TypeOverrides = make(map[string]string)
+			}
+			cfg.TypeOverrides[name] = typ
+		}
+		if rename, ok := strings.CutPrefix(f, "rename="); ok {
+			old, current, _ := strings.Cut(rename, "=")
+			if cfg.Renames == nil {
+				cfg.Renames = make(map[string]string)
+			}
+			cfg.Renames[old] = current
+		}
 		if f == "no-resolve-deps" {
 			cfg.ResolveDeps = false
 		}
@@ -121,6 +208,27 @@ func runCodegen(input []byte, flags []string) (map[string][]byte, error) {
 	if out.JSON != nil {
 		result["json.go"] = stripGeneratedHeader(out.JSON)
 	}
+	if out.Doc != nil {
+		result["doc.go"] = stripGeneratedHeader(out.Doc)
+	}
+	if out.Values != nil {
+		result["values.go"] = stripGeneratedHeader(out.Values)
+	}
+	if out.JSONRPC2 != nil {
+		result["jsonrpc2.go"] = stripGeneratedHeader(out.JSONRPC2)
+	}
+	if out.Registry != nil {
+		result["registry.go"] = stripGeneratedHeader(out.Registry)
+	}
+	if out.Schemas != nil {
+		result["schemas.go"] = stripGeneratedHeader(out.Schemas)
+	}
+	if out.Tests != nil {
+		result["protocol_roundtrip_test.go"] = stripGeneratedHeader(out.Tests)
+	}
+	for path, content := range out.Packages {
+		result[path] = stripGeneratedHeader(content)
+	}
 
 	return result, nil
 }
@@ -133,8 +241,8 @@ func stripGeneratedHeader(content []byte) []byte {
 	inHeader := true
 
 	for _, line := range lines {
-		// Keep the "Code generated" line
-		if strings.HasPrefix(line, "// Code generated by lspls") {
+		// Keep the "Code generated" line and build constraints
+		if strings.HasPrefix(line, "// Code generated by lspls") || strings.HasPrefix(line, "// +build ") {
 			result = append(result, line)
 			continue
 		}
@@ -0,0 +1,73 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package golang
+
+import (
+	"fmt"
+	"strings"
+)
+
+// writeConnInterface writes the Conn interface the <Name>Conn adapters send
+// methods over. It is emitted once per package.
+func (g *Generator) writeConnInterface(f *goFile) {
+	f.use("context")
+	f.body.WriteString(connInterface)
+}
+
+const connInterface = `// Conn is a connection to the peer over any transport, such as stdio, TCP,
+// or an in-memory pipe. Call sends a request and decodes its result into
+// result, a pointer; Notify sends a notification. Params are nil for
+// methods without params.
+type Conn interface {
+	Call(ctx context.Context, method string, params, result any) error
+	Notify(ctx context.Context, method string, params any) error
+}
+
+`
+
+// writeConn writes a <name>Conn function returning a name that sends each
+// method to the peer over a Conn: requests with Call, decoding their
+// result, and notifications with Notify. Under NoContext, the methods pass
+// context.Background() to the Conn.
+func (g *Generator) writeConn(f *goFile, name string, methods *orderedMap[methodInfo]) {
+	keys := methods.keys()
+	if len(keys) == 0 {
+		return
+	}
+	f.use("context")
+
+	adapter := strings.ToLower(name[:1]) + name[1:] + "Conn"
+	buf := &f.body
+
+	fmt.Fprintf(buf, "// %sConn returns a %s that sends each method to the %s over conn.\n", name, name, strings.ToLower(name))
+	fmt.Fprintf(buf, "func %sConn(conn Conn) %s {\n", name, name)
+	fmt.Fprintf(buf, "\treturn %s{conn}\n", adapter)
+	buf.WriteString("}\n\n")
+
+	fmt.Fprintf(buf, "type %s struct{ conn Conn }\n\n", adapter)
+
+	ctx := "ctx"
+	if g.config.NoContext {
+		ctx = "context.Background()"
+	}
+	for _, key := range keys {
+		info := methods.get(key)
+		params := "nil"
+		if info.paramsType != "" {
+			params = "params"
+		}
+		fmt.Fprintf(buf, "func (c %s) %s%s {\n", adapter, info.name, g.methodSignature(info, true))
+		if info.isNotification {
+			fmt.Fprintf(buf, "\treturn c.conn.Notify(%s, %s, %s)\n", ctx, g.methodConst(info.name), params)
+		} else {
+			fmt.Fprintf(buf, "\tvar result %s\n", info.resultType)
+			fmt.Fprintf(buf, "\terr := c.conn.Call(%s, %s, %s, &result)\n", ctx, g.methodConst(info.name), params)
+			buf.WriteString("\treturn result, err\n")
+		}
+		buf.WriteString("}\n\n")
+	}
+}
@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: MIT
+
+package golang_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/lspls/generators/golang"
+)
+
+// connRuntimeTest drives the ServerConn and ClientConn generated for
+// testdata/conn.txtar with a fake Conn that records each call and answers
+// requests with canned JSON results.
+const connRuntimeTest = `package protocol
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+type ctxKey struct{}
+
+// fakeConn records the methods sent over it, failing unless the context
+// carries ctxKey.
+type fakeConn struct {
+	t       *testing.T
+	results map[string]string
+	sent    []string
+}
+
+func (c *fakeConn) Call(ctx context.Context, method string, params, result any) error {
+	c.record(ctx, method, params)
+	return json.Unmarshal([]byte(c.results[method]), result)
+}
+
+func (c *fakeConn) Notify(ctx context.Context, method string, params any) error {
+	c.record(ctx, method, params)
+	return nil
+}
+
+func (c *fakeConn) record(ctx context.Context, method string, params any) {
+	if ctx.Value(ctxKey{}) == nil {
+		c.t.Errorf("%s: context not passed to the Conn", method)
+	}
+	data, err := json.Marshal(params)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	c.sent = append(c.sent, method+" "+string(data))
+}
+
+func TestConn(t *testing.T) {
+	ctx := context.WithValue(context.Background(), ctxKey{}, true)
+	conn := &fakeConn{t: t, results: map[string]string{
+		MethodInitialize:               "{}",
+		MethodShutdown:                 "null",
+		MethodClientRegisterCapability: "null",
+	}}
+	var _ Conn = conn
+
+	server := ServerConn(conn)
+	result, err := server.Initialize(ctx, &InitializeParams{})
+	if err != nil || result == nil {
+		t.Fatalf("Initialize = %v, %v, want a result", result, err)
+	}
+	if err := server.Initialized(ctx, &InitializedParams{}); err != nil {
+		t.Fatal(err)
+	}
+	if result, err := server.Shutdown(ctx); err != nil || result != nil {
+		t.Fatalf("Shutdown = %v, %v, want nil", result, err)
+	}
+
+	client := ClientConn(conn)
+	if _, err := client.ClientRegisterCapability(ctx, &RegistrationParams{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.WindowLogMessage(ctx, &LogMessageParams{}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"initialize {}",
+		"initialized {}",
+		"shutdown null",
+		"client/registerCapability {}",
+		"window/logMessage {}",
+	}
+	if !slices.Equal(conn.sent, want) {
+		t.Errorf("sent %q, want %q", conn.sent, want)
+	}
+}
+
+func TestConnError(t *testing.T) {
+	conn := &fakeConn{t: t, results: map[string]string{MethodInitialize: "not json"}}
+	ctx := context.WithValue(context.Background(), ctxKey{}, true)
+	if _, err := ServerConn(conn).Initialize(ctx, &InitializeParams{}); err == nil {
+		t.Error("Initialize succeeded, want the Conn's error")
+	}
+}
+`
+
+func TestConnRuntime(t *testing.T) {
+	cfg := golang.DefaultConfig()
+	cfg.GenerateServer = true
+	cfg.GenerateClient = true
+	cfg.Conn = true
+	runGenerated(t, "conn.txtar", cfg, connRuntimeTest)
+}
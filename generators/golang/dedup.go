@@ -0,0 +1,171 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package golang
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/albertocavalcante/lspls/model"
+)
+
+// dedupStructures collapses structurally identical structures into one type.
+// The first structure (by name) with a given body is kept; the others are
+// rewritten as aliases of it, keeping their own doc comments. It runs after
+// generation, over the rendered bodies in g.types. Structures whose literal
+// properties have different values, such as the kind of CreateFile and
+// DeleteFile, differ, and two members of the same union are never merged,
+// since the union could no longer tell them apart.
+func (g *Generator) dedupStructures() {
+	peers := g.unionPeers()
+	groups := make(map[string][][]string) // body hash -> kept type name, then the types merged into it
+	for _, name := range g.types.keys() {
+		s, ok := g.structures[name]
+		if !ok {
+			continue
+		}
+		doc, hash := splitStructBody(g.types.get(name), literalValues(s))
+		i := slices.IndexFunc(groups[hash], func(group []string) bool {
+			return !slices.ContainsFunc(group, func(member string) bool { return peers[name][member] })
+		})
+		if i < 0 {
+			groups[hash] = append(groups[hash], []string{name})
+			continue
+		}
+		kept := groups[hash][i][0]
+		groups[hash][i] = append(groups[hash][i], name)
+		g.log.Debug("merged identical structure", "name", name, "into", kept)
+		g.dedupAliases[name] = kept
+		g.types.set(name, doc+"type "+g.typeName(name)+" = "+g.typeName(kept)+"\n\n")
+	}
+}
+
+// splitStructBody splits a rendered structure into its leading doc comment
+// and a hash of its normalized body: comments dropped and the type name
+// removed, so that only the fields and their tags are compared. Literal
+// values, which the body renders as their base types, are hashed too.
+func splitStructBody(src string, literals []string) (doc, hash string) {
+	var docLines, body []string
+	inDoc := true
+	for line := range strings.SplitSeq(src, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "//") {
+			if inDoc {
+				docLines = append(docLines, line+"\n")
+			}
+			continue
+		}
+		if inDoc && strings.HasPrefix(trimmed, "type ") {
+			inDoc = false
+			_, rest, _ := strings.Cut(trimmed, " struct ")
+			line = "struct " + rest
+		}
+		body = append(body, line)
+	}
+	body = append(body, literals...)
+	sum := sha256.Sum256([]byte(strings.Join(body, "\n")))
+	return strings.Join(docLines, ""), hex.EncodeToString(sum[:])
+}
+
+// literalValues returns name=value for each property of s whose type is
+// a string, integer, or boolean literal.
+func literalValues(s *model.Structure) []string {
+	var values []string
+	for _, p := range s.Properties {
+		switch p.Type.Kind {
+		case "stringLiteral", "integerLiteral", "booleanLiteral":
+			values = append(values, fmt.Sprintf("%s=%s:%v", p.Name, p.Type.Kind, p.Type.Value))
+		}
+	}
+	return values
+}
+
+// unionPeers maps each type that is a member of a union anywhere in the
+// model to the other members of the unions it is in, directly or as array
+// elements of the same depth: merging a pair would leave the union two
+// identical members, such as []TextEdit and []AnnotatedTextEdit.
+func (g *Generator) unionPeers() map[string]map[string]bool {
+	peers := make(map[string]map[string]bool)
+	var visit func(t *model.Type)
+	visit = func(t *model.Type) {
+		if t == nil {
+			return
+		}
+		switch t.Kind {
+		case "or":
+			for _, a := range t.Items {
+				for _, b := range t.Items {
+					nameA, depthA := unionMember(a)
+					nameB, depthB := unionMember(b)
+					if nameA == "" || nameB == "" || nameA == nameB || depthA != depthB {
+						continue
+					}
+					if peers[nameA] == nil {
+						peers[nameA] = make(map[string]bool)
+					}
+					peers[nameA][nameB] = true
+				}
+			}
+			for _, item := range t.Items {
+				visit(item)
+			}
+		case "and", "tuple":
+			for _, item := range t.Items {
+				visit(item)
+			}
+		case "array":
+			visit(t.Element)
+		case "map":
+			visit(t.Key)
+			if vt, ok := t.Value.(*model.Type); ok {
+				visit(vt)
+			}
+		case "literal":
+			if lit, ok := t.Value.(model.Literal); ok {
+				for _, p := range lit.Properties {
+					visit(p.Type)
+				}
+			}
+		}
+	}
+	for _, s := range g.model.Structures {
+		for _, p := range s.Properties {
+			visit(p.Type)
+		}
+	}
+	for _, a := range g.model.TypeAliases {
+		visit(a.Type)
+	}
+	for _, r := range g.model.Requests {
+		visit(r.Params)
+		visit(r.Result)
+		visit(r.PartialResult)
+		visit(r.RegistrationOptions)
+		visit(r.ErrorData)
+	}
+	for _, n := range g.model.Notifications {
+		visit(n.Params)
+		visit(n.RegistrationOptions)
+	}
+	return peers
+}
+
+// unionMember returns the type a union member refers to, directly or as
+// the element of depth nested arrays, or "" if it refers to none.
+func unionMember(t *model.Type) (name string, depth int) {
+	for t.Kind == "array" {
+		t = t.Element
+		depth++
+	}
+	if t.Kind != "reference" {
+		return "", 0
+	}
+	return t.Name, depth
+}
@@ -0,0 +1,45 @@
+// SPDX-License-Identifier: MIT
+
+package golang_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/lspls/generators/golang"
+)
+
+// dedupRuntimeTest checks that the unions of
+// testdata/dedup_literals_unions.txtar compile and still decode each
+// member by its kind after --dedup-literals.
+const dedupRuntimeTest = `package protocol
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestDedupKeepsUnionMembers(t *testing.T) {
+	var e WorkspaceEdit
+	in := ` + "`" + `{"operations": [{"kind": "create", "uri": "a"}, {"kind": "delete", "uri": "b"}]}` + "`" + `
+	if err := json.Unmarshal([]byte(in), &e); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := e.Operations[0].AsCreateFile(); !ok {
+		t.Errorf("operations[0] = %T, want CreateFile", e.Operations[0].Value)
+	}
+	if d, ok := e.Operations[1].AsDeleteFile(); !ok || d.Uri != "b" {
+		t.Errorf("operations[1] = %#v, want DeleteFile b", e.Operations[1].Value)
+	}
+
+	// TextDocumentIdentifier, in no union, is an alias of Location.
+	var id TextDocumentIdentifier = Location{Uri: "c"}
+	_ = id
+}
+`
+
+func TestDedupLiteralsUnionsRuntime(t *testing.T) {
+	cfg := golang.DefaultConfig()
+	cfg.DedupLiterals = true
+	cfg.DiscriminatedUnions = true
+	runGenerated(t, "dedup_literals_unions.txtar", cfg, dedupRuntimeTest)
+}
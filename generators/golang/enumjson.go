@@ -0,0 +1,56 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package golang
+
+import (
+	"fmt"
+
+	"github.com/albertocavalcante/lspls/model"
+)
+
+// writeEnumJSONMethods writes MarshalJSON and UnmarshalJSON for the
+// enumeration e, encoding it as its declared base type: a JSON string for
+// string enumerations and a JSON number for integer ones. UnmarshalJSON
+// reports an error for the other kind, and string enumerations are
+// validated like UnmarshalText. A JSON null leaves the value unchanged.
+func (g *Generator) writeEnumJSONMethods(f *goFile, e *model.Enumeration) {
+	name := g.typeName(e.Name)
+	base := g.defaultBaseType(e.Type)
+	buf := &f.body
+	f.use("encoding/json", "fmt")
+
+	fmt.Fprintf(buf, "// MarshalJSON implements json.Marshaler, encoding x as a JSON %s.\n", jsonKind(base))
+	fmt.Fprintf(buf, "func (x %s) MarshalJSON() ([]byte, error) {\n", name)
+	fmt.Fprintf(buf, "\treturn json.Marshal(%s(x))\n", base)
+	buf.WriteString("}\n\n")
+
+	fmt.Fprintf(buf, "// UnmarshalJSON implements json.Unmarshaler, accepting only a JSON %s.\n", jsonKind(base))
+	fmt.Fprintf(buf, "func (x *%s) UnmarshalJSON(data []byte) error {\n", name)
+	buf.WriteString("\tif string(data) == \"null\" {\n")
+	buf.WriteString("\t\treturn nil\n")
+	buf.WriteString("\t}\n")
+	fmt.Fprintf(buf, "\tvar v %s\n", base)
+	buf.WriteString("\tif err := json.Unmarshal(data, &v); err != nil {\n")
+	fmt.Fprintf(buf, "\t\treturn fmt.Errorf(\"%s: %%w\", err)\n", name)
+	buf.WriteString("\t}\n")
+	if base == "string" {
+		buf.WriteString("\treturn x.UnmarshalText([]byte(v))\n")
+	} else {
+		fmt.Fprintf(buf, "\t*x = %s(v)\n", name)
+		buf.WriteString("\treturn nil\n")
+	}
+	buf.WriteString("}\n\n")
+}
+
+// jsonKind returns the JSON type that values of the Go base type of an
+// enumeration encode as.
+func jsonKind(base string) string {
+	if base == "string" {
+		return "string"
+	}
+	return "number"
+}
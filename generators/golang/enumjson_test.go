@@ -0,0 +1,73 @@
+// SPDX-License-Identifier: MIT
+
+package golang_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/lspls/generators/golang"
+)
+
+// enumJSONRuntimeTest checks that the enumerations generated for
+// testdata/enum_json.txtar round-trip through JSON as their base type and
+// reject the other kind of JSON value.
+const enumJSONRuntimeTest = `package protocol
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+type settings struct {
+	Kind     MarkupKind         ` + "`json:\"kind\"`" + `
+	Severity DiagnosticSeverity ` + "`json:\"severity\"`" + `
+}
+
+func TestEnumRoundTrip(t *testing.T) {
+	in := settings{Kind: MarkupKindMarkdown, Severity: DiagnosticSeverityWarning}
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := ` + "`" + `{"kind":"markdown","severity":2}` + "`" + `; string(data) != want {
+		t.Errorf("Marshal = %s, want %s", data, want)
+	}
+	var got settings
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got != in {
+		t.Errorf("round trip = %+v, want %+v", got, in)
+	}
+}
+
+func TestEnumRejectsOtherKind(t *testing.T) {
+	for _, data := range []string{
+		` + "`" + `{"kind":1}` + "`" + `,
+		` + "`" + `{"kind":"html"}` + "`" + `,
+		` + "`" + `{"severity":"2"}` + "`" + `,
+		` + "`" + `{"severity":true}` + "`" + `,
+	} {
+		var got settings
+		if err := json.Unmarshal([]byte(data), &got); err == nil {
+			t.Errorf("Unmarshal(%s) = %+v, want an error", data, got)
+		}
+	}
+}
+
+func TestEnumNull(t *testing.T) {
+	got := settings{Kind: MarkupKindPlainText, Severity: DiagnosticSeverityError}
+	if err := json.Unmarshal([]byte(` + "`" + `{"kind":null,"severity":null}` + "`" + `), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Kind != MarkupKindPlainText || got.Severity != DiagnosticSeverityError {
+		t.Errorf("null changed the values to %+v", got)
+	}
+}
+`
+
+func TestEnumJSONRuntime(t *testing.T) {
+	cfg := golang.DefaultConfig()
+	cfg.EnumJSON = true
+	runGenerated(t, "enum_json.txtar", cfg, enumJSONRuntimeTest)
+}
@@ -0,0 +1,273 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package golang
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/albertocavalcante/lspls/model"
+)
+
+// equalPtrHelper compares optional scalars, which are generated as pointers.
+const equalPtrHelper = `// equalPtr reports whether x and y are both nil or point to equal values.
+func equalPtr[T comparable](x, y *T) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	return *x == *y
+}
+
+`
+
+// writeEqualMethod writes an Equal method for structure s to f.
+func (g *Generator) writeEqualMethod(f *goFile, s *model.Structure) {
+	name := g.typeName(s.Name)
+
+	var terms []string
+	for _, ext := range slices.Concat(s.Extends, s.Mixins) {
+		if ext.Kind == "reference" && !g.omitted(ext.Name) {
+			field := g.typeName(ext.Name)
+			terms = append(terms, g.equalExpr(f, ext, false, "x."+field, "y."+field))
+		}
+	}
+	for _, p := range s.Properties {
+		if !g.includeProperty(&p) {
+			continue
+		}
+		field := g.exportName(p.Name)
+		if g.tristate(&p) {
+			x, y := "x."+field, "y."+field
+			terms = append(terms, fmt.Sprintf("%s.Set == %s.Set && %s.Null == %s.Null", x, y, x, y),
+				g.equalExpr(f, p.Type.NonNullType(), false, x+".Value", y+".Value"))
+			continue
+		}
+		terms = append(terms, g.equalExpr(f, p.Type, p.Optional, "x."+field, "y."+field))
+	}
+
+	fmt.Fprintf(&f.body, "// Equal reports whether x and y are deeply equal.\n")
+	fmt.Fprintf(&f.body, "func (x *%s) Equal(y *%s) bool {\n", name, name)
+	f.body.WriteString("\tif x == nil || y == nil {\n")
+	f.body.WriteString("\t\treturn x == y\n")
+	f.body.WriteString("\t}\n")
+	if len(terms) == 0 {
+		f.body.WriteString("\treturn true\n")
+	} else {
+		fmt.Fprintf(&f.body, "\treturn %s\n", strings.Join(terms, " &&\n\t\t"))
+	}
+	f.body.WriteString("}\n\n")
+}
+
+// writeOrEqualMethod writes an Equal method for an Or_* union type to f.
+// Values of the same member type are compared with that member's equality;
+// anything else falls back to reflect.DeepEqual.
+func (g *Generator) writeOrEqualMethod(f *goFile, info orTypeInfo) {
+	f.use("reflect")
+
+	fmt.Fprintf(&f.body, "// Equal reports whether x and y hold deeply equal values.\n")
+	fmt.Fprintf(&f.body, "func (x *%s) Equal(y *%s) bool {\n", info.name, info.name)
+	f.body.WriteString("\tif x == nil || y == nil {\n")
+	f.body.WriteString("\t\treturn x == y\n")
+	f.body.WriteString("\t}\n")
+	f.body.WriteString("\tswitch xv := x.Value.(type) {\n")
+	seen := make(map[string]bool)
+	for i, name := range info.itemNames {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		fmt.Fprintf(&f.body, "\tcase %s:\n", name)
+		fmt.Fprintf(&f.body, "\t\tyv, ok := y.Value.(%s)\n", name)
+		fmt.Fprintf(&f.body, "\t\treturn ok && %s\n", g.equalExpr(f, info.items[i], false, "xv", "yv"))
+	}
+	f.body.WriteString("\tcase nil:\n")
+	f.body.WriteString("\t\treturn y.Value == nil\n")
+	f.body.WriteString("\t}\n")
+	f.body.WriteString("\treturn reflect.DeepEqual(x.Value, y.Value)\n")
+	f.body.WriteString("}\n\n")
+}
+
+// equalExpr returns a Go boolean expression comparing x and y, two
+// addressable values of the Go type that goType(t, optional) produces.
+// Packages the expression needs are recorded on f.
+func (g *Generator) equalExpr(f *goFile, t *model.Type, optional bool, x, y string) string {
+	return g.equalExprSeen(f, t, optional, x, y, nil)
+}
+
+// equalExprSeen implements equalExpr. seen holds the type aliases being
+// expanded, so that a self-referential alias falls back to reflect.DeepEqual
+// instead of recursing forever.
+func (g *Generator) equalExprSeen(f *goFile, t *model.Type, optional bool, x, y string, seen map[string]bool) string {
+	if t == nil {
+		return deepEqual(f, x, y)
+	}
+
+	// T | null is generated as *T
+	if t.IsOptional() {
+		inner := t.NonNullType()
+		switch {
+		case g.isComparable(inner, seen):
+			return fmt.Sprintf("equalPtr(%s, %s)", x, y)
+		case g.hasEqualMethod(inner, seen):
+			return fmt.Sprintf("%s.Equal(%s)", x, y)
+		default:
+			return fmt.Sprintf("(%s == nil) == (%s == nil) && (%s == nil || %s)",
+				x, y, x, g.equalExprSeen(f, inner, false, "(*"+x+")", "(*"+y+")", seen))
+		}
+	}
+
+	switch t.Kind {
+	case "base":
+		if g.goBaseType(t) == "any" || g.baseOverridden(t) {
+			return deepEqual(f, x, y)
+		}
+		if optional && isPointerScalar(t.Name) {
+			return fmt.Sprintf("equalPtr(%s, %s)", x, y)
+		}
+		return fmt.Sprintf("%s == %s", x, y)
+
+	case "stringLiteral", "integerLiteral", "booleanLiteral":
+		return fmt.Sprintf("%s == %s", x, y)
+
+	case "reference":
+		if g.omitted(t.Name) {
+			return deepEqual(f, x, y)
+		}
+		if _, ok := g.structures[t.Name]; ok {
+			return fmt.Sprintf("%s.Equal(&%s)", x, y)
+		}
+		if ov, ok := g.overrideOf(t.Name); ok && ov.hasEqual {
+			return fmt.Sprintf("%s.Equal(&%s)", x, y)
+		}
+		if _, ok := g.enums[t.Name]; ok || g.overridden(t.Name) {
+			return fmt.Sprintf("%s == %s", x, y)
+		}
+		if a, ok := g.aliases[t.Name]; ok && !seen[t.Name] {
+			return g.equalExprSeen(f, a.Type, false, x, y, withSeen(seen, t.Name))
+		}
+		return deepEqual(f, x, y)
+
+	case "array":
+		if g.isComparable(t.Element, seen) {
+			f.use("slices")
+			return fmt.Sprintf("slices.Equal(%s, %s)", x, y)
+		}
+		f.use("slices")
+		return fmt.Sprintf("slices.EqualFunc(%s, %s, func(a, b %s) bool { return %s })",
+			x, y, g.goType(t.Element, false), g.equalExprSeen(f, t.Element, false, "a", "b", seen))
+
+	case "map":
+		vt, ok := t.Value.(*model.Type)
+		if !ok {
+			return deepEqual(f, x, y)
+		}
+		f.use("maps")
+		if g.isComparable(vt, seen) {
+			return fmt.Sprintf("maps.Equal(%s, %s)", x, y)
+		}
+		return fmt.Sprintf("maps.EqualFunc(%s, %s, func(a, b %s) bool { return %s })",
+			x, y, g.goType(vt, false), g.equalExprSeen(f, vt, false, "a", "b", seen))
+
+	case "or":
+		members := g.orMembers(t)
+		switch len(members) {
+		case 0:
+			return deepEqual(f, x, y)
+		case 1:
+			return g.equalExprSeen(f, members[0], false, x, y, seen)
+		}
+		return fmt.Sprintf("%s.Equal(&%s)", x, y)
+
+	default:
+		// literal, and, and tuple types are generated as any or []any
+		return deepEqual(f, x, y)
+	}
+}
+
+// isComparable reports whether values of t's Go type can be compared with ==
+// and give the same answer as a deep comparison.
+func (g *Generator) isComparable(t *model.Type, seen map[string]bool) bool {
+	if t == nil || t.IsOptional() {
+		return false
+	}
+	switch t.Kind {
+	case "base":
+		return g.goBaseType(t) != "any" && !g.baseOverridden(t)
+	case "stringLiteral", "integerLiteral", "booleanLiteral":
+		return true
+	case "reference":
+		if g.omitted(t.Name) {
+			return false
+		}
+		if ov, ok := g.overrideOf(t.Name); ok {
+			return !ov.hasEqual
+		}
+		if _, ok := g.enums[t.Name]; ok {
+			return true
+		}
+		if a, ok := g.aliases[t.Name]; ok && !seen[t.Name] {
+			return g.isComparable(a.Type, withSeen(seen, t.Name))
+		}
+		return false
+	case "or":
+		members := g.orMembers(t)
+		return len(members) == 1 && g.isComparable(members[0], seen)
+	default:
+		return false
+	}
+}
+
+// hasEqualMethod reports whether t's Go type is a generated structure or
+// Or_* union, and so has an Equal method.
+func (g *Generator) hasEqualMethod(t *model.Type, seen map[string]bool) bool {
+	if t == nil || t.IsOptional() {
+		return false
+	}
+	switch t.Kind {
+	case "reference":
+		if g.omitted(t.Name) {
+			return false
+		}
+		if _, ok := g.structures[t.Name]; ok {
+			return true
+		}
+		if ov, ok := g.overrideOf(t.Name); ok {
+			return ov.hasEqual
+		}
+		if a, ok := g.aliases[t.Name]; ok && !seen[t.Name] {
+			return g.hasEqualMethod(a.Type, withSeen(seen, t.Name))
+		}
+		return false
+	case "or":
+		members := g.orMembers(t)
+		switch len(members) {
+		case 0:
+			return false
+		case 1:
+			return g.hasEqualMethod(members[0], seen)
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+func deepEqual(f *goFile, x, y string) string {
+	f.use("reflect")
+	return fmt.Sprintf("reflect.DeepEqual(%s, %s)", x, y)
+}
+
+// withSeen returns a copy of seen with name added.
+func withSeen(seen map[string]bool, name string) map[string]bool {
+	next := make(map[string]bool, len(seen)+1)
+	for k := range seen {
+		next[k] = true
+	}
+	next[name] = true
+	return next
+}
@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: MIT
+
+package golang_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/lspls/generators/golang"
+)
+
+// equalRuntimeTest exercises the Equal methods generated for
+// testdata/equal_methods.txtar.
+const equalRuntimeTest = `package protocol
+
+import "testing"
+
+func ptr[T any](v T) *T { return &v }
+
+func sample() *Diagnostic {
+	return &Diagnostic{
+		Location:   Location{Uri: "file:///a.go", Position: Position{Line: 1, Character: 2}},
+		Severity:   DiagnosticSeverityError,
+		Code:       NewOr_int32_string_FromInt32(42),
+		Deprecated: ptr(false),
+		Tags:       []string{"unused"},
+		Related:    []Location{{Uri: "file:///b.go"}},
+		Parent:     &Location{Uri: "file:///c.go"},
+		Changes:    map[string][]Position{"file:///a.go": {{Line: 3}}},
+		Data:       map[string]any{"k": []any{1.0, "v"}},
+	}
+}
+
+func TestEqual(t *testing.T) {
+	if !sample().Equal(sample()) {
+		t.Error("identical values are not equal")
+	}
+
+	changes := []func(d *Diagnostic){
+		func(d *Diagnostic) { d.Location.Position.Character = 9 },
+		func(d *Diagnostic) { d.Severity = DiagnosticSeverityWarning },
+		func(d *Diagnostic) { d.Code = NewOr_int32_string_FromString("42") },
+		func(d *Diagnostic) { d.Deprecated = nil },
+		func(d *Diagnostic) { d.Deprecated = ptr(true) },
+		func(d *Diagnostic) { d.Tags = append(d.Tags, "extra") },
+		func(d *Diagnostic) { d.Related[0].Position.Line = 7 },
+		func(d *Diagnostic) { d.Parent = nil },
+		func(d *Diagnostic) { d.Parent.Uri = "file:///d.go" },
+		func(d *Diagnostic) { d.Changes["file:///a.go"][0].Character = 1 },
+		func(d *Diagnostic) { d.Data = map[string]any{"k": []any{2.0}} },
+	}
+	for i, change := range changes {
+		d := sample()
+		change(d)
+		if sample().Equal(d) || d.Equal(sample()) {
+			t.Errorf("change %d: modified value reported equal", i)
+		}
+	}
+}
+
+func TestEqualNil(t *testing.T) {
+	var x, y *Diagnostic
+	if !x.Equal(y) {
+		t.Error("nil != nil")
+	}
+	if x.Equal(sample()) || sample().Equal(y) {
+		t.Error("nil reported equal to non-nil")
+	}
+
+	a, b := sample(), sample()
+	a.Parent, b.Parent = nil, nil
+	a.Code, b.Code = Or_int32_string{}, Or_int32_string{}
+	if !a.Equal(b) {
+		t.Error("values with nil fields are not equal")
+	}
+}
+`
+
+func TestEqualMethodsRuntime(t *testing.T) {
+	cfg := golang.DefaultConfig()
+	cfg.GenerateEqual = true
+	runGenerated(t, "equal_methods.txtar", cfg, equalRuntimeTest)
+}
@@ -0,0 +1,74 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package golang
+
+import (
+	"fmt"
+	"unicode"
+	"unicode/utf8"
+)
+
+// errorCodeEnums are the enumerations whose values get a ResponseError
+// constructor, in order. ErrorCodes holds the JSON-RPC codes and is the
+// type of ResponseError.Code; LSPErrorCodes holds the codes LSP adds.
+var errorCodeEnums = []string{"ErrorCodes", "LSPErrorCodes"}
+
+// writeResponseError writes the ResponseError type and a constructor per
+// error code. Nothing is written unless the ErrorCodes enumeration is
+// generated.
+func (g *Generator) writeResponseError(f *goFile) {
+	if _, ok := g.types.m["ErrorCodes"]; !ok {
+		return
+	}
+	name := g.typeName("ResponseError")
+	if _, ok := g.structures["ResponseError"]; ok {
+		g.log.Warn("ResponseError is defined by the specification; error type not generated")
+		return
+	}
+	codeType := g.typeName("ErrorCodes")
+	f.use("fmt")
+	buf := &f.body
+
+	fmt.Fprintf(buf, "// %s is the error object of a JSON-RPC response. It implements error,\n", name)
+	buf.WriteString("// so handlers can return it to have the dispatcher reply with its code.\n")
+	fmt.Fprintf(buf, "type %s struct {\n", name)
+	fmt.Fprintf(buf, "\tCode    %s `json:\"code\"`\n", codeType)
+	buf.WriteString("\tMessage string `json:\"message\"`\n")
+	buf.WriteString("\tData    any `json:\"data,omitempty\"`\n")
+	buf.WriteString("}\n\n")
+	fmt.Fprintf(buf, "func (e *%s) Error() string {\n", name)
+	buf.WriteString("\treturn fmt.Sprintf(\"%s (code %d)\", e.Message, e.Code)\n")
+	buf.WriteString("}\n\n")
+
+	seen := make(map[string]bool)
+	for _, enum := range errorCodeEnums {
+		if _, ok := g.types.m[enum]; !ok {
+			continue
+		}
+		e := g.enums[enum]
+		for _, v := range e.Values {
+			// Range markers such as jsonrpcReservedErrorRangeStart are
+			// not codes an error is created with.
+			if r, _ := utf8.DecodeRuneInString(v.Name); !unicode.IsUpper(r) {
+				continue
+			}
+			ctor := "New" + g.exportName(v.Name)
+			if seen[ctor] {
+				continue
+			}
+			seen[ctor] = true
+			code := g.typeName(enum) + g.exportName(v.Name)
+			if enum != "ErrorCodes" {
+				code = codeType + "(" + code + ")"
+			}
+			fmt.Fprintf(buf, "// %s returns a %s with code %s.\n", ctor, name, v.Name)
+			fmt.Fprintf(buf, "func %s(message string) *%s {\n", ctor, name)
+			fmt.Fprintf(buf, "\treturn &%s{Code: %s, Message: message}\n", name, code)
+			buf.WriteString("}\n\n")
+		}
+	}
+}
@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: MIT
+
+package golang_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/lspls/generators/golang"
+)
+
+// errorTypeRuntimeTest uses the ResponseError generated for
+// testdata/error_type.txtar as an error and encodes it.
+const errorTypeRuntimeTest = `package protocol
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestResponseError(t *testing.T) {
+	var err error = fmt.Errorf("hover: %w", NewRequestFailed("no document"))
+
+	var rerr *ResponseError
+	if !errors.As(err, &rerr) {
+		t.Fatalf("errors.As(%v) failed", err)
+	}
+	if rerr.Code != -32803 {
+		t.Errorf("Code = %d, want -32803", rerr.Code)
+	}
+	if got, want := err.Error(), "hover: no document (code -32803)"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+
+	data, err := json.Marshal(NewMethodNotFound("unknown method"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := ` + "`" + `{"code":-32601,"message":"unknown method"}` + "`" + `; string(data) != want {
+		t.Errorf("Marshal = %s, want %s", data, want)
+	}
+}
+`
+
+func TestErrorTypeRuntime(t *testing.T) {
+	cfg := golang.DefaultConfig()
+	cfg.ErrorType = true
+	runGenerated(t, "error_type.txtar", cfg, errorTypeRuntimeTest)
+}
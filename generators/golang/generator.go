@@ -8,6 +8,12 @@ package golang
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/albertocavalcante/lspls/generator"
 	"github.com/albertocavalcante/lspls/model"
@@ -32,26 +38,166 @@ func (g *GoGenerator) Metadata() generator.Metadata {
 	}
 }
 
+// Describe returns the options, outputs, and requirements of this generator.
+func (g *GoGenerator) Describe() generator.Description {
+	return generator.Description{
+		Options: []generator.OptionInfo{
+			{Key: "package", Flag: "-p", Default: "protocol", Description: "Go package name"},
+			{Key: "equal", Flag: "--equal", Default: "false", Description: "Generate deep Equal methods"},
+			{Key: "discriminated_unions", Flag: "--discriminated-unions", Default: "false", Description: "Generate Kind and As<Member> accessors on kind-discriminated unions"},
+			{Key: "dedup_literals", Flag: "--dedup-literals", Default: "false", Description: "Merge structurally identical structures into aliases"},
+			{Key: "strict_required", Flag: "--strict-required", Default: "false", Description: "Reject JSON missing required properties"},
+			{Key: "iota_enums", Flag: "--iota-enums", Default: "false", Description: "Write contiguous integer enums as iota blocks"},
+			{Key: "bitmask_enums", Flag: "--bitmask-enums", Default: "false", Description: "Generate Has/Set/Clear/String methods on power-of-two integer enums"},
+			{Key: "flag_value_enums", Flag: "--flag-value-enums", Default: "false", Description: "Generate String/Set methods making string enums flag.Value"},
+			{Key: "enum_json", Flag: "--enum-json", Default: "false", Description: "Generate MarshalJSON/UnmarshalJSON on enums enforcing their base type"},
+			{Key: "binary", Flag: "--binary", Default: "false", Description: "Generate MarshalBinary/UnmarshalBinary on structures, encoding them as JSON"},
+			{Key: "handler_struct", Flag: "--handler-struct", Default: "false", Description: "Generate ServerHandlers/ClientHandlers func-field structs"},
+			{Key: "no_context", Flag: "--no-context", Default: "false", Description: "Leave the context.Context parameter out of Server/Client methods"},
+			{Key: "async_client", Flag: "--async-client", Default: "false", Description: "Generate AsyncServer/AsyncClient wrappers with callback-based methods"},
+			{Key: "conn", Flag: "--conn", Default: "false", Description: "Generate a Conn transport interface and ServerConn/ClientConn sending methods over it"},
+			{Key: "only_stable_methods", Flag: "--only-stable-methods", Default: "false", Description: "Leave proposed methods out of Server/Client"},
+			{Key: "sort_helpers", Flag: "--sort-helpers", Default: "false", Description: "Generate Sort functions for Range/Position-keyed structures"},
+			{Key: "position_helpers", Flag: "--position-helpers", Default: "false", Description: "Generate Position.Before and Range.IsEmpty/Contains/Overlaps methods"},
+			{Key: "progress_helpers", Flag: "--progress-helpers", Default: "false", Description: "Generate WorkDoneProgress payload and ProgressParams constructors"},
+			{Key: "capability_accessors", Flag: "--capability-accessors", Default: "false", Description: "Generate ClientCapabilities/ServerCapabilities accessors for nested optional capabilities"},
+			{Key: "unknown_as_interface", Flag: "--emit-unknown-as-interface", Default: "false", Description: "Generate unknown type kinds as UnknownKind_<kind> placeholder types"},
+			{Key: "enum_values", Flag: "--enum-values", Default: "false", Description: "Generate All<Enum> slices of enum constants"},
+			{Key: "semantic_tokens_helpers", Flag: "--semantic-tokens-helpers", Default: "false", Description: "Generate SemanticTokenTypesLegend/SemanticTokenModifiersLegend functions"},
+			{Key: "workspace_edit_helpers", Flag: "--workspace-edit-helpers", Default: "false", Description: "Generate ApplyWorkspaceEdit and ApplyTextEdits"},
+			{Key: "jsonrpc2", Flag: "--jsonrpc2", Default: "", Description: "Generate ServerHandler/ClientHandler adapters for a jsonrpc2 package: x-tools or sourcegraph"},
+			{Key: "jsonrpc2_import", Flag: "--jsonrpc2-import", Default: "", Description: "Import path of a jsonrpc2 copy or fork with the API of the jsonrpc2 flavor"},
+			{Key: "registry", Flag: "--registry", Default: "false", Description: "Generate a Registry of MethodSpecs for every method and a UnionTypes map of union members"},
+			{Key: "embed_schemas", Flag: "--embed-schemas", Default: "false", Description: "Generate a Schemas map of the JSON Schema of every type"},
+			{Key: "server_info_helper", Flag: "--server-info-helper", Default: "false", Description: "Generate LSPVersion, NewClientInfo, and NewServerInfo"},
+			{Key: "error_type", Flag: "--error-type", Default: "false", Description: "Generate a ResponseError type with a constructor per error code"},
+			{Key: "build_tags", Flag: "--go-build-tags", Default: "", Description: "Build constraint expression gating every generated file, such as lsp"},
+			{Key: "go_version", Flag: "--go-version", Default: "", Description: "Oldest Go release the output must compile with, leaving out options that need a newer one"},
+			{Key: "dep_depth", Flag: "--dep-depth", Default: "0", Description: "Resolve dependencies at most n references deep, typing references beyond as any"},
+			{Key: "filtered_interfaces", Flag: "--filtered-interfaces", Default: "false", Description: "Keep Server/Client methods whose types pass the type filter"},
+			{Key: "tristate", Flag: "--tristate", Default: "false", Description: "Generate optional nullable properties as Optional[T], telling absent from null"},
+			{Key: "type_overrides", Flag: "--type-override", Default: "", Description: "Comma-separated base=Go type mappings, such as decimal=encoding/json.Number"},
+			{Key: "renames", Flag: "--rename", Default: "", Description: "Comma-separated Old=New type renames, generating Old as a deprecated alias of New"},
+			{Key: "raw_any", Flag: "--raw-any", Default: "false", Description: "Keep LSPAny, LSPObject, and LSPArray as raw JSON"},
+			{Key: "omit_deprecated", Flag: "--no-deprecated", Default: "false", Description: "Omit deprecated types and properties"},
+			{Key: "omit_internal", Flag: "--no-internal", Default: "false", Description: "Omit types whose LSP name starts with _, such as _InitializeParams"},
+			{Key: "gen_tests", Flag: "--gen-tests", Default: "false", Description: "Generate protocol_roundtrip_test.go (directory output)"},
+			{Key: "split_packages", Flag: "--split-packages", Default: "false", Description: "Move namespace-only types into subpackages"},
+			{Key: "import_path", Flag: "--import-path", Default: "", Description: "Import path of the output directory, for split_packages"},
+		},
+		Outputs: []string{
+			"protocol.go: types, enums, and constants",
+			"server.go, client.go: Server/Client interfaces and dispatch (directory output)",
+			"json.go: JSON marshaling for unions and literals (directory output)",
+			"values.go: All<Enum> slices (directory output, enum_values)",
+			"registry.go: method registry (directory output, registry)",
+			"jsonrpc2.go: jsonrpc2 Handler adapters (directory output, jsonrpc2)",
+			"schemas.go: JSON Schemas of the types (directory output, embed_schemas)",
+			"doc.go: type index (directory output, --index)",
+			"protocol_roundtrip_test.go: JSON round-trip test (directory output, gen_tests)",
+			"<namespace>/<namespace>.go: subpackages (split_packages)",
+		},
+		Requirements: []string{
+			"Go 1.18+ (any)",
+			"Go 1.21+ with equal or workspace_edit_helpers (cmp, maps, slices)",
+			"Go 1.22+ with sort_helpers (cmp.Or)",
+			"Go 1.24+ with tristate (omitzero)",
+			"no third-party modules",
+		},
+	}
+}
+
 // Generate produces Go output files from the LSP model.
 func (g *GoGenerator) Generate(ctx context.Context, m *model.Model, cfg generator.Config) (*generator.Output, error) {
+	m, err := generator.ResolveEnumBases(m)
+	if err != nil {
+		return nil, err
+	}
+	typeOverrides, err := parsePairs("type override", "base=type", cfg.Option("type_overrides", ""))
+	if err != nil {
+		return nil, err
+	}
+	renames, err := parsePairs("rename", "Old=New", cfg.Option("renames", ""))
+	if err != nil {
+		return nil, err
+	}
+	depDepth, err := strconv.Atoi(cfg.Option("dep_depth", "0"))
+	if err != nil || depDepth < 0 {
+		return nil, fmt.Errorf("dep depth %q: want a number of references", cfg.Option("dep_depth", ""))
+	}
+
 	// Convert generator.Config to internal Config
 	internalCfg := Config{
-		PackageName:     cfg.Option("package", "protocol"),
-		Types:           cfg.Types,
-		ResolveDeps:     cfg.ResolveDeps,
-		IncludeProposed: cfg.IncludeProposed,
-		GenerateClient:  cfg.GenerateClient,
-		GenerateServer:  cfg.GenerateServer,
-		GenerateJSON:    true,
-		Source:          cfg.Source,
-		Ref:             cfg.Ref,
-		CommitHash:      cfg.CommitHash,
-		LSPVersion:      cfg.LSPVersion,
+		PackageName:           cfg.Option("package", "protocol"),
+		Types:                 cfg.Types,
+		Methods:               cfg.Methods,
+		TypePrefix:            cfg.TypePrefix,
+		TypeSuffix:            cfg.TypeSuffix,
+		Acronyms:              cfg.Acronyms,
+		NameOverrides:         cfg.NameOverrides,
+		ResolveDeps:           cfg.ResolveDeps,
+		DepDepth:              depDepth,
+		IncludeProposed:       cfg.IncludeProposed,
+		GenerateClient:        cfg.GenerateClient,
+		GenerateServer:        cfg.GenerateServer,
+		GenerateJSON:          true,
+		GenerateEqual:         cfg.Option("equal", "false") == "true",
+		DiscriminatedUnions:   cfg.Option("discriminated_unions", "false") == "true",
+		DedupLiterals:         cfg.Option("dedup_literals", "false") == "true",
+		StrictRequired:        cfg.Option("strict_required", "false") == "true",
+		IotaEnums:             cfg.Option("iota_enums", "false") == "true",
+		BitmaskEnums:          cfg.Option("bitmask_enums", "false") == "true",
+		FlagValueEnums:        cfg.Option("flag_value_enums", "false") == "true",
+		EnumJSON:              cfg.Option("enum_json", "false") == "true",
+		Binary:                cfg.Option("binary", "false") == "true",
+		HandlerStruct:         cfg.Option("handler_struct", "false") == "true",
+		NoContext:             cfg.Option("no_context", "false") == "true",
+		AsyncClient:           cfg.Option("async_client", "false") == "true",
+		Conn:                  cfg.Option("conn", "false") == "true",
+		OnlyStableMethods:     cfg.Option("only_stable_methods", "false") == "true",
+		SortHelpers:           cfg.Option("sort_helpers", "false") == "true",
+		PositionHelpers:       cfg.Option("position_helpers", "false") == "true",
+		ProgressHelpers:       cfg.Option("progress_helpers", "false") == "true",
+		CapabilityAccessors:   cfg.Option("capability_accessors", "false") == "true",
+		UnknownAsInterface:    cfg.Option("unknown_as_interface", "false") == "true",
+		EnumValues:            cfg.Option("enum_values", "false") == "true",
+		SemanticTokensHelpers: cfg.Option("semantic_tokens_helpers", "false") == "true",
+		WorkspaceEditHelpers:  cfg.Option("workspace_edit_helpers", "false") == "true",
+		Registry:              cfg.Option("registry", "false") == "true",
+		EmbedSchemas:          cfg.Option("embed_schemas", "false") == "true",
+		ServerInfoHelper:      cfg.Option("server_info_helper", "false") == "true",
+		ErrorType:             cfg.Option("error_type", "false") == "true",
+		FilteredInterfaces:    cfg.Option("filtered_interfaces", "false") == "true",
+		RawAny:                cfg.Option("raw_any", "false") == "true",
+		TypeOverrides:         typeOverrides,
+		Renames:               renames,
+		Tristate:              cfg.Option("tristate", "false") == "true",
+		JSONRPC2:              cfg.Option("jsonrpc2", ""),
+		JSONRPC2Import:        cfg.Option("jsonrpc2_import", ""),
+		BuildTags:             cfg.Option("build_tags", ""),
+		GoVersion:             cfg.Option("go_version", ""),
+		OmitDeprecated:        cfg.Option("omit_deprecated", "false") == "true",
+		OmitInternal:          cfg.Option("omit_internal", "false") == "true",
+		SplitPackages:         cfg.Option("split_packages", "false") == "true",
+		ImportPath:            cfg.Option("import_path", ""),
+		MinifyDocs:            cfg.MinifyDocs,
+		StrictJSONNames:       cfg.StrictJSONNames,
+		Index:                 cfg.Index,
+		GenTests:              cfg.Option("gen_tests", "false") == "true",
+		Source:                cfg.Source,
+		Ref:                   cfg.Ref,
+		CommitHash:            cfg.CommitHash,
+		LSPVersion:            cfg.LSPVersion,
+		ToolVersion:           cfg.ToolVersion,
+		Timestamp:             cfg.Timestamp,
+		Logger:                cfg.Logger,
 	}
 
 	// Enable split files when writing to a directory
 	if cfg.OutputDir != "" {
 		internalCfg.SplitFiles = true
+	} else if internalCfg.SplitPackages {
+		return nil, errors.New("split packages require directory output")
 	}
 
 	// Create internal generator and generate
@@ -63,6 +209,7 @@ func (g *GoGenerator) Generate(ctx context.Context, m *model.Model, cfg generato
 
 	// Convert to generator.Output
 	result := generator.NewOutput()
+	result.Stats = gen.Stats()
 
 	// Determine output filename for protocol types
 	filename := "protocol.go"
@@ -80,5 +227,44 @@ func (g *GoGenerator) Generate(ctx context.Context, m *model.Model, cfg generato
 	if out.JSON != nil {
 		result.Add("json.go", out.JSON)
 	}
+	if out.Doc != nil {
+		result.Add("doc.go", out.Doc)
+	}
+	if out.Values != nil {
+		result.Add("values.go", out.Values)
+	}
+	if out.JSONRPC2 != nil {
+		result.Add("jsonrpc2.go", out.JSONRPC2)
+	}
+	if out.Registry != nil {
+		result.Add("registry.go", out.Registry)
+	}
+	if out.Schemas != nil {
+		result.Add("schemas.go", out.Schemas)
+	}
+	if out.Tests != nil {
+		result.Add("protocol_roundtrip_test.go", out.Tests)
+	}
+	for _, path := range slices.Sorted(maps.Keys(out.Packages)) {
+		result.Add(path, out.Packages[path])
+	}
 	return result, nil
 }
+
+// parsePairs parses an option holding a comma-separated list of key=value
+// pairs, such as type_overrides and renames, into a map. what names an
+// entry of the option and form its syntax in errors.
+func parsePairs(what, form, s string) (map[string]string, error) {
+	if s == "" {
+		return nil, nil
+	}
+	pairs := make(map[string]string)
+	for pair := range strings.SplitSeq(s, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok || key == "" || value == "" {
+			return nil, fmt.Errorf("%s %q: want %s", what, pair, form)
+		}
+		pairs[key] = value
+	}
+	return pairs, nil
+}
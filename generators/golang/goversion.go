@@ -0,0 +1,55 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package golang
+
+import (
+	"fmt"
+	"go/version"
+	"strings"
+)
+
+// minGoVersion is the oldest Go release the output compiles with, for its
+// use of any.
+const minGoVersion = "go1.18"
+
+// goVersionFeatures lists the options whose code needs a Go release newer
+// than minGoVersion, with the release and what it brings.
+var goVersionFeatures = []struct {
+	name    string
+	version string
+	needs   string
+	enabled func(*Config) *bool
+}{
+	{"equal methods", "go1.21", "maps, slices", func(c *Config) *bool { return &c.GenerateEqual }},
+	{"workspace edit helpers", "go1.21", "cmp, slices", func(c *Config) *bool { return &c.WorkspaceEditHelpers }},
+	{"sort helpers", "go1.22", "cmp.Or", func(c *Config) *bool { return &c.SortHelpers }},
+	{"tristate properties", "go1.24", "omitzero", func(c *Config) *bool { return &c.Tristate }},
+}
+
+// checkGoVersion reports an error for a GoVersion that is not a Go release
+// at least minGoVersion, and turns off the options that need a newer one.
+func (g *Generator) checkGoVersion() error {
+	if g.config.GoVersion == "" {
+		return nil
+	}
+	v := "go" + strings.TrimPrefix(g.config.GoVersion, "go")
+	if !version.IsValid(v) {
+		return fmt.Errorf("go version %q: want a Go release, such as 1.21", g.config.GoVersion)
+	}
+	if version.Compare(v, minGoVersion) < 0 {
+		return fmt.Errorf("go version %q: the generated code needs Go %s or later", g.config.GoVersion, strings.TrimPrefix(minGoVersion, "go"))
+	}
+	for _, feat := range goVersionFeatures {
+		enabled := feat.enabled(&g.config)
+		if *enabled && version.Compare(v, feat.version) < 0 {
+			g.log.Warn(feat.name+" are not generated for Go "+g.config.GoVersion,
+				"needs", "Go "+strings.TrimPrefix(feat.version, "go")+" ("+feat.needs+")")
+			*enabled = false
+		}
+	}
+	return nil
+}
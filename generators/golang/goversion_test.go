@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: MIT
+
+package golang_test
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/albertocavalcante/lspls/generators/golang"
+	"github.com/albertocavalcante/lspls/model"
+	"golang.org/x/tools/txtar"
+)
+
+func TestGoVersionInvalid(t *testing.T) {
+	for _, tc := range []struct {
+		version string
+		want    string
+	}{
+		{"1.17", "needs Go 1.18 or later"},
+		{"go1.16", "needs Go 1.18 or later"},
+		{"latest", "want a Go release"},
+		{"1.x", "want a Go release"},
+	} {
+		cfg := golang.DefaultConfig()
+		cfg.GoVersion = tc.version
+		_, err := golang.New(&model.Model{}, cfg).Generate()
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("GoVersion %q: got error %v, want one containing %q", tc.version, err, tc.want)
+		}
+	}
+}
+
+// goVersionRuntimeTest checks that the union generated for
+// testdata/union_types.txtar round-trips through JSON.
+const goVersionRuntimeTest = `package protocol
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestUnionRoundTrip(t *testing.T) {
+	in := NewOr_AnnotatedTextEdit_TextEdit_FromTextEdit(TextEdit{NewText: "x"})
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got Or_AnnotatedTextEdit_TextEdit
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !got.Equal(&in) {
+		t.Errorf("round trip of %s = %#v, want %#v", data, got, in)
+	}
+}
+`
+
+// TestGoVersionRuntime generates code for Go 1.21 with every option it
+// allows, checks that it declares no generic types, and compiles it in a
+// module whose go directive is 1.21.
+func TestGoVersionRuntime(t *testing.T) {
+	cfg := golang.DefaultConfig()
+	cfg.GoVersion = "1.21"
+	cfg.GenerateEqual = true
+	cfg.StrictRequired = true
+	cfg.DiscriminatedUnions = true
+	cfg.SortHelpers = true
+	cfg.Tristate = true
+
+	ar, err := txtar.ParseFile(filepath.Join("testdata", "union_types.txtar"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var m model.Model
+	if err := json.Unmarshal(ar.Files[0].Data, &m); err != nil {
+		t.Fatal(err)
+	}
+	out, err := golang.New(&m, cfg).Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := parser.ParseFile(token.NewFileSet(), "protocol.go", out.Protocol, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ast.Inspect(f, func(n ast.Node) bool {
+		if spec, ok := n.(*ast.TypeSpec); ok && spec.TypeParams != nil {
+			t.Errorf("type %s has type parameters", spec.Name.Name)
+		}
+		return true
+	})
+
+	runGeneratedFiles(t, "union_types.txtar", cfg, map[string]string{
+		"go.mod":          "module runtimetest\n\ngo 1.21\n",
+		"runtime_test.go": goVersionRuntimeTest,
+	})
+}
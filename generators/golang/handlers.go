@@ -0,0 +1,80 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package golang
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/albertocavalcante/lspls/internal/lspbase"
+)
+
+// writeHandlers writes a <name>Handlers struct with one func field per
+// method of the name interface, and an adapter that implements the
+// interface by calling the non-nil fields. Methods whose field is nil
+// return an error wrapping ErrMethodNotFound.
+func (g *Generator) writeHandlers(f *goFile, name string, methods *orderedMap[methodInfo]) {
+	keys := methods.keys()
+	if len(keys) == 0 {
+		return
+	}
+	g.useContext(f)
+	f.use("fmt")
+
+	handlers := name + "Handlers"
+	adapter := strings.ToLower(name[:1]) + name[1:] + "Handlers"
+	buf := &f.body
+
+	fmt.Fprintf(buf, "// %s implements %s with one optional function per method.\n", handlers, name)
+	fmt.Fprintf(buf, "// Set the fields for the methods you handle and call %s to adapt it;\n", name)
+	buf.WriteString("// methods whose field is nil report ErrMethodNotFound.\n")
+	fmt.Fprintf(buf, "type %s struct {\n", handlers)
+	for _, key := range keys {
+		info := methods.get(key)
+		if info.documentation != "" {
+			lspbase.WriteComment(buf, "\t//", info.documentation)
+		}
+		fmt.Fprintf(buf, "\t%s func%s\n", info.name, g.methodSignature(info, false))
+	}
+	buf.WriteString("}\n\n")
+
+	fmt.Fprintf(buf, "// %s returns a %s that calls the non-nil functions in h.\n", name, name)
+	fmt.Fprintf(buf, "func (h *%s) %s() %s {\n", handlers, name, name)
+	fmt.Fprintf(buf, "\treturn %s{h}\n", adapter)
+	buf.WriteString("}\n\n")
+
+	fmt.Fprintf(buf, "type %s struct{ h *%s }\n\n", adapter, handlers)
+
+	for _, key := range keys {
+		info := methods.get(key)
+		fmt.Fprintf(buf, "func (a %s) %s%s {\n", adapter, info.name, g.methodSignature(info, true))
+		fmt.Fprintf(buf, "\tif a.h.%s == nil {\n", info.name)
+		notFound := fmt.Sprintf("fmt.Errorf(\"%%w: %%s\", ErrMethodNotFound, %s)", g.methodConst(info.name))
+		if info.isNotification {
+			fmt.Fprintf(buf, "\t\treturn %s\n", notFound)
+		} else {
+			fmt.Fprintf(buf, "\t\treturn nil, %s\n", notFound)
+		}
+		buf.WriteString("\t}\n")
+		fmt.Fprintf(buf, "\treturn a.h.%s(%s)\n", info.name, g.methodArgs(info, "params"))
+		buf.WriteString("}\n\n")
+	}
+}
+
+// writeErrMethodNotFound writes the ErrMethodNotFound variable used by the
+// handler structs. It is emitted once per package.
+func (g *Generator) writeErrMethodNotFound(f *goFile) {
+	f.use("errors")
+	f.body.WriteString(errMethodNotFound)
+}
+
+const errMethodNotFound = `// ErrMethodNotFound is reported by handler structs for methods without a
+// handler. Dispatchers should answer such requests with the JSON-RPC
+// MethodNotFound error (-32601).
+var ErrMethodNotFound = errors.New("method not found")
+
+`
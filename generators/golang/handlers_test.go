@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: MIT
+
+package golang_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/lspls/generators/golang"
+)
+
+// handlersRuntimeTest exercises the handler structs generated for
+// testdata/handler_struct.txtar.
+const handlersRuntimeTest = `package protocol
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestServerHandlers(t *testing.T) {
+	var called bool
+	h := &ServerHandlers{
+		Initialized: func(context.Context, *InitializedParams) error {
+			called = true
+			return nil
+		},
+	}
+	var s Server = h.Server()
+
+	if err := s.Initialized(context.Background(), &InitializedParams{}); err != nil || !called {
+		t.Errorf("Initialized: err = %v, called = %v", err, called)
+	}
+	if _, err := s.Initialize(context.Background(), &InitializeParams{}); !errors.Is(err, ErrMethodNotFound) {
+		t.Errorf("Initialize without handler: err = %v, want ErrMethodNotFound", err)
+	}
+	if _, err := s.Shutdown(context.Background()); !errors.Is(err, ErrMethodNotFound) {
+		t.Errorf("Shutdown without handler: err = %v, want ErrMethodNotFound", err)
+	}
+}
+
+func TestClientHandlers(t *testing.T) {
+	var c Client = (&ClientHandlers{}).Client()
+	if err := c.WindowLogMessage(context.Background(), &LogMessageParams{}); !errors.Is(err, ErrMethodNotFound) {
+		t.Errorf("WindowLogMessage without handler: err = %v, want ErrMethodNotFound", err)
+	}
+}
+`
+
+func TestHandlerStructRuntime(t *testing.T) {
+	cfg := golang.DefaultConfig()
+	cfg.HandlerStruct = true
+	runGenerated(t, "handler_struct.txtar", cfg, handlersRuntimeTest)
+}
@@ -7,7 +7,12 @@
 package golang
 
 import (
+	"bytes"
+	"go/token"
+	"log/slog"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/albertocavalcante/lspls/model"
 )
@@ -63,11 +68,49 @@ func TestMethodToGoName(t *testing.T) {
 			input:    "",
 			expected: "",
 		},
+		{
+			name:     "three segments",
+			input:    "window/workDoneProgress/create",
+			expected: "WindowWorkDoneProgressCreate",
+		},
+		{
+			name:     "digit-leading segment",
+			input:    "experimental/2d/render",
+			expected: "Experimental2dRender",
+		},
+		{
+			name:     "digit-leading method",
+			input:    "3d/render",
+			expected: "X3dRender",
+		},
+		{
+			name:     "keyword segment",
+			input:    "type/definition",
+			expected: "TypeDefinition",
+		},
+		{
+			name:     "keyword method",
+			input:    "func",
+			expected: "Func",
+		},
+		{
+			name:     "non-letter characters",
+			input:    "$/custom-method.v2",
+			expected: "CustomMethodV2",
+		},
+		{
+			name:     "underscore prefix",
+			input:    "_internal/ping",
+			expected: "XinternalPing",
+		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			result := methodToGoName(tc.input)
+			result := new(Generator).methodToGoName(tc.input)
+			if result != "" && (!token.IsIdentifier(result) || !token.IsExported(result)) {
+				t.Errorf("methodToGoName(%q) = %q, not an exported identifier", tc.input, result)
+			}
 			if result != tc.expected {
 				t.Errorf("methodToGoName(%q) = %q, want %q", tc.input, result, tc.expected)
 			}
@@ -120,7 +163,7 @@ func TestExportName(t *testing.T) {
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			result := exportName(tc.input)
+			result := new(Generator).exportName(tc.input)
 			if result != tc.expected {
 				t.Errorf("exportName(%q) = %q, want %q", tc.input, result, tc.expected)
 			}
@@ -128,6 +171,27 @@ func TestExportName(t *testing.T) {
 	}
 }
 
+func TestOrConstructorName(t *testing.T) {
+	tests := []struct {
+		orName    string
+		identName string
+		expected  string
+	}{
+		{orName: "Or_Location_string", identName: "Location", expected: "NewOr_Location_string_FromLocation"},
+		{orName: "Or_Location_string", identName: "string", expected: "NewOr_Location_string_FromString"},
+		{orName: "Or_ArrLocation_Location", identName: "ArrLocation", expected: "NewOr_ArrLocation_Location_FromArrLocation"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.expected, func(t *testing.T) {
+			result := orConstructorName(tc.orName, tc.identName)
+			if result != tc.expected {
+				t.Errorf("orConstructorName(%q, %q) = %q, want %q", tc.orName, tc.identName, result, tc.expected)
+			}
+		})
+	}
+}
+
 func TestFormatConstValue(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -377,3 +441,66 @@ func TestTypeNameForIdent(t *testing.T) {
 		})
 	}
 }
+
+func TestGoTypeLogsDegradedTypes(t *testing.T) {
+	var logs bytes.Buffer
+	cfg := DefaultConfig()
+	cfg.Logger = slog.New(slog.NewTextHandler(&logs, nil))
+	g := New(&model.Model{}, cfg)
+
+	if got := g.goType(&model.Type{Kind: "and", Line: 42}, false); got != "any" {
+		t.Fatalf("goType(and) = %q, want %q", got, "any")
+	}
+	if got := g.goType(&model.Type{Kind: "reference", Name: "Position"}, false); got != "Position" {
+		t.Fatalf("goType(reference) = %q, want %q", got, "Position")
+	}
+
+	out := logs.String()
+	if n := strings.Count(out, "\n"); n != 1 {
+		t.Fatalf("got %d log lines, want 1:\n%s", n, out)
+	}
+	for _, want := range []string{"level=WARN", "intersection type degraded to any", "line=42"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("log output %q does not contain %q", out, want)
+		}
+	}
+}
+
+func TestFileHeader(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.LSPVersion = "3.17.0"
+	g := New(&model.Model{}, cfg)
+	want := "// Code generated by lspls. DO NOT EDIT.\n// LSP Version: 3.17.0\n"
+	if got := g.fileHeader(); got != want {
+		t.Errorf("fileHeader() = %q, want %q", got, want)
+	}
+
+	cfg.ToolVersion = "v1.2.3"
+	cfg.Timestamp = time.Date(2026, 3, 1, 12, 30, 0, 0, time.FixedZone("BRT", -3*60*60))
+	g = New(&model.Model{}, cfg)
+	want = "// Code generated by lspls. DO NOT EDIT.\n" +
+		"// LSP Version: 3.17.0\n" +
+		"// Generator: lspls v1.2.3\n" +
+		"// Generated: 2026-03-01T15:30:00Z\n"
+	if got := g.fileHeader(); got != want {
+		t.Errorf("fileHeader() = %q, want %q", got, want)
+	}
+}
+
+func TestPlainStructImportsNothing(t *testing.T) {
+	m := &model.Model{
+		Structures: []*model.Structure{{
+			Name: "Position",
+			Properties: []model.Property{
+				{Name: "line", Type: &model.Type{Kind: "base", Name: "uinteger"}},
+			},
+		}},
+	}
+	out, err := New(m, DefaultConfig()).Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(out.Protocol); strings.Contains(got, "import") || strings.Contains(got, "json.") {
+		t.Errorf("plain struct output references encoding/json:\n%s", got)
+	}
+}
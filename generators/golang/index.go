@@ -0,0 +1,55 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package golang
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strings"
+
+	"github.com/albertocavalcante/lspls/generator"
+)
+
+// generateDocFile produces doc.go: a package comment indexing every
+// generated type with its summary, version, and metaModel.json line.
+func (g *Generator) generateDocFile() ([]byte, error) {
+	entries := generator.Index(g.model, generator.Config{
+		Types:           g.config.Types,
+		ResolveDeps:     g.config.ResolveDeps,
+		IncludeProposed: g.config.IncludeProposed,
+	})
+
+	var buf bytes.Buffer
+	buf.WriteString(g.fileHeader())
+	buf.WriteString("\n")
+	fmt.Fprintf(&buf, "// Package %s contains types generated from the Language Server\n", g.config.PackageName)
+	buf.WriteString("// Protocol specification.\n")
+	if len(entries) > 0 {
+		buf.WriteString("//\n// # Types\n//\n")
+		for _, e := range entries {
+			var notes []string
+			if e.Since != "" {
+				notes = append(notes, "since "+e.Since)
+			}
+			if e.Line > 0 {
+				notes = append(notes, fmt.Sprintf("metaModel.json line %d", e.Line))
+			}
+			line := "//   - [" + g.typeName(e.Name) + "]"
+			if e.Summary != "" {
+				line += ": " + e.Summary
+			}
+			if len(notes) > 0 {
+				line += " (" + strings.Join(notes, ", ") + ")"
+			}
+			buf.WriteString(line + "\n")
+		}
+	}
+	fmt.Fprintf(&buf, "package %s\n", g.config.PackageName)
+
+	return format.Source(buf.Bytes())
+}
@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package golang
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/albertocavalcante/lspls/model"
+)
+
+// infoStructures are the structures that get a New<Name> constructor, with
+// the party they describe.
+var infoStructures = []struct{ name, party string }{
+	{"ClientInfo", "client"},
+	{"ServerInfo", "server"},
+}
+
+// writeInfoHelpers writes the LSPVersion constant and a constructor for
+// each of the ClientInfo and ServerInfo structures that is generated with
+// a string name and version, as sent in InitializeParams.clientInfo and
+// InitializeResult.serverInfo.
+func (g *Generator) writeInfoHelpers(f *goFile) {
+	buf := &f.body
+	if version := g.model.Version.Version; version != "" {
+		buf.WriteString("// LSPVersion is the version of the protocol these types were generated\n")
+		buf.WriteString("// from.\n")
+		fmt.Fprintf(buf, "const LSPVersion = %q\n\n", version)
+	}
+
+	written := false
+	for _, info := range infoStructures {
+		if _, ok := g.types.m[info.name]; !ok || g.structures[info.name] == nil {
+			continue
+		}
+		nameProp, versionProp := g.findProperty(info.name, "name"), g.findProperty(info.name, "version")
+		if !isStringProperty(nameProp) || nameProp.Optional || !isStringProperty(versionProp) {
+			g.log.Warn("info helper not generated: unexpected properties", "type", info.name)
+			continue
+		}
+		written = true
+		name := g.typeName(info.name)
+		version := "bi.Main.Version"
+		if typ, _ := g.fieldType(versionProp); strings.HasPrefix(typ, "*") {
+			version = "&" + version
+		}
+		f.use("runtime/debug")
+		fmt.Fprintf(buf, "// New%s returns the %s of a %s named name. Its version is\n", name, name, info.party)
+		buf.WriteString("// the version of the main module in the build information of the binary,\n")
+		buf.WriteString("// such as \"v1.2.3\", and is left unset for a development build.\n")
+		fmt.Fprintf(buf, "func New%s(name string) %s {\n", name, name)
+		fmt.Fprintf(buf, "\tinfo := %s{Name: name}\n", name)
+		buf.WriteString("\tif bi, ok := debug.ReadBuildInfo(); ok && bi.Main.Version != \"\" && bi.Main.Version != \"(devel)\" {\n")
+		fmt.Fprintf(buf, "\t\tinfo.Version = %s\n", version)
+		buf.WriteString("\t}\n")
+		buf.WriteString("\treturn info\n")
+		buf.WriteString("}\n\n")
+	}
+	if !written {
+		g.log.Warn("info helpers not generated: neither ClientInfo nor ServerInfo is generated")
+	}
+}
+
+// isStringProperty reports whether p exists and is a string.
+func isStringProperty(p *model.Property) bool {
+	return p != nil && p.Type.Kind == "base" && p.Type.Name == "string"
+}
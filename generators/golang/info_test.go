@@ -0,0 +1,35 @@
+// SPDX-License-Identifier: MIT
+
+package golang_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/lspls/generators/golang"
+)
+
+// infoRuntimeTest calls the constructors generated for
+// testdata/server_info_helper.txtar. A test binary has no module version,
+// so the version stays unset.
+const infoRuntimeTest = `package protocol
+
+import "testing"
+
+func TestInfoHelpers(t *testing.T) {
+	if LSPVersion != "3.18.0" {
+		t.Errorf("LSPVersion = %q, want 3.18.0", LSPVersion)
+	}
+	if got, want := NewServerInfo("my-server"), (ServerInfo{Name: "my-server"}); got != want {
+		t.Errorf("NewServerInfo = %+v, want %+v", got, want)
+	}
+	if got, want := NewClientInfo("my-editor"), (ClientInfo{Name: "my-editor"}); got != want {
+		t.Errorf("NewClientInfo = %+v, want %+v", got, want)
+	}
+}
+`
+
+func TestServerInfoHelperRuntime(t *testing.T) {
+	cfg := golang.DefaultConfig()
+	cfg.ServerInfoHelper = true
+	runGenerated(t, "server_info_helper.txtar", cfg, infoRuntimeTest)
+}
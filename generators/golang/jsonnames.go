@@ -0,0 +1,73 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package golang
+
+import (
+	"slices"
+
+	"github.com/albertocavalcante/lspls/generator"
+	"github.com/albertocavalcante/lspls/model"
+)
+
+// checkJSONNames reports the JSON names that more than one property of s
+// has through the structures it embeds for its extends and mixins.
+// encoding/json uses the property of a name that is embedded least deep,
+// so a property of s shadows those of its parents; properties of the same
+// name at the same depth are ambiguous, and encoding/json ignores them
+// all. Both are errors under StrictJSONNames and warnings otherwise.
+func (g *Generator) checkJSONNames(s *model.Structure) error {
+	seen := make(map[string]bool)
+	reported := make(map[string]bool)
+	level := []*model.Structure{s}
+	// A depth beyond the number of structures means a cycle of extends,
+	// which does not compile anyway.
+	for depth := 0; len(level) > 0 && depth <= len(g.structures); depth++ {
+		count := make(map[string]int)
+		var names []string
+		var next []*model.Structure
+		for _, t := range level {
+			for _, p := range t.Properties {
+				if !g.includeProperty(&p) {
+					continue
+				}
+				if count[p.Name] == 0 {
+					names = append(names, p.Name)
+				}
+				count[p.Name]++
+			}
+			for _, ext := range slices.Concat(t.Extends, t.Mixins) {
+				if ext.Kind != "reference" || g.omitted(ext.Name) {
+					continue
+				}
+				if parent, ok := g.structures[ext.Name]; ok {
+					next = append(next, parent)
+				}
+			}
+		}
+		for _, name := range names {
+			var msg string
+			switch {
+			case reported[name]:
+				continue
+			case seen[name]:
+				msg = "duplicate JSON name through embedding, encoding/json uses the most-derived property"
+			case count[name] > 1:
+				msg = "ambiguous JSON name through embedding, encoding/json ignores the properties"
+			default:
+				seen[name] = true
+				continue
+			}
+			if g.config.StrictJSONNames {
+				return &generator.DuplicateJSONNameError{Structure: s.Name, Name: name}
+			}
+			g.log.Warn(msg, "structure", s.Name, "name", name)
+			reported[name] = true
+		}
+		level = next
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: MIT
+
+package golang_test
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/albertocavalcante/lspls/generator"
+	"github.com/albertocavalcante/lspls/generators/golang"
+	"github.com/albertocavalcante/lspls/model"
+)
+
+// jsonNamesModel has TextDocumentItem redeclare the uri of the structure
+// it extends, and VersionedItem embed two structures that both declare
+// version at the same depth.
+func jsonNamesModel() *model.Model {
+	str := &model.Type{Kind: "base", Name: "string"}
+	ref := func(name string) *model.Type { return &model.Type{Kind: "reference", Name: name} }
+	return &model.Model{Structures: []*model.Structure{
+		{Name: "TextDocumentIdentifier", Properties: []model.Property{{Name: "uri", Type: str}}},
+		{Name: "TextDocumentItem", Extends: []*model.Type{ref("TextDocumentIdentifier")}, Properties: []model.Property{{Name: "uri", Type: str}}},
+		{Name: "Versioned", Properties: []model.Property{{Name: "version", Type: str}}},
+		{Name: "OptionalVersioned", Properties: []model.Property{{Name: "version", Type: str}}},
+		{Name: "VersionedItem", Extends: []*model.Type{ref("Versioned")}, Mixins: []*model.Type{ref("OptionalVersioned")}},
+	}}
+}
+
+func TestJSONNamesWarn(t *testing.T) {
+	var logs bytes.Buffer
+	cfg := golang.DefaultConfig()
+	cfg.Logger = slog.New(slog.NewTextHandler(&logs, nil))
+	if _, err := golang.New(jsonNamesModel(), cfg).Generate(); err != nil {
+		t.Fatal(err)
+	}
+	out := logs.String()
+	for _, want := range []string{
+		"encoding/json uses the most-derived property\" structure=TextDocumentItem name=uri",
+		"encoding/json ignores the properties\" structure=VersionedItem name=version",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("log output %q does not contain %q", out, want)
+		}
+	}
+	if n := strings.Count(out, "level=WARN"); n != 2 {
+		t.Errorf("got %d warnings, want 2:\n%s", n, out)
+	}
+}
+
+func TestJSONNamesStrict(t *testing.T) {
+	for _, structure := range []string{"TextDocumentItem", "VersionedItem"} {
+		cfg := golang.DefaultConfig()
+		cfg.Types = []string{structure}
+		cfg.StrictJSONNames = true
+		_, err := golang.New(jsonNamesModel(), cfg).Generate()
+		var dup *generator.DuplicateJSONNameError
+		if !errors.As(err, &dup) || dup.Structure != structure {
+			t.Errorf("Generate(%s) error = %v, want a DuplicateJSONNameError for it", structure, err)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package golang
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// jsonrpc2Imports maps the JSONRPC2 flavors to the import paths of their
+// packages.
+var jsonrpc2Imports = map[string]string{
+	"x-tools":     "golang.org/x/tools/internal/jsonrpc2",
+	"sourcegraph": "github.com/sourcegraph/jsonrpc2",
+}
+
+// jsonrpc2Import returns the import path of the jsonrpc2 package the
+// adapters use: JSONRPC2Import if set, or the flavor's own.
+func (g *Generator) jsonrpc2Import() string {
+	if g.config.JSONRPC2Import != "" {
+		return g.config.JSONRPC2Import
+	}
+	return jsonrpc2Imports[g.config.JSONRPC2]
+}
+
+// writeJSONRPC2 writes a ServerHandler and a ClientHandler adapting the
+// Server and Client interfaces to the Handler of the JSONRPC2 flavor, for
+// the interfaces that have methods. Each comes with a <Name>HandlerWithAliases
+// variant routing legacy method names to the methods they map to.
+func (g *Generator) writeJSONRPC2(f *goFile) {
+	if len(g.serverMethods.keys()) == 0 && len(g.clientMethods.keys()) == 0 {
+		return
+	}
+	f.use("context", "encoding/json", g.jsonrpc2Import())
+	switch g.config.JSONRPC2 {
+	case "x-tools":
+		f.use("fmt")
+	case "sourcegraph":
+		f.use("errors")
+	}
+	pkg := path.Base(g.jsonrpc2Import())
+	buf := &f.body
+
+	for _, name := range []string{"Server", "Client"} {
+		methods := g.serverMethods
+		if name == "Client" {
+			methods = g.clientMethods
+		}
+		keys := methods.keys()
+		if len(keys) == 0 {
+			continue
+		}
+		recv := strings.ToLower(name)
+
+		switch g.config.JSONRPC2 {
+		case "x-tools":
+			fmt.Fprintf(buf, "// %sHandler returns a %s.Handler that decodes the params of each\n", name, pkg)
+			fmt.Fprintf(buf, "// request and notification, calls the matching method of %s, and replies\n", recv)
+			fmt.Fprintf(buf, "// with its result. Other methods are answered with %s.ErrMethodNotFound.\n", pkg)
+			fmt.Fprintf(buf, "func %sHandler(%s %s) %s.Handler {\n", name, recv, name, pkg)
+			fmt.Fprintf(buf, "\treturn %sHandlerWithAliases(%s, nil)\n", name, recv)
+			buf.WriteString("}\n\n")
+			writeAliasesDoc(buf, name)
+			fmt.Fprintf(buf, "func %sHandlerWithAliases(%s %s, aliases map[string]string) %s.Handler {\n", name, recv, name, pkg)
+			fmt.Fprintf(buf, "\treturn func(ctx context.Context, reply %s.Replier, req %s.Request) error {\n", pkg, pkg)
+			if !g.config.NoContext {
+				fmt.Fprintf(buf, "\t\tif call, ok := req.(*%s.Call); ok {\n", pkg)
+				buf.WriteString("\t\t\tctx = WithRequestID(ctx, call.ID())\n")
+				buf.WriteString("\t\t}\n")
+			}
+			buf.WriteString("\t\tmethod := req.Method()\n")
+			buf.WriteString("\t\tif current, ok := aliases[method]; ok {\n")
+			buf.WriteString("\t\t\tmethod = current\n")
+			buf.WriteString("\t\t}\n")
+			buf.WriteString("\t\tswitch method {\n")
+			for _, key := range keys {
+				info := methods.get(key)
+				fmt.Fprintf(buf, "\t\tcase %s:\n", g.methodConst(info.name))
+				args := g.methodArgs(info, "&params")
+				if info.paramsType != "" {
+					fmt.Fprintf(buf, "\t\t\tvar params %s\n", strings.TrimPrefix(info.paramsType, "*"))
+					buf.WriteString("\t\t\tif err := unmarshalJSONRPC2Params(req.Params(), &params); err != nil {\n")
+					fmt.Fprintf(buf, "\t\t\t\treturn reply(ctx, nil, fmt.Errorf(\"%%w: %%v\", %s.ErrInvalidParams, err))\n", pkg)
+					buf.WriteString("\t\t\t}\n")
+				}
+				if info.isNotification {
+					fmt.Fprintf(buf, "\t\t\treturn reply(ctx, nil, %s.%s(%s))\n", recv, info.name, args)
+				} else {
+					fmt.Fprintf(buf, "\t\t\tresult, err := %s.%s(%s)\n", recv, info.name, args)
+					buf.WriteString("\t\t\treturn reply(ctx, result, err)\n")
+				}
+			}
+			buf.WriteString("\t\t}\n")
+			fmt.Fprintf(buf, "\t\treturn %s.MethodNotFound(ctx, reply, req)\n", pkg)
+			buf.WriteString("\t}\n")
+			buf.WriteString("}\n\n")
+
+		case "sourcegraph":
+			handler := recv + "Handler"
+			fmt.Fprintf(buf, "// %sHandler returns a %s.Handler that decodes the params of each\n", name, pkg)
+			fmt.Fprintf(buf, "// request and notification, calls the matching method of %s, and replies\n", recv)
+			fmt.Fprintf(buf, "// to requests with its result. Errors that do not wrap a *%s.Error are\n", pkg)
+			fmt.Fprintf(buf, "// replied with %s.CodeInternalError, and other methods with\n", pkg)
+			fmt.Fprintf(buf, "// %s.CodeMethodNotFound.\n", pkg)
+			fmt.Fprintf(buf, "func %sHandler(%s %s) %s.Handler {\n", name, recv, name, pkg)
+			fmt.Fprintf(buf, "\treturn %sHandlerWithAliases(%s, nil)\n", name, recv)
+			buf.WriteString("}\n\n")
+			writeAliasesDoc(buf, name)
+			fmt.Fprintf(buf, "func %sHandlerWithAliases(%s %s, aliases map[string]string) %s.Handler {\n", name, recv, name, pkg)
+			fmt.Fprintf(buf, "\treturn %s{%s, aliases}\n", handler, recv)
+			buf.WriteString("}\n\n")
+			fmt.Fprintf(buf, "type %s struct {\n", handler)
+			fmt.Fprintf(buf, "\t%s %s\n", recv, name)
+			buf.WriteString("\taliases map[string]string\n")
+			buf.WriteString("}\n\n")
+			fmt.Fprintf(buf, "func (h %s) Handle(ctx context.Context, conn *%s.Conn, req *%s.Request) {\n", handler, pkg, pkg)
+			if !g.config.NoContext {
+				buf.WriteString("\tif !req.Notif {\n")
+				buf.WriteString("\t\tctx = WithRequestID(ctx, req.ID)\n")
+				buf.WriteString("\t}\n")
+			}
+			ctxArg, ctxParam := "ctx, ", "ctx context.Context, "
+			if g.config.NoContext {
+				ctxArg, ctxParam = "", ""
+			}
+			fmt.Fprintf(buf, "\tresult, err := h.handle(%sreq)\n", ctxArg)
+			buf.WriteString("\tif req.Notif {\n")
+			buf.WriteString("\t\treturn\n")
+			buf.WriteString("\t}\n")
+			buf.WriteString("\tif err != nil {\n")
+			fmt.Fprintf(buf, "\t\tvar respErr *%s.Error\n", pkg)
+			buf.WriteString("\t\tif !errors.As(err, &respErr) {\n")
+			fmt.Fprintf(buf, "\t\t\trespErr = &%s.Error{Code: %s.CodeInternalError, Message: err.Error()}\n", pkg, pkg)
+			buf.WriteString("\t\t}\n")
+			buf.WriteString("\t\t_ = conn.ReplyWithError(ctx, req.ID, respErr)\n")
+			buf.WriteString("\t\treturn\n")
+			buf.WriteString("\t}\n")
+			buf.WriteString("\t_ = conn.Reply(ctx, req.ID, result)\n")
+			buf.WriteString("}\n\n")
+			fmt.Fprintf(buf, "func (h %s) handle(%sreq *%s.Request) (any, error) {\n", handler, ctxParam, pkg)
+			buf.WriteString("\tvar raw json.RawMessage\n")
+			buf.WriteString("\tif req.Params != nil {\n")
+			buf.WriteString("\t\traw = *req.Params\n")
+			buf.WriteString("\t}\n")
+			buf.WriteString("\tmethod := req.Method\n")
+			buf.WriteString("\tif current, ok := h.aliases[method]; ok {\n")
+			buf.WriteString("\t\tmethod = current\n")
+			buf.WriteString("\t}\n")
+			buf.WriteString("\tswitch method {\n")
+			for _, key := range keys {
+				info := methods.get(key)
+				fmt.Fprintf(buf, "\tcase %s:\n", g.methodConst(info.name))
+				args := g.methodArgs(info, "&params")
+				if info.paramsType != "" {
+					fmt.Fprintf(buf, "\t\tvar params %s\n", strings.TrimPrefix(info.paramsType, "*"))
+					buf.WriteString("\t\tif err := unmarshalJSONRPC2Params(raw, &params); err != nil {\n")
+					fmt.Fprintf(buf, "\t\t\treturn nil, &%s.Error{Code: %s.CodeInvalidParams, Message: err.Error()}\n", pkg, pkg)
+					buf.WriteString("\t\t}\n")
+				}
+				if info.isNotification {
+					fmt.Fprintf(buf, "\t\treturn nil, h.%s.%s(%s)\n", recv, info.name, args)
+				} else {
+					fmt.Fprintf(buf, "\t\treturn h.%s.%s(%s)\n", recv, info.name, args)
+				}
+			}
+			buf.WriteString("\t}\n")
+			fmt.Fprintf(buf, "\treturn nil, &%s.Error{Code: %s.CodeMethodNotFound, Message: \"method not found: \" + req.Method}\n", pkg, pkg)
+			buf.WriteString("}\n\n")
+		}
+	}
+
+	buf.WriteString(unmarshalJSONRPC2Params)
+}
+
+// writeAliasesDoc writes the doc comment of <name>HandlerWithAliases.
+func writeAliasesDoc(buf *bytes.Buffer, name string) {
+	fmt.Fprintf(buf, "// %sHandlerWithAliases is like %sHandler, but routes each request and\n", name, name)
+	buf.WriteString("// notification whose method is a key of aliases, such as the legacy name\n")
+	buf.WriteString("// of a renamed method, to the handler of the method it maps to.\n")
+}
+
+const unmarshalJSONRPC2Params = `// unmarshalJSONRPC2Params decodes the params of a request or notification
+// into v, leaving v zero when they are absent.
+func unmarshalJSONRPC2Params(raw json.RawMessage, v any) error {
+	if len(raw) == 0 {
+		return nil
+	}
+	return json.Unmarshal(raw, v)
+}
+
+`
@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: MIT
+
+package golang_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/lspls/generators/golang"
+)
+
+// jsonrpc2Stub is the part of the github.com/sourcegraph/jsonrpc2 API the
+// sourcegraph handlers use. Its Conn records the last reply.
+const jsonrpc2Stub = `package jsonrpc2
+
+import (
+	"context"
+	"encoding/json"
+)
+
+const (
+	CodeMethodNotFound int64 = -32601
+	CodeInvalidParams  int64 = -32602
+	CodeInternalError  int64 = -32603
+)
+
+type ID struct{ Num uint64 }
+
+type Request struct {
+	Method string
+	Params *json.RawMessage
+	ID     ID
+	Notif  bool
+}
+
+type Error struct {
+	Code    int64
+	Message string
+}
+
+func (e *Error) Error() string { return e.Message }
+
+type Handler interface {
+	Handle(context.Context, *Conn, *Request)
+}
+
+type Conn struct {
+	Result any
+	Err    *Error
+}
+
+func (c *Conn) Reply(ctx context.Context, id ID, result any) error {
+	c.Result = result
+	return nil
+}
+
+func (c *Conn) ReplyWithError(ctx context.Context, id ID, respErr *Error) error {
+	c.Err = respErr
+	return nil
+}
+`
+
+// jsonrpc2AliasesRuntimeTest routes legacy method names through the
+// ServerHandlerWithAliases generated for testdata/jsonrpc2_sourcegraph.txtar.
+const jsonrpc2AliasesRuntimeTest = `package protocol
+
+import (
+	"context"
+	"testing"
+
+	"runtimetest/jsonrpc2"
+)
+
+type server struct{ initialized bool }
+
+func (s *server) Initialize(context.Context, *InitializeParams) (*InitializeResult, error) {
+	return &InitializeResult{}, nil
+}
+
+func (s *server) Initialized(context.Context, *InitializedParams) error {
+	s.initialized = true
+	return nil
+}
+
+func (s *server) Shutdown(context.Context) (*any, error) { return nil, nil }
+
+func TestHandlerAliases(t *testing.T) {
+	ctx := context.Background()
+	s := &server{}
+	h := ServerHandlerWithAliases(s, map[string]string{
+		"legacy/initialize":  MethodInitialize,
+		"legacy/initialized": MethodInitialized,
+	})
+
+	var conn jsonrpc2.Conn
+	h.Handle(ctx, &conn, &jsonrpc2.Request{Method: "legacy/initialize"})
+	if _, ok := conn.Result.(*InitializeResult); !ok || conn.Err != nil {
+		t.Errorf("legacy/initialize replied %v, %v, want an InitializeResult", conn.Result, conn.Err)
+	}
+	h.Handle(ctx, &conn, &jsonrpc2.Request{Method: "legacy/initialized", Notif: true})
+	if !s.initialized {
+		t.Error("legacy/initialized was not routed to Initialized")
+	}
+
+	for _, h := range []jsonrpc2.Handler{h, ServerHandler(s)} {
+		conn = jsonrpc2.Conn{}
+		h.Handle(ctx, &conn, &jsonrpc2.Request{Method: "legacy/shutdown"})
+		if conn.Err == nil || conn.Err.Code != jsonrpc2.CodeMethodNotFound {
+			t.Errorf("legacy/shutdown replied %v, want method not found", conn.Err)
+		}
+	}
+}
+`
+
+func TestJSONRPC2AliasesRuntime(t *testing.T) {
+	cfg := golang.DefaultConfig()
+	cfg.JSONRPC2 = "sourcegraph"
+	cfg.JSONRPC2Import = "runtimetest/jsonrpc2"
+	runGeneratedFiles(t, "jsonrpc2_sourcegraph.txtar", cfg, map[string]string{
+		"jsonrpc2/jsonrpc2.go": jsonrpc2Stub,
+		"runtime_test.go":      jsonrpc2AliasesRuntimeTest,
+	})
+}
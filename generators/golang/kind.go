@@ -0,0 +1,69 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package golang
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// unionKinds returns the value of the string literal "kind" property of
+// each member of the union, parallel to info.items, if every member is a
+// structure with one and no two share a value. Otherwise it returns nil.
+func (g *Generator) unionKinds(info orTypeInfo) []string {
+	kinds := make([]string, len(info.items))
+	seen := make(map[string]bool)
+	for i, item := range info.items {
+		if item.Kind != "reference" {
+			return nil
+		}
+		p := g.findProperty(item.Name, "kind")
+		if p == nil || p.Optional || p.Type == nil || p.Type.Kind != "stringLiteral" {
+			return nil
+		}
+		kind, ok := p.Type.Value.(string)
+		if !ok || seen[kind] {
+			return nil
+		}
+		seen[kind] = true
+		kinds[i] = kind
+	}
+	return kinds
+}
+
+// writeKindAccessors writes a Kind method and an As<Member> getter per
+// member for a union whose members are told apart by their "kind"
+// property, so callers can branch on the kind without a type switch.
+// Other unions get nothing.
+func (g *Generator) writeKindAccessors(f *goFile, info orTypeInfo) {
+	kinds := g.unionKinds(info)
+	if kinds == nil {
+		return
+	}
+	buf := &f.body
+
+	buf.WriteString("// Kind returns the kind property of the member t holds, or \"\" if it\n")
+	buf.WriteString("// holds none.\n")
+	fmt.Fprintf(buf, "func (t %s) Kind() string {\n", info.name)
+	buf.WriteString("\tswitch t.Value.(type) {\n")
+	for i, name := range info.itemNames {
+		fmt.Fprintf(buf, "\tcase %s:\n", name)
+		fmt.Fprintf(buf, "\t\treturn %s\n", strconv.Quote(kinds[i]))
+	}
+	buf.WriteString("\t}\n")
+	buf.WriteString("\treturn \"\"\n")
+	buf.WriteString("}\n\n")
+
+	for i, name := range info.itemNames {
+		getter := "As" + info.identNames[i]
+		fmt.Fprintf(buf, "// %s returns the %s t holds, of kind %s, and whether it holds one.\n", getter, name, strconv.Quote(kinds[i]))
+		fmt.Fprintf(buf, "func (t %s) %s() (%s, bool) {\n", info.name, getter, name)
+		fmt.Fprintf(buf, "\tv, ok := t.Value.(%s)\n", name)
+		buf.WriteString("\treturn v, ok\n")
+		buf.WriteString("}\n\n")
+	}
+}
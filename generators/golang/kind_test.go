@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: MIT
+
+package golang_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/lspls/generators/golang"
+)
+
+// kindRuntimeTest decodes the union generated for
+// testdata/discriminated_unions.txtar and reads it through its accessors.
+const kindRuntimeTest = `package protocol
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestKindAccessors(t *testing.T) {
+	var edit WorkspaceEdit
+	input := ` + "`" + `{"operations": [
+		{"kind": "rename", "oldUri": "file:///a", "newUri": "file:///b"},
+		{"kind": "delete", "uri": "file:///c"}
+	]}` + "`" + `
+	if err := json.Unmarshal([]byte(input), &edit); err != nil {
+		t.Fatal(err)
+	}
+	var kinds []string
+	for _, op := range edit.Operations {
+		kinds = append(kinds, op.Kind())
+	}
+	if len(kinds) != 2 || kinds[0] != "rename" || kinds[1] != "delete" {
+		t.Errorf("kinds = %q, want [rename delete]", kinds)
+	}
+
+	rename, ok := edit.Operations[0].AsRenameFile()
+	if !ok || rename.NewUri != "file:///b" {
+		t.Errorf("AsRenameFile() = %+v, %v", rename, ok)
+	}
+	if _, ok := edit.Operations[0].AsDeleteFile(); ok {
+		t.Error("AsDeleteFile() on a rename: ok = true")
+	}
+	if got := (Or_CreateFile_DeleteFile_RenameFile{}).Kind(); got != "" {
+		t.Errorf("empty union Kind() = %q, want \"\"", got)
+	}
+}
+`
+
+func TestDiscriminatedUnionsRuntime(t *testing.T) {
+	cfg := golang.DefaultConfig()
+	cfg.DiscriminatedUnions = true
+	runGenerated(t, "discriminated_unions.txtar", cfg, kindRuntimeTest)
+}
@@ -6,49 +6,86 @@ import (
 	"bytes"
 	"fmt"
 	"strings"
-	"unicode"
+
+	"github.com/albertocavalcante/lspls/internal/lspbase"
+	"github.com/albertocavalcante/lspls/model"
 )
 
-// methodToGoName converts an LSP method name to a Go method name.
-// Examples:
+// methodToGoName converts an LSP method name to a Go method name. Every
+// character that cannot appear in an identifier, such as "/" and "$",
+// separates words, and a name that would start with a digit or "_" is
+// prefixed with "X", so the result is always an exported identifier or
+// empty. Examples:
 //   - "textDocument/hover" -> "TextDocumentHover"
 //   - "$/cancelRequest" -> "CancelRequest"
 //   - "initialize" -> "Initialize"
-func methodToGoName(method string) string {
-	// Strip $/ prefix
-	method = strings.TrimPrefix(method, "$/")
+//   - "experimental/2d-view" -> "Experimental2dView"
+func (g *Generator) methodToGoName(method string) string {
+	return g.casing.ExportName(method)
+}
 
-	var result strings.Builder
-	capitalizeNext := true
+// includeMethod reports whether a request or notification is emitted.
+// Methods not selected by Methods are left out. Proposed methods need
+// IncludeProposed and are still left out under OnlyStableMethods.
+func (g *Generator) includeMethod(method string, proposed bool) bool {
+	if g.methodFilter != nil && !g.methodFilter[method] {
+		return false
+	}
+	return !proposed || (g.config.IncludeProposed && !g.config.OnlyStableMethods)
+}
 
-	for _, r := range method {
-		if r == '/' {
-			capitalizeNext = true
+// typesIncluded reports whether every named type the Go types of ts refer
+// to is generated. Without a type filter, it is always true; with one, it
+// selects the methods of a partial Server and Client under
+// FilteredInterfaces.
+func (g *Generator) typesIncluded(ts ...*model.Type) bool {
+	for _, t := range ts {
+		if t == nil {
 			continue
 		}
-		if capitalizeNext {
-			result.WriteRune(unicode.ToUpper(r))
-			capitalizeNext = false
-		} else {
-			result.WriteRune(r)
+		switch t.Kind {
+		case "reference":
+			// References to omitted types become any.
+			if g.typeFilter != nil && !g.typeFilter[t.Name] && !g.omitted(t.Name) {
+				return false
+			}
+		case "array":
+			if !g.typesIncluded(t.Element) {
+				return false
+			}
+		case "map":
+			vt, _ := t.Value.(*model.Type)
+			if !g.typesIncluded(t.Key, vt) {
+				return false
+			}
+		case "or":
+			for _, item := range t.Items {
+				// Proposed members are left out of the union.
+				if !g.config.IncludeProposed && item.Kind == "reference" && g.isProposed(item.Name) {
+					continue
+				}
+				if !g.typesIncluded(item) {
+					return false
+				}
+			}
 		}
 	}
-
-	return result.String()
+	return true
 }
 
 // processRequests processes all requests from the model and adds them to
 // the appropriate interface (server, client, or both).
 func (g *Generator) processRequests() {
 	for _, req := range g.model.Requests {
-		if req.Proposed && !g.config.IncludeProposed {
+		if !g.includeMethod(req.Method, req.Proposed) || !g.typesIncluded(req.Params, req.Result) {
 			continue
 		}
 
 		info := methodInfo{
-			name:           methodToGoName(req.Method),
+			name:           g.methodToGoName(req.Method),
 			method:         req.Method,
-			documentation:  req.Documentation,
+			documentation:  g.docs(req.Documentation),
+			direction:      req.Direction,
 			isNotification: false,
 		}
 
@@ -75,14 +112,15 @@ func (g *Generator) processRequests() {
 // to the appropriate interface (server, client, or both).
 func (g *Generator) processNotifications() {
 	for _, notif := range g.model.Notifications {
-		if notif.Proposed && !g.config.IncludeProposed {
+		if !g.includeMethod(notif.Method, notif.Proposed) || !g.typesIncluded(notif.Params) {
 			continue
 		}
 
 		info := methodInfo{
-			name:           methodToGoName(notif.Method),
+			name:           g.methodToGoName(notif.Method),
 			method:         notif.Method,
-			documentation:  notif.Documentation,
+			documentation:  g.docs(notif.Documentation),
+			direction:      notif.Direction,
 			isNotification: true,
 		}
 
@@ -99,8 +137,11 @@ func (g *Generator) processNotifications() {
 // and registers the method constant.
 func (g *Generator) addMethodToInterfaces(info methodInfo, direction string) {
 	// Add method constant
-	constName := "Method" + info.name
+	constName := g.methodConst(info.name)
 	g.methodConsts.set(constName, fmt.Sprintf("%s = %q", constName, info.method))
+	if g.config.Registry {
+		g.registryMethods.set(info.method, info)
+	}
 
 	// Add to appropriate interface(s) based on direction
 	switch direction {
@@ -122,7 +163,28 @@ func (g *Generator) addMethodToInterfaces(info methodInfo, direction string) {
 	}
 }
 
-// generateMethodConstants generates the const block with LSP method name constants.
+// lifecycleMethods are the "$/" methods of the protocol itself rather than
+// of a feature. Transport code refers to them whichever methods are
+// generated, so their constants are always generated with the interfaces.
+var lifecycleMethods = []string{"$/cancelRequest", "$/progress", "$/setTrace", "$/logTrace"}
+
+// addLifecycleMethods adds the constants of the lifecycleMethods that
+// filtering left out.
+func (g *Generator) addLifecycleMethods() {
+	g.lifecycleConsts = 0
+	for _, method := range lifecycleMethods {
+		constName := g.methodConst(g.methodToGoName(method))
+		if _, ok := g.methodConsts.m[constName]; ok {
+			continue
+		}
+		g.methodConsts.set(constName, fmt.Sprintf("%s = %q", constName, method))
+		g.lifecycleConsts++
+	}
+}
+
+// generateMethodConstants generates the const block with LSP method name
+// constants, followed by IsLifecycleMethod when the lifecycle methods are
+// among them.
 func (g *Generator) generateMethodConstants() string {
 	keys := g.methodConsts.keys()
 	if len(keys) == 0 {
@@ -136,6 +198,23 @@ func (g *Generator) generateMethodConstants() string {
 		fmt.Fprintf(&buf, "\t%s\n", g.methodConsts.get(key))
 	}
 	buf.WriteString(")\n\n")
+
+	if g.lifecycleConsts >= 0 {
+		consts := make([]string, len(lifecycleMethods))
+		for i, method := range lifecycleMethods {
+			consts[i] = g.methodConst(g.methodToGoName(method))
+		}
+		buf.WriteString("// IsLifecycleMethod reports whether method is one of the \"$/\" methods of\n")
+		last := len(lifecycleMethods) - 1
+		fmt.Fprintf(&buf, "// the protocol itself: %s, or %s.\n", strings.Join(lifecycleMethods[:last], ", "), lifecycleMethods[last])
+		buf.WriteString("func IsLifecycleMethod(method string) bool {\n")
+		buf.WriteString("\tswitch method {\n")
+		fmt.Fprintf(&buf, "\tcase %s:\n", strings.Join(consts, ", "))
+		buf.WriteString("\t\treturn true\n")
+		buf.WriteString("\t}\n")
+		buf.WriteString("\treturn false\n")
+		buf.WriteString("}\n\n")
+	}
 	return buf.String()
 }
 
@@ -148,6 +227,9 @@ func (g *Generator) generateInterface(name string, methods *orderedMap[methodInf
 
 	var buf bytes.Buffer
 	fmt.Fprintf(&buf, "// %s defines the LSP %s interface.\n", name, strings.ToLower(name))
+	if !g.config.NoContext {
+		buf.WriteString("// Request handlers can read the JSON-RPC request ID with RequestIDFromContext.\n")
+	}
 	fmt.Fprintf(&buf, "type %s interface {\n", name)
 
 	for _, key := range keys {
@@ -155,29 +237,13 @@ func (g *Generator) generateInterface(name string, methods *orderedMap[methodInf
 
 		// Add documentation comment
 		if info.documentation != "" {
-			for line := range strings.SplitSeq(info.documentation, "\n") {
-				fmt.Fprintf(&buf, "\t// %s\n", line)
-			}
+			lspbase.WriteComment(&buf, "\t//", info.documentation)
 		}
 
-		// Generate method signature
-		if info.isNotification {
-			// Notifications: MethodName(context.Context, *ParamsType) error
-			// or MethodName(context.Context) error
-			if info.paramsType != "" {
-				fmt.Fprintf(&buf, "\t%s(context.Context, %s) error\n", info.name, info.paramsType)
-			} else {
-				fmt.Fprintf(&buf, "\t%s(context.Context) error\n", info.name)
-			}
-		} else {
-			// Requests: MethodName(context.Context, *ParamsType) (*ResultType, error)
-			// or MethodName(context.Context) (*ResultType, error)
-			if info.paramsType != "" {
-				fmt.Fprintf(&buf, "\t%s(context.Context, %s) (%s, error)\n", info.name, info.paramsType, info.resultType)
-			} else {
-				fmt.Fprintf(&buf, "\t%s(context.Context) (%s, error)\n", info.name, info.resultType)
-			}
-		}
+		// Generate method signature: MethodName(context.Context, *ParamsType)
+		// returning error for notifications and (*ResultType, error) for
+		// requests.
+		fmt.Fprintf(&buf, "\t%s%s\n", info.name, g.methodSignature(info, false))
 	}
 
 	buf.WriteString("}\n\n")
@@ -192,14 +258,107 @@ func (g *Generator) generateInterfaces() string {
 	buf.WriteString(g.generateMethodConstants())
 
 	// Generate Server interface
+	var server, client string
 	if g.config.GenerateServer {
-		buf.WriteString(g.generateInterface("Server", g.serverMethods))
+		server = g.generateInterface("Server", g.serverMethods)
 	}
 
 	// Generate Client interface
 	if g.config.GenerateClient {
-		buf.WriteString(g.generateInterface("Client", g.clientMethods))
+		client = g.generateInterface("Client", g.clientMethods)
+	}
+
+	buf.WriteString(server)
+	buf.WriteString(client)
+	if server != "" || client != "" {
+		g.writeRequestIDHelpers(&buf)
 	}
 
 	return buf.String()
 }
+
+// methodSignature returns the parameter and result lists of info's
+// interface method. The parameters are a context.Context, unless NoContext
+// is set, and the params of the method, if any; with named set, they are
+// named ctx and params.
+func (g *Generator) methodSignature(info methodInfo, named bool) string {
+	params := g.methodParams(info, named)
+	if info.isNotification {
+		return "(" + params + ") error"
+	}
+	return "(" + params + ") (" + info.resultType + ", error)"
+}
+
+// methodParams returns the parameter list of info's interface method,
+// without parentheses, as described for methodSignature.
+func (g *Generator) methodParams(info methodInfo, named bool) string {
+	var params []string
+	if !g.config.NoContext {
+		if named {
+			params = append(params, "ctx context.Context")
+		} else {
+			params = append(params, "context.Context")
+		}
+	}
+	if info.paramsType != "" {
+		if named {
+			params = append(params, "params "+info.paramsType)
+		} else {
+			params = append(params, info.paramsType)
+		}
+	}
+	return strings.Join(params, ", ")
+}
+
+// methodArgs returns the arguments of a call to info's interface method:
+// ctx, unless NoContext is set, and params when the method has params.
+func (g *Generator) methodArgs(info methodInfo, params string) string {
+	var args []string
+	if !g.config.NoContext {
+		args = append(args, "ctx")
+	}
+	if info.paramsType != "" {
+		args = append(args, params)
+	}
+	return strings.Join(args, ", ")
+}
+
+// useContext records that f uses the context package for the
+// context.Context parameters of Server and Client methods.
+func (g *Generator) useContext(f *goFile) {
+	if !g.config.NoContext {
+		f.use("context")
+	}
+}
+
+// writeRequestIDHelpers writes requestIDHelpers for the dispatchers that
+// invoke Server and Client methods: the JSONRPC2 adapters, which set the
+// ID, and the HandlerStruct functions, which a hand-written dispatcher
+// calls. NoContext leaves out the context they carry the request ID in.
+func (g *Generator) writeRequestIDHelpers(buf *bytes.Buffer) {
+	if g.config.NoContext || (g.config.JSONRPC2 == "" && !g.config.HandlerStruct) {
+		return
+	}
+	buf.WriteString(requestIDHelpers)
+}
+
+// requestIDHelpers carries the JSON-RPC request ID through the context
+// passed to Server and Client methods. Dispatchers set it with
+// WithRequestID; handlers read it with RequestIDFromContext.
+const requestIDHelpers = `// requestIDKey is the context key for the JSON-RPC request ID.
+type requestIDKey struct{}
+
+// WithRequestID returns a copy of ctx carrying the JSON-RPC request ID.
+// Dispatchers call it before invoking a Server or Client method.
+func WithRequestID(ctx context.Context, id any) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the JSON-RPC request ID carried by ctx.
+// It reports false for notifications, which have no ID.
+func RequestIDFromContext(ctx context.Context) (any, bool) {
+	id := ctx.Value(requestIDKey{})
+	return id, id != nil
+}
+
+`
@@ -0,0 +1,41 @@
+// SPDX-License-Identifier: MIT
+
+package golang_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/lspls/generators/golang"
+)
+
+// lifecycleRuntimeTest checks the lifecycle method constants generated for
+// testdata/interface_basic.txtar, whose specification has no "$/" methods.
+const lifecycleRuntimeTest = `package protocol
+
+import "testing"
+
+func TestLifecycleMethods(t *testing.T) {
+	for method, want := range map[string]string{
+		MethodCancelRequest: "$/cancelRequest",
+		MethodProgress:      "$/progress",
+		MethodSetTrace:      "$/setTrace",
+		MethodLogTrace:      "$/logTrace",
+	} {
+		if method != want {
+			t.Errorf("constant of %s = %q", want, method)
+		}
+		if !IsLifecycleMethod(method) {
+			t.Errorf("IsLifecycleMethod(%q) = false, want true", method)
+		}
+	}
+	for _, method := range []string{MethodTextDocumentHover, "$/unknown", ""} {
+		if IsLifecycleMethod(method) {
+			t.Errorf("IsLifecycleMethod(%q) = true, want false", method)
+		}
+	}
+}
+`
+
+func TestLifecycleMethodsRuntime(t *testing.T) {
+	runGenerated(t, "interface_basic.txtar", golang.DefaultConfig(), lifecycleRuntimeTest)
+}
@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: MIT
+
+package golang_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/lspls/generators/golang"
+)
+
+// noContextRuntimeTest drives the context-free methods generated for
+// testdata/no_context.txtar through ServerHandlers, AsyncServer, and the
+// sourcegraph ServerHandler.
+const noContextRuntimeTest = `package protocol
+
+import (
+	"context"
+	"testing"
+
+	"runtimetest/jsonrpc2"
+)
+
+func TestNoContext(t *testing.T) {
+	var got int32
+	handlers := &ServerHandlers{
+		Initialize: func(params *InitializeParams) (*InitializeResult, error) {
+			got = params.ProcessId
+			return &InitializeResult{}, nil
+		},
+	}
+	server := handlers.Server()
+
+	done := make(chan error)
+	AsyncServer{server}.InitializeAsync(&InitializeParams{ProcessId: 7}, func(_ *InitializeResult, err error) { done <- err })
+	if err := <-done; err != nil || got != 7 {
+		t.Fatalf("InitializeAsync: err = %v, ProcessId = %d, want 7", err, got)
+	}
+
+	var conn jsonrpc2.Conn
+	ServerHandler(server).Handle(context.Background(), &conn, &jsonrpc2.Request{Method: MethodShutdown})
+	if conn.Err == nil || conn.Err.Code != jsonrpc2.CodeInternalError {
+		t.Errorf("shutdown without a handler replied %v, want an internal error", conn.Err)
+	}
+}
+`
+
+func TestNoContextRuntime(t *testing.T) {
+	cfg := golang.DefaultConfig()
+	cfg.NoContext = true
+	cfg.HandlerStruct = true
+	cfg.AsyncClient = true
+	cfg.JSONRPC2 = "sourcegraph"
+	cfg.JSONRPC2Import = "runtimetest/jsonrpc2"
+	runGeneratedFiles(t, "no_context.txtar", cfg, map[string]string{
+		"jsonrpc2/jsonrpc2.go": jsonrpc2Stub,
+		"runtime_test.go":      noContextRuntimeTest,
+	})
+}
@@ -0,0 +1,46 @@
+// SPDX-License-Identifier: MIT
+
+package golang_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/lspls/generators/golang"
+)
+
+// omitInternalRuntimeTest checks that the types generated for
+// testdata/omit_internal.txtar compile without the internal types, and
+// that the references degraded to any still decode.
+const omitInternalRuntimeTest = `package protocol
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestOmitInternal(t *testing.T) {
+	var e Envelope
+	data := ` + "`" + `{"params":{"processId":1},"kind":"full","all":[{"rootPath":"/src"}]}` + "`" + `
+	if err := json.Unmarshal([]byte(data), &e); err != nil {
+		t.Fatal(err)
+	}
+	params, ok := e.Params.(map[string]any)
+	if !ok || params["processId"] != 1.0 {
+		t.Errorf("Params = %#v, want the decoded object", e.Params)
+	}
+	if e.Kind != "full" || len(e.All) != 1 || e.All[0].RootPath != "/src" {
+		t.Errorf("Envelope = %+v", e)
+	}
+	if !e.Equal(&e) {
+		t.Error("Envelope is not equal to itself")
+	}
+}
+`
+
+func TestOmitInternalRuntime(t *testing.T) {
+	cfg := golang.DefaultConfig()
+	cfg.OmitInternal = true
+	cfg.GenerateEqual = true
+	cfg.Types = []string{"InitializeParams", "Envelope"}
+	runGenerated(t, "omit_internal.txtar", cfg, omitInternalRuntimeTest)
+}
@@ -0,0 +1,328 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package golang
+
+import (
+	"fmt"
+	"go/token"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/albertocavalcante/lspls/internal/lspbase"
+	"github.com/albertocavalcante/lspls/model"
+)
+
+// typeOverride is a hand-written Go definition replacing the generated
+// code for a type alias.
+type typeOverride struct {
+	// matches reports whether the alias has the shape code was written
+	// for, so that a specification change falls back to generated code.
+	matches func(t *model.Type) bool
+
+	// enabled reports whether the override applies under c. A nil enabled
+	// always applies.
+	enabled func(c *Config) bool
+
+	// hasEqual reports that code defines an Equal method, for types that
+	// are not comparable with ==.
+	hasEqual bool
+
+	// imports lists the packages code uses.
+	imports []string
+
+	// code is the Go source of the definition, without a doc comment. It
+	// refers to the type by its LSP name, which is replaced by the
+	// configured Go name.
+	code string
+}
+
+// typeOverrides holds the curated overrides, keyed by LSP type name.
+// Overridden types without hasEqual are comparable with ==.
+var typeOverrides = map[string]typeOverride{
+	"ProgressToken": {
+		matches: isIntegerOrString,
+		imports: []string{"encoding/json", "fmt", "strconv"},
+		code:    progressTokenCode,
+	},
+	lspbase.TypeLSPAny: {
+		matches:  func(t *model.Type) bool { return t != nil && t.Kind == "or" },
+		enabled:  rawAny,
+		hasEqual: true,
+		imports:  []string{"bytes", "encoding/json", "fmt"},
+		code:     lspAnyCode,
+	},
+	lspbase.TypeLSPObject: {
+		matches: func(t *model.Type) bool {
+			vt, ok := t.Value.(*model.Type)
+			return t.Kind == "map" && ok && isReference(vt, lspbase.TypeLSPAny)
+		},
+		enabled:  rawAny,
+		hasEqual: true,
+		imports:  []string{"bytes", "encoding/json", "fmt"},
+		code:     rawJSONCode(lspbase.TypeLSPObject, '{', "object"),
+	},
+	lspbase.TypeLSPArray: {
+		matches: func(t *model.Type) bool {
+			return t.Kind == "array" && isReference(t.Element, lspbase.TypeLSPAny)
+		},
+		enabled:  rawAny,
+		hasEqual: true,
+		imports:  []string{"bytes", "encoding/json", "fmt"},
+		code:     rawJSONCode(lspbase.TypeLSPArray, '[', "array"),
+	},
+}
+
+// rawAny enables the raw JSON overrides of LSPAny, LSPObject, and LSPArray.
+func rawAny(c *Config) bool {
+	return c.RawAny
+}
+
+// override returns the override for alias a, if there is one that is
+// enabled and matches a's type.
+func (g *Generator) override(a *model.TypeAlias) (typeOverride, bool) {
+	ov, ok := typeOverrides[a.Name]
+	if !ok || !ov.matches(a.Type) || (ov.enabled != nil && !ov.enabled(&g.config)) {
+		return typeOverride{}, false
+	}
+	return ov, true
+}
+
+// overridden reports whether the named type is generated from an override.
+func (g *Generator) overridden(name string) bool {
+	_, ok := g.overrideOf(name)
+	return ok
+}
+
+// overrideOf returns the override the named type is generated from.
+func (g *Generator) overrideOf(name string) (typeOverride, bool) {
+	a, ok := g.aliases[name]
+	if !ok {
+		return typeOverride{}, false
+	}
+	return g.override(a)
+}
+
+// isReference reports whether t is a reference to the named type.
+func isReference(t *model.Type, name string) bool {
+	return t != nil && t.Kind == "reference" && t.Name == name
+}
+
+// isIntegerOrString reports whether t is the union integer | string.
+func isIntegerOrString(t *model.Type) bool {
+	if t == nil || t.Kind != "or" || len(t.Items) != 2 {
+		return false
+	}
+	var names []string
+	for _, item := range t.Items {
+		if item.Kind != "base" {
+			return false
+		}
+		names = append(names, item.Name)
+	}
+	slices.Sort(names)
+	return slices.Equal(names, []string{lspbase.TypeInteger, lspbase.TypeString})
+}
+
+const progressTokenCode = `type ProgressToken struct {
+	value any // string, int32, or nil
+}
+
+// NewStringToken returns a ProgressToken holding s.
+func NewStringToken(s string) ProgressToken {
+	return ProgressToken{s}
+}
+
+// NewIntToken returns a ProgressToken holding n.
+func NewIntToken(n int32) ProgressToken {
+	return ProgressToken{n}
+}
+
+// IsZero reports whether t holds no token.
+func (t ProgressToken) IsZero() bool {
+	return t.value == nil
+}
+
+// Int returns the value of an integer token, and false for other tokens.
+func (t ProgressToken) Int() (int32, bool) {
+	n, ok := t.value.(int32)
+	return n, ok
+}
+
+// String returns the value of a string token, or the decimal form of an
+// integer token.
+func (t ProgressToken) String() string {
+	switch v := t.value.(type) {
+	case string:
+		return v
+	case int32:
+		return strconv.FormatInt(int64(v), 10)
+	}
+	return ""
+}
+
+func (t ProgressToken) MarshalJSON() ([]byte, error) {
+	if t.value == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(t.value)
+}
+
+func (t *ProgressToken) UnmarshalJSON(x []byte) error {
+	if string(x) == "null" {
+		*t = ProgressToken{}
+		return nil
+	}
+	var n int32
+	if err := json.Unmarshal(x, &n); err == nil {
+		*t = NewIntToken(n)
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(x, &s); err == nil {
+		*t = NewStringToken(s)
+		return nil
+	}
+	return fmt.Errorf("progress token must be a string or an integer: %s", x)
+}
+
+`
+
+// lspAnyCode keeps an LSPAny as the JSON text it was decoded from, for
+// servers that pass opaque data through. It is a byte slice rather than a
+// struct so that omitempty leaves out an unset optional property.
+const lspAnyCode = `type LSPAny json.RawMessage
+
+// MarshalJSON returns the JSON text held by a, or null if a is empty.
+func (a LSPAny) MarshalJSON() ([]byte, error) {
+	if len(a) == 0 {
+		return []byte("null"), nil
+	}
+	return a, nil
+}
+
+// UnmarshalJSON sets a to a copy of data, unchanged.
+func (a *LSPAny) UnmarshalJSON(data []byte) error {
+	*a = append((*a)[:0], data...)
+	return nil
+}
+
+// As decodes the JSON text held by a into the value pointed to by v.
+func (a LSPAny) As(v any) error {
+	data, _ := a.MarshalJSON()
+	return json.Unmarshal(data, v)
+}
+
+// MustObject returns the members of the JSON object held by a. It panics
+// if a holds anything else.
+func (a LSPAny) MustObject() map[string]LSPAny {
+	var m map[string]LSPAny
+	if err := a.As(&m); err != nil || m == nil {
+		panic(fmt.Sprintf("LSPAny is not an object: %s", a))
+	}
+	return m
+}
+
+// MustArray returns the elements of the JSON array held by a. It panics
+// if a holds anything else.
+func (a LSPAny) MustArray() []LSPAny {
+	var s []LSPAny
+	if err := a.As(&s); err != nil || s == nil {
+		panic(fmt.Sprintf("LSPAny is not an array: %s", a))
+	}
+	return s
+}
+
+// Equal reports whether x and y hold the same JSON text.
+func (x *LSPAny) Equal(y *LSPAny) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	return bytes.Equal(*x, *y)
+}
+
+`
+
+// rawJSONCode returns the definition of name as the JSON text of a value
+// of the given kind, whose encoding starts with the delim byte.
+func rawJSONCode(name string, delim byte, kind string) string {
+	return strings.NewReplacer("NAME", name, "DELIM", strconv.QuoteRune(rune(delim)), "KIND", kind).Replace(`type NAME json.RawMessage
+
+// MarshalJSON returns the JSON text held by v, or null if v is empty.
+func (v NAME) MarshalJSON() ([]byte, error) {
+	if len(v) == 0 {
+		return []byte("null"), nil
+	}
+	return v, nil
+}
+
+// UnmarshalJSON sets v to a copy of data, unchanged. data must be a JSON
+// KIND or null.
+func (v *NAME) UnmarshalJSON(data []byte) error {
+	if data[0] != DELIM && string(data) != "null" {
+		return fmt.Errorf("NAME must be a JSON KIND: %s", data)
+	}
+	*v = append((*v)[:0], data...)
+	return nil
+}
+
+// As decodes the JSON text held by v into the value pointed to by target.
+func (v NAME) As(target any) error {
+	data, _ := v.MarshalJSON()
+	return json.Unmarshal(data, target)
+}
+
+// Equal reports whether x and y hold the same JSON text.
+func (x *NAME) Equal(y *NAME) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	return bytes.Equal(*x, *y)
+}
+
+`)
+}
+
+// qualifiedType returns the Go expression for a TypeOverrides value: the
+// type name qualified by its package name, as json.Number for
+// "encoding/json.Number", or the name itself for an unqualified type.
+func qualifiedType(ov string) string {
+	if i := strings.LastIndex(ov, "/"); i >= 0 {
+		return ov[i+1:]
+	}
+	return ov
+}
+
+// overrideImport returns the import path of a TypeOverrides value, or ""
+// for an unqualified type.
+func overrideImport(ov string) string {
+	i := strings.LastIndex(ov, ".")
+	if i < 0 {
+		return ""
+	}
+	return ov[:i]
+}
+
+// checkTypeOverrides reports an error for a TypeOverrides entry that does
+// not map a base type with a Go default to a well-formed type.
+func checkTypeOverrides(overrides map[string]string) error {
+	for _, name := range slices.Sorted(maps.Keys(overrides)) {
+		if !lspbase.IsStringLike(name) && !lspbase.IsNumeric(name) && name != lspbase.TypeBoolean {
+			return fmt.Errorf("type override for %q: not an overridable base type", name)
+		}
+		ov := overrides[name]
+		pkg, ident, qualified := strings.Cut(qualifiedType(ov), ".")
+		if !qualified {
+			pkg, ident = "", pkg
+		}
+		if !token.IsIdentifier(ident) || (qualified != token.IsIdentifier(pkg)) || (!qualified && strings.Contains(ov, "/")) {
+			return fmt.Errorf("type override for %q: %q is not a Go type name", name, ov)
+		}
+	}
+	return nil
+}
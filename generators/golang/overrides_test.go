@@ -0,0 +1,123 @@
+// SPDX-License-Identifier: MIT
+
+package golang_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/lspls/generators/golang"
+	"github.com/albertocavalcante/lspls/model"
+)
+
+// progressTokenRuntimeTest round-trips both forms of the ProgressToken
+// generated for testdata/progress_token.txtar.
+const progressTokenRuntimeTest = `package protocol
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestProgressTokenRoundTrip(t *testing.T) {
+	for _, tc := range []struct {
+		token ProgressToken
+		json  string
+	}{
+		{NewStringToken("indexing"), ` + "`" + `{"workDoneToken":"indexing"}` + "`" + `},
+		{NewIntToken(42), ` + "`" + `{"workDoneToken":42}` + "`" + `},
+		{NewStringToken("42"), ` + "`" + `{"workDoneToken":"42"}` + "`" + `},
+	} {
+		data, err := json.Marshal(WorkDoneProgressParams{WorkDoneToken: tc.token})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != tc.json {
+			t.Errorf("Marshal(%v) = %s, want %s", tc.token, data, tc.json)
+		}
+		var got WorkDoneProgressParams
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Fatal(err)
+		}
+		if got.WorkDoneToken != tc.token || !got.Equal(&WorkDoneProgressParams{WorkDoneToken: tc.token}) {
+			t.Errorf("round trip of %s = %#v, want %#v", data, got.WorkDoneToken, tc.token)
+		}
+	}
+
+	if n, ok := NewIntToken(7).Int(); !ok || n != 7 {
+		t.Errorf("Int() = %d, %t, want 7, true", n, ok)
+	}
+	if _, ok := NewStringToken("7").Int(); ok {
+		t.Error("string token reports an integer")
+	}
+	if s := NewIntToken(7).String(); s != "7" {
+		t.Errorf("String() = %q, want \"7\"", s)
+	}
+
+	var zero WorkDoneProgressParams
+	if err := json.Unmarshal([]byte(` + "`" + `{"workDoneToken":null}` + "`" + `), &zero); err != nil || !zero.WorkDoneToken.IsZero() {
+		t.Errorf("null token = %#v, %v, want zero", zero.WorkDoneToken, err)
+	}
+	if err := json.Unmarshal([]byte(` + "`" + `{"workDoneToken":true}` + "`" + `), &zero); err == nil {
+		t.Error("boolean token decoded without error")
+	}
+}
+`
+
+func TestProgressTokenRuntime(t *testing.T) {
+	cfg := golang.DefaultConfig()
+	cfg.GenerateEqual = true
+	runGenerated(t, "progress_token.txtar", cfg, progressTokenRuntimeTest)
+}
+
+// typeOverridesRuntimeTest checks that the json.Number decimals generated
+// for testdata/type_overrides.txtar keep the JSON text of their numbers.
+const typeOverridesRuntimeTest = `package protocol
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestDecimalPrecision(t *testing.T) {
+	const in = ` + "`" + `{"value":0.10000000000000000001,"samples":[1e400,3],"byName":{"a":12345678901234567890},"limit":2.50,"count":1}` + "`" + `
+	var m Measurement
+	if err := json.Unmarshal([]byte(in), &m); err != nil {
+		t.Fatal(err)
+	}
+	if m.Value != "0.10000000000000000001" || m.Samples[0] != "1e400" || m.ByName["a"] != "12345678901234567890" {
+		t.Errorf("decoded %+v, want the numbers unchanged", m)
+	}
+	if v, ok := m.Limit.Value.(json.Number); !ok || v != "2.50" {
+		t.Errorf("Limit = %#v, want json.Number 2.50", m.Limit.Value)
+	}
+	out, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != in {
+		t.Errorf("Marshal = %s, want %s", out, in)
+	}
+}
+`
+
+func TestTypeOverridesRuntime(t *testing.T) {
+	cfg := golang.DefaultConfig()
+	cfg.SplitFiles = true
+	cfg.TypeOverrides = map[string]string{"decimal": "encoding/json.Number"}
+	runGenerated(t, "type_overrides.txtar", cfg, typeOverridesRuntimeTest)
+}
+
+func TestTypeOverridesInvalid(t *testing.T) {
+	for _, overrides := range []map[string]string{
+		{"LSPAny": "string"},
+		{"decimal": "json.Num.ber"},
+		{"decimal": "encoding/json"},
+		{"decimal": "big number"},
+	} {
+		cfg := golang.DefaultConfig()
+		cfg.TypeOverrides = overrides
+		if _, err := golang.New(&model.Model{}, cfg).Generate(); err == nil {
+			t.Errorf("TypeOverrides %v: no error", overrides)
+		}
+	}
+}
@@ -0,0 +1,404 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package golang
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/albertocavalcante/lspls/model"
+)
+
+// declChunk is a unit of generated code that moves between packages as a
+// whole: a type with its methods and helpers, an Or_* union, or an
+// enumeration constant.
+type declChunk struct {
+	f *goFile
+
+	// prefix and suffix surround the body to parse it as a file.
+	prefix, suffix string
+
+	decls []string     // top-level identifiers the chunk declares
+	refs  []chunkRef   // references to identifiers of other chunks
+	deps  []*declChunk // chunks refs point to, without duplicates
+	pkg   string       // subpackage name, or "" for the base package
+}
+
+// chunkRef is a reference from a chunk to an identifier declared by
+// another chunk.
+type chunkRef struct {
+	to     *declChunk
+	offset int // byte offset in the chunk body
+}
+
+// generatePackages fills out for SplitPackages. The base package gets
+// protocol.go, with the method constants, and json.go as with SplitFiles,
+// less the types moved into subpackages. Every namespace left with types
+// of its own gets a file in out.Packages.
+func (g *Generator) generatePackages(out *Output) error {
+	if g.config.ImportPath == "" {
+		return errors.New("split packages: the import path of the base package is required")
+	}
+	if g.config.GenerateServer || g.config.GenerateClient {
+		g.log.Warn("Server and Client interfaces are not generated with split packages")
+	}
+	if g.config.Registry {
+		g.log.Warn("the method registry is not generated with split packages")
+	}
+	if g.config.WorkspaceEditHelpers {
+		g.log.Warn("workspace edit helpers are not generated with split packages")
+	}
+	if g.config.ServerInfoHelper {
+		g.log.Warn("info helpers are not generated with split packages")
+	}
+	if g.config.EmbedSchemas {
+		g.log.Warn("embedded schemas are not generated with split packages")
+	}
+	if g.config.GenTests {
+		g.log.Warn("the round-trip test is not generated with split packages")
+	}
+	if len(g.config.Renames) > 0 {
+		g.log.Warn("renamed type aliases are not generated with split packages")
+	}
+
+	var types, unions, consts []*declChunk
+	for _, name := range g.types.keys() {
+		c := &declChunk{f: newGoFile(), prefix: "package p\n\n"}
+		g.writeType(c.f, name)
+		if e, ok := g.enums[name]; ok && g.config.EnumValues {
+			g.writeEnumValues(c.f, e)
+		}
+		if e, ok := g.enums[name]; ok && g.config.SemanticTokensHelpers {
+			g.writeSemanticTokensLegend(c.f, e)
+		}
+		types = append(types, c)
+	}
+	for _, name := range g.orTypes.keys() {
+		c := &declChunk{f: newGoFile(), prefix: "package p\n\n"}
+		g.writeOrType(c.f, g.orTypes.get(name))
+		unions = append(unions, c)
+	}
+	for _, name := range g.consts.keys() {
+		c := &declChunk{f: newGoFile(), prefix: "package p\n\nconst (\n", suffix: ")\n"}
+		c.f.body.WriteString(g.consts.get(name))
+		consts = append(consts, c)
+	}
+	all := slices.Concat(types, unions, consts)
+	if err := linkChunks(all); err != nil {
+		return fmt.Errorf("split packages: %w", err)
+	}
+	g.partition(slices.Concat(types, unions), consts)
+
+	var err error
+	f := newGoFile()
+	if g.writeChunks(f, types) && g.config.GenerateEqual {
+		f.body.WriteString(equalPtrHelper)
+	}
+	g.writeConstChunks(f, consts)
+	if g.config.ErrorType {
+		g.writeResponseError(f)
+	}
+	g.writeProposedTables(&f.body)
+	f.body.WriteString(g.generateMethodConstants())
+	if out.Protocol, err = g.render(f); err != nil {
+		return fmt.Errorf("generate protocol: %w", err)
+	}
+
+	f = newGoFile()
+	if g.writeChunks(f, unions) {
+		if out.JSON, err = g.render(f); err != nil {
+			return fmt.Errorf("generate json: %w", err)
+		}
+	}
+
+	if g.config.Index {
+		if out.Doc, err = g.generateDocFile(); err != nil {
+			return fmt.Errorf("generate doc: %w", err)
+		}
+	}
+
+	var pkgs []string
+	for _, c := range all {
+		if c.pkg != "" && !slices.Contains(pkgs, c.pkg) {
+			pkgs = append(pkgs, c.pkg)
+		}
+	}
+	slices.Sort(pkgs)
+	out.Packages = make(map[string][]byte, len(pkgs))
+	for _, pkg := range pkgs {
+		f := newGoFile()
+		f.pkg = pkg
+		if g.writeChunks(f, types) && g.config.GenerateEqual {
+			f.body.WriteString(equalPtrHelper)
+		}
+		g.writeChunks(f, unions)
+		g.writeConstChunks(f, consts)
+		path := pkg + "/" + pkg + ".go"
+		if out.Packages[path], err = g.render(f); err != nil {
+			return fmt.Errorf("generate %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// linkChunks parses every chunk and records the identifiers it declares,
+// its references to identifiers declared by other chunks, and the chunks
+// it depends on.
+func linkChunks(chunks []*declChunk) error {
+	fset := token.NewFileSet()
+	files := make([]*ast.File, len(chunks))
+	owner := make(map[string]*declChunk)
+	for i, c := range chunks {
+		file, err := parser.ParseFile(fset, "", c.prefix+c.f.body.String()+c.suffix, parser.SkipObjectResolution)
+		if err != nil {
+			return err
+		}
+		files[i] = file
+		for _, decl := range file.Decls {
+			switch decl := decl.(type) {
+			case *ast.GenDecl:
+				for _, spec := range decl.Specs {
+					switch spec := spec.(type) {
+					case *ast.TypeSpec:
+						c.decls = append(c.decls, spec.Name.Name)
+					case *ast.ValueSpec:
+						for _, name := range spec.Names {
+							c.decls = append(c.decls, name.Name)
+						}
+					}
+				}
+			case *ast.FuncDecl:
+				if decl.Recv == nil {
+					c.decls = append(c.decls, decl.Name.Name)
+				}
+			}
+		}
+		for _, name := range c.decls {
+			owner[name] = c
+		}
+	}
+
+	for i, c := range chunks {
+		typeIdents(files[i], func(id *ast.Ident) {
+			d := owner[id.Name]
+			if d == nil || d == c {
+				return
+			}
+			c.refs = append(c.refs, chunkRef{d, fset.Position(id.Pos()).Offset - len(c.prefix)})
+			if !slices.Contains(c.deps, d) {
+				c.deps = append(c.deps, d)
+			}
+		})
+	}
+	return nil
+}
+
+// typeIdents calls fn for every identifier under node that may refer to a
+// package-level declaration: declared names, field and parameter names,
+// selectors, and composite literal keys are skipped.
+func typeIdents(node ast.Node, fn func(*ast.Ident)) {
+	skip := make(map[*ast.Ident]bool)
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.File:
+			skip[n.Name] = true
+		case *ast.Field:
+			for _, name := range n.Names {
+				skip[name] = true
+			}
+		case *ast.SelectorExpr:
+			skip[n.Sel] = true
+		case *ast.KeyValueExpr:
+			if id, ok := n.Key.(*ast.Ident); ok {
+				skip[id] = true
+			}
+		case *ast.TypeSpec:
+			skip[n.Name] = true
+		case *ast.ValueSpec:
+			for _, name := range n.Names {
+				skip[name] = true
+			}
+		case *ast.FuncDecl:
+			skip[n.Name] = true
+		case *ast.Ident:
+			if !skip[n] {
+				fn(n)
+			}
+		}
+		return true
+	})
+}
+
+// partition assigns chunks to packages. A type or union moves into the
+// subpackage of a namespace when only that namespace's requests and
+// notifications reach it; everything else, and everything a base package
+// chunk references, stays in the base package so that it never imports a
+// subpackage. Constants follow the type they belong to.
+func (g *Generator) partition(chunks, consts []*declChunk) {
+	owner := make(map[string]*declChunk)
+	for _, c := range chunks {
+		for _, name := range c.decls {
+			owner[name] = c
+		}
+	}
+
+	seeds := make(map[string][]*declChunk)
+	seed := func(method string, types ...*model.Type) {
+		ns := g.namespace(method)
+		if ns == "" {
+			return
+		}
+		for _, t := range types {
+			if t == nil {
+				continue
+			}
+			expr, err := parser.ParseExpr(g.goType(t, false))
+			if err != nil {
+				continue
+			}
+			typeIdents(expr, func(id *ast.Ident) {
+				if c := owner[id.Name]; c != nil {
+					seeds[ns] = append(seeds[ns], c)
+				}
+			})
+		}
+	}
+	for _, r := range g.model.Requests {
+		if g.includeMethod(r.Method, r.Proposed) {
+			seed(r.Method, r.Params, r.Result, r.PartialResult, r.RegistrationOptions)
+		}
+	}
+	for _, n := range g.model.Notifications {
+		if g.includeMethod(n.Method, n.Proposed) {
+			seed(n.Method, n.Params, n.RegistrationOptions)
+		}
+	}
+
+	// reached maps each chunk to the one namespace reaching it, or to ""
+	// once several do.
+	reached := make(map[*declChunk]string)
+	for _, ns := range slices.Sorted(maps.Keys(seeds)) {
+		visited := make(map[*declChunk]bool)
+		stack := slices.Clone(seeds[ns])
+		for len(stack) > 0 {
+			c := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if visited[c] {
+				continue
+			}
+			visited[c] = true
+			if prev, ok := reached[c]; ok && prev != ns {
+				reached[c] = ""
+			} else {
+				reached[c] = ns
+			}
+			stack = append(stack, c.deps...)
+		}
+	}
+	for _, c := range chunks {
+		c.pkg = reached[c]
+	}
+	for _, c := range consts {
+		if len(c.deps) > 0 {
+			c.pkg = c.deps[0].pkg
+		}
+	}
+
+	all := slices.Concat(chunks, consts)
+	for changed := true; changed; {
+		changed = false
+		for _, c := range all {
+			if c.pkg != "" {
+				continue
+			}
+			for _, d := range c.deps {
+				if d.pkg != "" {
+					d.pkg = ""
+					changed = true
+				}
+			}
+		}
+		for _, c := range consts {
+			if len(c.deps) > 0 && c.pkg != c.deps[0].pkg {
+				c.pkg = c.deps[0].pkg
+				changed = true
+			}
+		}
+	}
+}
+
+// namespace returns the subpackage name for the types of method: its
+// lowercased prefix up to the first slash, such as "textdocument" for
+// "textDocument/hover". It returns "" for methods without one, "$/"
+// methods, and prefixes that are not usable as a package name.
+func (g *Generator) namespace(method string) string {
+	prefix, _, ok := strings.Cut(method, "/")
+	ns := strings.ToLower(prefix)
+	if !ok || !token.IsIdentifier(ns) || ns == g.config.PackageName {
+		return ""
+	}
+	return ns
+}
+
+// writeChunks writes the chunks of f's package to f and reports whether
+// there were any. References from a subpackage to identifiers of the base
+// package are qualified with its name.
+func (g *Generator) writeChunks(f *goFile, chunks []*declChunk) bool {
+	wrote := false
+	for _, c := range chunks {
+		if c.pkg == f.pkg {
+			g.writeChunk(f, c)
+			wrote = true
+		}
+	}
+	return wrote
+}
+
+// writeConstChunks writes the constants of f's package to f as a single
+// const block.
+func (g *Generator) writeConstChunks(f *goFile, consts []*declChunk) {
+	var block []*declChunk
+	for _, c := range consts {
+		if c.pkg == f.pkg {
+			block = append(block, c)
+		}
+	}
+	if len(block) == 0 {
+		return
+	}
+	f.body.WriteString("const (\n")
+	for _, c := range block {
+		f.body.WriteString("\t")
+		g.writeChunk(f, c)
+	}
+	f.body.WriteString(")\n\n")
+}
+
+// writeChunk writes the body of c to f, merging its imports.
+func (g *Generator) writeChunk(f *goFile, c *declChunk) {
+	for imp := range c.f.imports {
+		f.use(imp)
+	}
+	body := c.f.body.String()
+	last := 0
+	for _, ref := range c.refs {
+		if f.pkg == "" || ref.to.pkg != "" {
+			continue
+		}
+		f.body.WriteString(body[last:ref.offset])
+		f.body.WriteString(g.config.PackageName + ".")
+		last = ref.offset
+		f.use(g.config.ImportPath)
+	}
+	f.body.WriteString(body[last:])
+}
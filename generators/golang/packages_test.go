@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: MIT
+
+package golang_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/lspls/generators/golang"
+)
+
+// splitPackagesRuntimeTest uses the base package and both subpackages
+// generated for testdata/split_packages.txtar together.
+const splitPackagesRuntimeTest = `package protocol_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"runtimetest"
+	"runtimetest/textdocument"
+	"runtimetest/workspace"
+)
+
+func TestSplitPackages(t *testing.T) {
+	pos := protocol.Position{Line: 3, Character: 7}
+	params := textdocument.HoverParams{TextDocumentPositionParams: textdocument.TextDocumentPositionParams{
+		TextDocument: textdocument.TextDocumentIdentifier{Uri: "file:///a.go"},
+		Position:     pos,
+	}}
+	data, err := json.Marshal(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got textdocument.HoverParams
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !got.Equal(&params) {
+		t.Errorf("round trip = %+v, want %+v", got, params)
+	}
+
+	sym := workspace.WorkspaceSymbol{
+		Name:     "main",
+		Location: protocol.Location{Uri: "file:///a.go", Range: protocol.Range{Start: pos, End: pos}},
+	}
+	if !sym.Location.Range.Start.Equal(&pos) {
+		t.Error("shared types differ across packages")
+	}
+	_ = protocol.MethodTextDocumentHover
+}
+`
+
+func TestSplitPackagesRuntime(t *testing.T) {
+	cfg := golang.DefaultConfig()
+	cfg.GenerateEqual = true
+	cfg.SplitPackages = true
+	cfg.ImportPath = "runtimetest"
+	runGenerated(t, "split_packages.txtar", cfg, splitPackagesRuntimeTest)
+}
@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package golang
+
+import (
+	"fmt"
+
+	"github.com/albertocavalcante/lspls/internal/lspbase"
+	"github.com/albertocavalcante/lspls/model"
+)
+
+// writePositionHelpers writes comparison methods for structure s if it is
+// Position, with numeric line and character properties, or Range, with
+// start and end properties of such a Position. Other structures, and ones
+// of those names with another shape, get nothing.
+func (g *Generator) writePositionHelpers(f *goFile, s *model.Structure) {
+	buf := &f.body
+	name := g.typeName(s.Name)
+	switch {
+	case s.Name == "Position" && isPosition(s):
+		buf.WriteString("// Before reports whether p comes before q: on an earlier line, or on\n")
+		buf.WriteString("// the same line at an earlier character.\n")
+		fmt.Fprintf(buf, "func (p %s) Before(q %s) bool {\n", name, name)
+		buf.WriteString("\treturn p.Line < q.Line || p.Line == q.Line && p.Character < q.Character\n")
+		buf.WriteString("}\n\n")
+	case s.Name == "Range" && g.isRange(s):
+		position := g.typeName("Position")
+		buf.WriteString("// IsEmpty reports whether r contains no characters, that is, whether\n")
+		buf.WriteString("// its end is not after its start.\n")
+		fmt.Fprintf(buf, "func (r %s) IsEmpty() bool {\n", name)
+		buf.WriteString("\treturn !r.Start.Before(r.End)\n")
+		buf.WriteString("}\n\n")
+		buf.WriteString("// Contains reports whether p is within r. Ranges are half-open, as in\n")
+		buf.WriteString("// the protocol: r contains its start but not its end, so an empty range\n")
+		buf.WriteString("// contains no position.\n")
+		fmt.Fprintf(buf, "func (r %s) Contains(p %s) bool {\n", name, position)
+		buf.WriteString("\treturn !p.Before(r.Start) && p.Before(r.End)\n")
+		buf.WriteString("}\n\n")
+		buf.WriteString("// Overlaps reports whether r and q have a position in common, that is,\n")
+		buf.WriteString("// whether each starts before the other ends. Ranges that only touch do\n")
+		buf.WriteString("// not overlap.\n")
+		fmt.Fprintf(buf, "func (r %s) Overlaps(q %s) bool {\n", name, name)
+		buf.WriteString("\treturn r.Start.Before(q.End) && q.Start.Before(r.End)\n")
+		buf.WriteString("}\n\n")
+	}
+}
+
+// isPosition reports whether s has the line and character properties of
+// a Position, both required numbers.
+func isPosition(s *model.Structure) bool {
+	return hasPropertyShape(s, "line", func(t *model.Type) bool {
+		return t.Kind == "base" && lspbase.IsNumeric(t.Name)
+	}) && hasPropertyShape(s, "character", func(t *model.Type) bool {
+		return t.Kind == "base" && lspbase.IsNumeric(t.Name)
+	})
+}
+
+// isRange reports whether s has the start and end properties of a Range,
+// both required references to a Position that isPosition accepts.
+func (g *Generator) isRange(s *model.Structure) bool {
+	if _, ok := g.types.m["Position"]; !ok {
+		return false
+	}
+	position, ok := g.structures["Position"]
+	if !ok || !isPosition(position) {
+		return false
+	}
+	isRef := func(t *model.Type) bool { return t.Kind == "reference" && t.Name == "Position" }
+	return hasPropertyShape(s, "start", isRef) && hasPropertyShape(s, "end", isRef)
+}
+
+// hasPropertyShape reports whether s has a required property called name
+// whose type satisfies ok.
+func hasPropertyShape(s *model.Structure, name string, ok func(*model.Type) bool) bool {
+	for _, p := range s.Properties {
+		if p.Name == name {
+			return !p.Optional && p.Type != nil && ok(p.Type)
+		}
+	}
+	return false
+}
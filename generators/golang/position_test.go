@@ -0,0 +1,93 @@
+// SPDX-License-Identifier: MIT
+
+package golang_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/lspls/generators/golang"
+)
+
+// positionRuntimeTest checks the comparison semantics of the methods
+// generated for testdata/position_helpers.txtar.
+const positionRuntimeTest = `package protocol
+
+import "testing"
+
+func at(line, char uint32) Position { return Position{Line: line, Character: char} }
+
+func span(sl, sc, el, ec uint32) Range { return Range{Start: at(sl, sc), End: at(el, ec)} }
+
+func TestBefore(t *testing.T) {
+	tests := []struct {
+		p, q Position
+		want bool
+	}{
+		{at(1, 5), at(1, 6), true},
+		{at(1, 9), at(2, 0), true},
+		{at(2, 0), at(1, 9), false},
+		{at(1, 5), at(1, 5), false},
+	}
+	for _, tt := range tests {
+		if got := tt.p.Before(tt.q); got != tt.want {
+			t.Errorf("%v.Before(%v) = %v, want %v", tt.p, tt.q, got, tt.want)
+		}
+	}
+}
+
+func TestContains(t *testing.T) {
+	r := span(1, 4, 3, 2)
+	tests := []struct {
+		p    Position
+		want bool
+	}{
+		{at(1, 4), true},
+		{at(2, 100), true},
+		{at(3, 1), true},
+		{at(3, 2), false},
+		{at(1, 3), false},
+		{at(0, 9), false},
+	}
+	for _, tt := range tests {
+		if got := r.Contains(tt.p); got != tt.want {
+			t.Errorf("%v.Contains(%v) = %v, want %v", r, tt.p, got, tt.want)
+		}
+	}
+	if empty := span(1, 4, 1, 4); !empty.IsEmpty() || empty.Contains(at(1, 4)) {
+		t.Errorf("empty range %v: IsEmpty() = %v, Contains(start) = %v", empty, empty.IsEmpty(), empty.Contains(at(1, 4)))
+	}
+	if r.IsEmpty() {
+		t.Errorf("%v.IsEmpty() = true", r)
+	}
+}
+
+func TestOverlaps(t *testing.T) {
+	r := span(1, 0, 1, 10)
+	tests := []struct {
+		q    Range
+		want bool
+	}{
+		{span(1, 5, 2, 0), true},
+		{span(0, 0, 1, 1), true},
+		{span(1, 2, 1, 3), true},
+		{span(0, 0, 5, 0), true},
+		{span(1, 10, 1, 12), false},
+		{span(0, 0, 1, 0), false},
+		{span(2, 0, 2, 1), false},
+	}
+	for _, tt := range tests {
+		if got := r.Overlaps(tt.q); got != tt.want {
+			t.Errorf("%v.Overlaps(%v) = %v, want %v", r, tt.q, got, tt.want)
+		}
+		if got := tt.q.Overlaps(r); got != tt.want {
+			t.Errorf("%v.Overlaps(%v) = %v, want %v", tt.q, r, got, tt.want)
+		}
+	}
+}
+`
+
+func TestPositionHelpersRuntime(t *testing.T) {
+	cfg := golang.DefaultConfig()
+	cfg.PositionHelpers = true
+	runGenerated(t, "position_helpers.txtar", cfg, positionRuntimeTest)
+}
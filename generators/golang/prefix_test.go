@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: MIT
+
+package golang_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/lspls/generators/golang"
+)
+
+// typePrefixRuntimeTest checks that the code generated for
+// testdata/type_prefix.txtar compiles and keeps the JSON property names.
+const typePrefixRuntimeTest = `package protocol
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestTypePrefix(t *testing.T) {
+	params := LSPDefinitionParams{
+		LSPTextDocumentPositionParams: LSPTextDocumentPositionParams{
+			Uri:      "file:///a.go",
+			Position: LSPPosition{Line: 1, Character: 2},
+		},
+		Kind: LSPSymbolKindModule,
+	}
+	data, err := json.Marshal(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := ` + "`" + `{"uri":"file:///a.go","position":{"line":1,"character":2},"kind":2}` + "`" + `
+	if string(data) != want {
+		t.Errorf("Marshal = %s, want %s", data, want)
+	}
+
+	var def LSPDefinition
+	if err := json.Unmarshal([]byte(` + "`" + `{"uri":"file:///a.go","range":{"start":{"line":3,"character":0},"end":{"line":3,"character":4}}}` + "`" + `), &def); err != nil {
+		t.Fatal(err)
+	}
+	loc, ok := def.Value.(LSPLocation)
+	if !ok {
+		t.Fatalf("Value has type %T, want LSPLocation", def.Value)
+	}
+	if loc.Range.End.Character != 4 {
+		t.Errorf("Location = %+v", loc)
+	}
+	if LSPMethodTextDocumentDefinition != "textDocument/definition" {
+		t.Errorf("method constant = %q", LSPMethodTextDocumentDefinition)
+	}
+}
+`
+
+func TestTypePrefixRuntime(t *testing.T) {
+	cfg := golang.DefaultConfig()
+	cfg.GenerateClient = false
+	cfg.TypePrefix = "LSP"
+	runGenerated(t, "type_prefix.txtar", cfg, typePrefixRuntimeTest)
+}
@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package golang
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/albertocavalcante/lspls/internal/lspbase"
+	"github.com/albertocavalcante/lspls/model"
+)
+
+// progressKinds maps the work done progress payloads to the value of
+// their kind discriminator.
+var progressKinds = map[string]string{
+	"WorkDoneProgressBegin":  "begin",
+	"WorkDoneProgressReport": "report",
+	"WorkDoneProgressEnd":    "end",
+}
+
+// writeProgressHelpers writes a constructor for structure s if it is a
+// work done progress payload with a kind property of its string literal,
+// setting the kind, or ProgressParams, with token and value properties.
+// Payloads with an optional percentage also get WithPercentage, which
+// keeps the percentage within the 0 to 100 the protocol allows. Other
+// structures, and ones of those names with another shape, get nothing.
+func (g *Generator) writeProgressHelpers(f *goFile, s *model.Structure) {
+	buf := &f.body
+	name := g.typeName(s.Name)
+
+	if s.Name == "ProgressParams" {
+		token, value := property(s, "token"), property(s, "value")
+		if token == nil || value == nil || token.Optional || value.Optional {
+			return
+		}
+		tokenType, _ := g.fieldType(token)
+		valueType, _ := g.fieldType(value)
+		fmt.Fprintf(buf, "// New%s returns the params of a $/progress notification reporting\n", name)
+		buf.WriteString("// value for token.\n")
+		fmt.Fprintf(buf, "func New%s(token %s, value %s) %s {\n", name, tokenType, valueType, name)
+		fmt.Fprintf(buf, "\treturn %s{Token: token, Value: value}\n", name)
+		buf.WriteString("}\n\n")
+		return
+	}
+
+	kind, ok := progressKinds[s.Name]
+	if !ok || !hasPropertyShape(s, "kind", func(t *model.Type) bool {
+		return t.Kind == "stringLiteral" && t.Value == kind
+	}) {
+		return
+	}
+	params, fields := "", fmt.Sprintf("Kind: %q", kind)
+	if s.Name == "WorkDoneProgressBegin" {
+		if !hasPropertyShape(s, "title", func(t *model.Type) bool { return t.Kind == "base" && t.Name == "string" }) {
+			return
+		}
+		params, fields = "title string", fields+", Title: title"
+	}
+	fmt.Fprintf(buf, "// New%s returns a %s\n", name, name)
+	if params == "" {
+		fmt.Fprintf(buf, "// with its kind set to %q.\n", kind)
+	} else {
+		fmt.Fprintf(buf, "// with the given title and its kind set to %q.\n", kind)
+	}
+	fmt.Fprintf(buf, "func New%s(%s) %s {\n", name, params, name)
+	fmt.Fprintf(buf, "\treturn %s{%s}\n", name, fields)
+	buf.WriteString("}\n\n")
+
+	p := property(s, "percentage")
+	if p == nil || !p.Optional || !g.includeProperty(p) || g.tristate(p) || p.Type.Kind != "base" || !lspbase.IsNumeric(p.Type.Name) {
+		return
+	}
+	base := g.goType(p.Type, false)
+	buf.WriteString("// WithPercentage returns x with its percentage set to n, capped at 100.\n")
+	fmt.Fprintf(buf, "func (x %s) WithPercentage(n %s) %s {\n", name, base, name)
+	buf.WriteString("\tif n > 100 {\n")
+	buf.WriteString("\t\tn = 100\n")
+	buf.WriteString("\t}\n")
+	if !strings.HasPrefix(base, "uint") {
+		buf.WriteString("\tif n < 0 {\n")
+		buf.WriteString("\t\tn = 0\n")
+		buf.WriteString("\t}\n")
+	}
+	buf.WriteString("\tx.Percentage = &n\n")
+	buf.WriteString("\treturn x\n")
+	buf.WriteString("}\n\n")
+}
+
+// property returns the property of s called name, or nil.
+func property(s *model.Structure, name string) *model.Property {
+	for i := range s.Properties {
+		if s.Properties[i].Name == name {
+			return &s.Properties[i]
+		}
+	}
+	return nil
+}
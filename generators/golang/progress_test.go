@@ -0,0 +1,56 @@
+// SPDX-License-Identifier: MIT
+
+package golang_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/lspls/generators/golang"
+)
+
+// progressRuntimeTest checks that the constructors generated for
+// testdata/progress_helpers.txtar set the kind of each payload and that
+// WithPercentage caps the percentage.
+const progressRuntimeTest = `package protocol
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestProgressKinds(t *testing.T) {
+	for _, tc := range []struct {
+		value any
+		want  string
+	}{
+		{NewWorkDoneProgressBegin("Indexing").WithPercentage(10), ` + "`" + `{"kind":"begin","title":"Indexing","percentage":10}` + "`" + `},
+		{NewWorkDoneProgressReport().WithPercentage(250), ` + "`" + `{"kind":"report","percentage":100}` + "`" + `},
+		{NewWorkDoneProgressEnd(), ` + "`" + `{"kind":"end"}` + "`" + `},
+	} {
+		data, err := json.Marshal(tc.value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != tc.want {
+			t.Errorf("Marshal(%T) = %s, want %s", tc.value, data, tc.want)
+		}
+	}
+}
+
+func TestProgressParams(t *testing.T) {
+	params := NewProgressParams(NewStringToken("index"), "done")
+	data, err := json.Marshal(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := ` + "`" + `{"token":"index","value":"done"}` + "`" + `; string(data) != want {
+		t.Errorf("Marshal = %s, want %s", data, want)
+	}
+}
+`
+
+func TestProgressHelpersRuntime(t *testing.T) {
+	cfg := golang.DefaultConfig()
+	cfg.ProgressHelpers = true
+	runGenerated(t, "progress_helpers.txtar", cfg, progressRuntimeTest)
+}
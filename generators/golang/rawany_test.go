@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: MIT
+
+package golang_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/lspls/generators/golang"
+)
+
+// rawAnyRuntimeTest checks that the raw JSON types generated for
+// testdata/raw_any.txtar re-encode exactly the bytes they decoded.
+const rawAnyRuntimeTest = `package protocol
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestRawAnyRoundTrip(t *testing.T) {
+	// Key order, a number beyond float64 precision, and escapes would all
+	// change if the values were decoded and re-encoded.
+	const in = ` + "`" + `{"command":"run","arguments":[{"z":1,"a":[true,null]},12345678901234567890,"é"],"data":{"b":2,"a":1},"settings":{"x":{"y":[]}}}` + "`" + `
+
+	var p ExecuteCommandParams
+	if err := json.Unmarshal([]byte(in), &p); err != nil {
+		t.Fatal(err)
+	}
+	out, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != in {
+		t.Errorf("round trip changed the JSON:\n got %s\nwant %s", out, in)
+	}
+	if string(p.Arguments[1]) != "12345678901234567890" {
+		t.Errorf("Arguments[1] = %s", p.Arguments[1])
+	}
+
+	var n json.Number
+	dec := json.NewDecoder(strings.NewReader(string(p.Arguments[1])))
+	dec.UseNumber()
+	if err := dec.Decode(&n); err != nil || n != "12345678901234567890" {
+		t.Errorf("decode number = %v, %v", n, err)
+	}
+	obj := p.Data.MustObject()
+	var a int
+	if err := obj["a"].As(&a); err != nil || a != 1 {
+		t.Errorf("data.a = %d, %v; want 1", a, err)
+	}
+	if elems := p.Arguments[0].MustObject()["a"].MustArray(); len(elems) != 2 || string(elems[1]) != "null" {
+		t.Errorf("arguments[0].a = %v", elems)
+	}
+	var settings map[string]any
+	if err := p.Settings.As(&settings); err != nil || settings["x"] == nil {
+		t.Errorf("settings = %v, %v", settings, err)
+	}
+
+	// Unset optional properties are left out.
+	out, err = json.Marshal(ExecuteCommandParams{Command: "run"})
+	if err != nil || string(out) != ` + "`" + `{"command":"run"}` + "`" + ` {
+		t.Errorf("Marshal of empty params = %s, %v", out, err)
+	}
+
+	if err := json.Unmarshal([]byte(` + "`" + `{"command":"run","settings":[1]}` + "`" + `), &p); err == nil {
+		t.Error("LSPObject accepted an array")
+	}
+	q := p
+	if !p.Equal(&q) {
+		t.Error("params are not equal to a copy")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("MustArray of an object did not panic")
+		}
+	}()
+	p.Data.MustArray()
+}
+`
+
+func TestRawAnyRuntime(t *testing.T) {
+	cfg := golang.DefaultConfig()
+	cfg.RawAny = true
+	cfg.GenerateEqual = true
+	runGenerated(t, "raw_any.txtar", cfg, rawAnyRuntimeTest)
+}
@@ -0,0 +1,132 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package golang
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// directionConsts maps metaModel message directions to the Direction
+// constants of the generated registry.
+var directionConsts = map[string]string{
+	"clientToServer": "DirectionClientToServer",
+	"serverToClient": "DirectionServerToClient",
+	"both":           "DirectionBoth",
+}
+
+// writeRegistry writes the Registry map describing every request and
+// notification, with the MethodSpec and Direction types and the generic
+// decode and encode functions its entries use.
+func (g *Generator) writeRegistry(f *goFile) {
+	keys := g.registryMethods.keys()
+	if len(keys) == 0 {
+		return
+	}
+	f.use("encoding/json", "fmt")
+	buf := &f.body
+
+	buf.WriteString(registryTypes)
+	buf.WriteString("// Registry maps every LSP method name to its MethodSpec, so that a generic\n")
+	buf.WriteString("// transport can route any method without a switch over method names.\n")
+	buf.WriteString("var Registry = map[string]MethodSpec{\n")
+	for _, key := range keys {
+		info := g.registryMethods.get(key)
+		fmt.Fprintf(buf, "\t%s: {\n", g.methodConst(info.name))
+		fmt.Fprintf(buf, "\t\tDirection:      %s,\n", directionConsts[info.direction])
+		if info.isNotification {
+			buf.WriteString("\t\tIsNotification: true,\n")
+		}
+		if info.paramsType != "" {
+			fmt.Fprintf(buf, "\t\tDecodeParams:   decodeParams[%s],\n", strings.TrimPrefix(info.paramsType, "*"))
+		} else {
+			buf.WriteString("\t\tDecodeParams:   decodeNoParams,\n")
+		}
+		if !info.isNotification {
+			fmt.Fprintf(buf, "\t\tEncodeResult:   encodeResult[%s],\n", info.resultType)
+		}
+		buf.WriteString("\t},\n")
+	}
+	buf.WriteString("}\n\n")
+	buf.WriteString(registryHelpers)
+}
+
+const registryTypes = `// Direction is the side of the connection that sends a method.
+type Direction string
+
+const (
+	DirectionClientToServer Direction = "clientToServer"
+	DirectionServerToClient Direction = "serverToClient"
+	DirectionBoth           Direction = "both"
+)
+
+// MethodSpec describes an LSP method: who sends it, whether it expects a
+// response, and how to decode its params and encode its result.
+type MethodSpec struct {
+	Direction      Direction
+	IsNotification bool
+
+	// DecodeParams decodes the params of a message into a value of the
+	// type the Server or Client method takes, such as *HoverParams. It
+	// returns nil for methods without params.
+	DecodeParams func(json.RawMessage) (any, error)
+
+	// EncodeResult encodes a request's result, which must have the type
+	// the Server or Client method returns. It is nil for notifications.
+	EncodeResult func(any) (json.RawMessage, error)
+}
+
+`
+
+const registryHelpers = `func decodeParams[T any](data json.RawMessage) (any, error) {
+	params := new(T)
+	if err := json.Unmarshal(data, params); err != nil {
+		return nil, err
+	}
+	return params, nil
+}
+
+func decodeNoParams(json.RawMessage) (any, error) {
+	return nil, nil
+}
+
+func encodeResult[T any](result any) (json.RawMessage, error) {
+	r, ok := result.(T)
+	if !ok && result != nil {
+		return nil, fmt.Errorf("result has type %T, want %T", result, r)
+	}
+	return json.Marshal(r)
+}
+
+`
+
+// writeUnionTypes writes the UnionTypes map from the name of every Or_*
+// union to the Go types of its members, for tools that introspect the
+// package without reflection.
+func (g *Generator) writeUnionTypes(f *goFile) {
+	keys := g.orTypes.keys()
+	if len(keys) == 0 {
+		return
+	}
+	buf := &f.body
+	buf.WriteString("// UnionTypes maps the name of every Or_* union type to the Go types of\n")
+	buf.WriteString("// its members, in the order of its constructors.\n")
+	buf.WriteString("var UnionTypes = map[string][]string{\n")
+	for _, key := range keys {
+		info := g.orTypes.get(key)
+		members := make([]string, 0, len(info.itemNames))
+		for _, name := range info.itemNames {
+			if q := strconv.Quote(name); !slices.Contains(members, q) {
+				members = append(members, q)
+			}
+		}
+		fmt.Fprintf(buf, "\t%q: {%s},\n", info.name, strings.Join(members, ", "))
+	}
+	buf.WriteString("}\n\n")
+}
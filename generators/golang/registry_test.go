@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: MIT
+
+package golang_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/lspls/generators/golang"
+)
+
+// registryRuntimeTest routes a request through the Registry generated for
+// testdata/registry.txtar.
+const registryRuntimeTest = `package protocol
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestRegistry(t *testing.T) {
+	spec, ok := Registry[MethodInitialize]
+	if !ok {
+		t.Fatal("initialize is not registered")
+	}
+	if spec.Direction != DirectionClientToServer || spec.IsNotification {
+		t.Errorf("spec = %+v, want a client-to-server request", spec)
+	}
+
+	params, err := spec.DecodeParams(json.RawMessage(` + "`" + `{"processId":42,"rootUri":"file:///w"}` + "`" + `))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, ok := params.(*InitializeParams)
+	if !ok {
+		t.Fatalf("params have type %T, want *InitializeParams", params)
+	}
+	if p.ProcessId == nil || *p.ProcessId != 42 || p.RootUri != "file:///w" {
+		t.Errorf("params = %+v", p)
+	}
+
+	data, err := spec.EncodeResult(&InitializeResult{ServerName: "lspls"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != ` + "`" + `{"serverName":"lspls"}` + "`" + ` {
+		t.Errorf("result = %s", data)
+	}
+	if _, err := spec.EncodeResult("wrong"); err == nil {
+		t.Error("EncodeResult accepted a result of the wrong type")
+	}
+
+	if params, err := Registry[MethodShutdown].DecodeParams(nil); params != nil || err != nil {
+		t.Errorf("shutdown params = %v, %v; want nil, nil", params, err)
+	}
+	if Registry[MethodInitialized].EncodeResult != nil {
+		t.Error("notification has a result encoder")
+	}
+}
+`
+
+func TestRegistryRuntime(t *testing.T) {
+	cfg := golang.DefaultConfig()
+	cfg.GenerateServer = false
+	cfg.GenerateClient = false
+	cfg.Registry = true
+	runGenerated(t, "registry.txtar", cfg, registryRuntimeTest)
+}
@@ -0,0 +1,62 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package golang
+
+import (
+	"fmt"
+	"go/token"
+	"maps"
+	"slices"
+)
+
+// checkRenames reports an error for a Renames entry whose old name is not
+// a Go identifier or is still a type of the specification, or whose new
+// name is not a type of the specification.
+func (g *Generator) checkRenames() error {
+	types := make(map[string]bool)
+	for _, s := range g.model.Structures {
+		types[s.Name] = true
+	}
+	for _, e := range g.model.Enumerations {
+		types[e.Name] = true
+	}
+	for _, a := range g.model.TypeAliases {
+		types[a.Name] = true
+	}
+	for _, old := range slices.Sorted(maps.Keys(g.config.Renames)) {
+		current := g.config.Renames[old]
+		switch {
+		case !token.IsIdentifier(old):
+			return fmt.Errorf("rename %s=%s: %q is not a Go identifier", old, current, old)
+		case types[old]:
+			return fmt.Errorf("rename %s=%s: %s is still a type of the specification", old, current, old)
+		case !types[current]:
+			return fmt.Errorf("rename %s=%s: unknown type %s", old, current, current)
+		}
+	}
+	return nil
+}
+
+// writeRenames writes a deprecated alias of the current type for each
+// old name in Renames, so that code written against the old name keeps
+// compiling. Renames of types left out of the output are skipped.
+func (g *Generator) writeRenames(f *goFile) {
+	for _, old := range slices.Sorted(maps.Keys(g.config.Renames)) {
+		current := g.config.Renames[old]
+		if _, ok := g.types.m[current]; !ok {
+			g.log.Warn("renamed type skipped: its current type is not generated", "old", old, "type", current)
+			continue
+		}
+		oldName, name := g.typeName(old), g.typeName(current)
+		fmt.Fprintf(&f.body, `// %s is the former name of %s.
+//
+// Deprecated: Use %s instead.
+type %s = %s
+
+`, oldName, name, name, oldName, name)
+	}
+}
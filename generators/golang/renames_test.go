@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: MIT
+
+package golang_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/lspls/generators/golang"
+	"github.com/albertocavalcante/lspls/model"
+)
+
+// renamesRuntimeTest uses the former name of SemanticTokensDelta generated
+// for testdata/renames.txtar interchangeably with the current one.
+const renamesRuntimeTest = `package protocol
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestRenames(t *testing.T) {
+	var delta SemanticTokensDelta = SemanticTokensEdits{ResultId: "1", Edits: []uint32{2}}
+	data, err := json.Marshal(delta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var old SemanticTokensEdits
+	if err := json.Unmarshal(data, &old); err != nil {
+		t.Fatal(err)
+	}
+	if old.ResultId != "1" || len(old.Edits) != 1 {
+		t.Errorf("round trip = %+v, want %+v", old, delta)
+	}
+}
+`
+
+func TestRenamesRuntime(t *testing.T) {
+	cfg := golang.DefaultConfig()
+	cfg.Renames = map[string]string{"SemanticTokensEdits": "SemanticTokensDelta"}
+	runGenerated(t, "renames.txtar", cfg, renamesRuntimeTest)
+}
+
+func TestRenamesInvalid(t *testing.T) {
+	m := &model.Model{
+		Structures: []*model.Structure{{Name: "Hover"}, {Name: "HoverParams"}},
+	}
+	for _, renames := range []map[string]string{
+		{"Old Hover": "Hover"},
+		{"HoverParams": "Hover"},
+		{"OldHover": "Missing"},
+	} {
+		cfg := golang.DefaultConfig()
+		cfg.Renames = renames
+		if _, err := golang.New(m, cfg).Generate(); err == nil {
+			t.Errorf("Renames %v: no error", renames)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package golang
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/albertocavalcante/lspls/model"
+)
+
+// roundTripHelper is the assertion the generated round-trip test runs on
+// every example value.
+const roundTripHelper = `// roundTrip encodes v, decodes the JSON into a new T, and encodes that
+// again, failing on any error.
+func roundTrip[T any](t *testing.T, v T) {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var got T
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("unmarshal %s: %v", data, err)
+	}
+	if _, err := json.Marshal(got); err != nil {
+		t.Fatalf("marshal decoded value: %v", err)
+	}
+}
+
+`
+
+// generateRoundTripTestFile produces protocol_roundtrip_test.go: a test
+// that encodes an example of every generated structure, with its required
+// properties set, and checks that it decodes and encodes again without
+// error. The JSON may change: a union decodes into the first member that
+// accepts it, which need not be the member the example holds.
+func (g *Generator) generateRoundTripTestFile() ([]byte, error) {
+	// The types were resolved, and their degradations reported, when they
+	// were generated; resolving them again must not repeat the warnings.
+	defer func(log *slog.Logger) { g.log = log }(g.log)
+	g.log = slog.New(slog.DiscardHandler)
+
+	f := newGoFile()
+	f.use("encoding/json", "testing")
+	buf := &f.body
+	buf.WriteString(roundTripHelper)
+	buf.WriteString("// TestRoundTrip round-trips an example of every structure through JSON.\n")
+	buf.WriteString("func TestRoundTrip(t *testing.T) {\n")
+	for _, name := range g.types.keys() {
+		if _, ok := g.structures[name]; !ok {
+			continue
+		}
+		fmt.Fprintf(buf, "\tt.Run(%q, func(t *testing.T) {\n", g.typeName(name))
+		fmt.Fprintf(buf, "\t\troundTrip(t, %s)\n", g.exampleStructure(name, make(map[string]bool)))
+		buf.WriteString("\t})\n")
+	}
+	buf.WriteString("}\n")
+
+	return g.render(f)
+}
+
+// exampleStructure returns a composite literal of the named structure with
+// its embedded types and required properties set to example values.
+// active holds the named types whose examples are being built.
+func (g *Generator) exampleStructure(name string, active map[string]bool) string {
+	s := g.structures[name]
+	active[name] = true
+	defer delete(active, name)
+
+	var fields []string
+	for _, ext := range slices.Concat(s.Extends, s.Mixins) {
+		if ext.Kind == "reference" && !g.omitted(ext.Name) {
+			fields = append(fields, g.typeName(ext.Name)+": "+g.exampleStructure(ext.Name, active))
+		}
+	}
+	for _, p := range s.Properties {
+		if p.Optional || !g.includeProperty(&p) {
+			continue
+		}
+		if v := g.exampleValue(p.Type, active); v != "nil" && v != "" {
+			fields = append(fields, g.exportName(p.Name)+": "+v)
+		}
+	}
+	return g.typeName(name) + "{" + strings.Join(fields, ", ") + "}"
+}
+
+// exampleValue returns a Go expression of type goType(t) holding an
+// example value of t: a fixed value for base types and literals, the
+// first constant of enumerations, the first member of unions, and one
+// element for arrays. It returns "nil" where the zero value is the only
+// example, as for nullable, any-typed, and overridden base values, and ""
+// for a reference back to a type in active, whose example would never
+// end; its zero value, or an empty array of it, stands in.
+func (g *Generator) exampleValue(t *model.Type, active map[string]bool) string {
+	if t == nil || t.IsOptional() {
+		return "nil"
+	}
+	switch t.Kind {
+	case "base":
+		if g.baseOverridden(t) {
+			return "nil"
+		}
+		switch base := g.goBaseType(t); base {
+		case "string":
+			return `"example"`
+		case "bool":
+			return "true"
+		case "float64":
+			return "float64(1.5)"
+		case "any":
+			return "nil"
+		default:
+			return base + "(1)"
+		}
+	case "stringLiteral":
+		s, _ := t.Value.(string)
+		return strconv.Quote(s)
+	case "integerLiteral":
+		return fmt.Sprintf("int32(%v)", t.Value)
+	case "booleanLiteral":
+		return fmt.Sprintf("%v", t.Value)
+	case "reference":
+		return g.exampleReference(t, active)
+	case "array":
+		typ := g.goType(t, false)
+		elem := g.exampleValue(t.Element, active)
+		if elem == "nil" || elem == "" {
+			return typ + "{}"
+		}
+		return typ + "{" + elem + "}"
+	case "map":
+		return g.goType(t, false) + "{}"
+	case "or":
+		members := g.orMembers(t)
+		switch len(members) {
+		case 0:
+			return "nil"
+		case 1:
+			return g.exampleValue(members[0], active)
+		}
+		typ := g.goType(t, false)
+		v := g.exampleValue(members[0], active)
+		if v == "nil" || v == "" {
+			return typ + "{}"
+		}
+		return typ + "{Value: " + v + "}"
+	}
+	return "nil"
+}
+
+// exampleReference returns the exampleValue of a reference to a named
+// type.
+func (g *Generator) exampleReference(t *model.Type, active map[string]bool) string {
+	switch {
+	case g.omitted(t.Name):
+		return "nil"
+	case g.overridden(t.Name):
+		return g.typeName(t.Name) + "{}"
+	case active[t.Name]:
+		return ""
+	}
+	if _, ok := g.structures[t.Name]; ok {
+		return g.exampleStructure(t.Name, active)
+	}
+	if e, ok := g.enums[t.Name]; ok {
+		if len(e.Values) == 0 {
+			return "*new(" + g.typeName(t.Name) + ")"
+		}
+		return g.typeName(t.Name) + g.exportName(e.Values[0].Name)
+	}
+	if a, ok := g.aliases[t.Name]; ok {
+		active[t.Name] = true
+		defer delete(active, t.Name)
+		return g.exampleValue(a.Type, active)
+	}
+	return "nil"
+}
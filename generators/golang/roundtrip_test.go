@@ -0,0 +1,23 @@
+// SPDX-License-Identifier: MIT
+
+package golang_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/lspls/generators/golang"
+)
+
+// TestGeneratedRoundTrip runs the generated round-trip test against the
+// generated types. In union_types.txtar, a TextEdit example decodes as an
+// AnnotatedTextEdit, so the JSON changes but the round trip succeeds.
+func TestGeneratedRoundTrip(t *testing.T) {
+	cfg := golang.DefaultConfig()
+	cfg.SplitFiles = true
+	cfg.GenTests = true
+	for _, golden := range []string{"gen_tests.txtar", "union_types.txtar"} {
+		t.Run(golden, func(t *testing.T) {
+			runGenerated(t, golden, cfg, "package protocol\n")
+		})
+	}
+}
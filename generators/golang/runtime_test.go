@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: MIT
+
+package golang_test
+
+import (
+	"encoding/json"
+	"maps"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/albertocavalcante/lspls/generators/golang"
+	"github.com/albertocavalcante/lspls/model"
+	"golang.org/x/tools/txtar"
+)
+
+// runGenerated generates code for the input of testdata/golden with cfg,
+// writes it to a temporary module alongside testSrc, and runs go test there.
+// The module is named runtimetest, the import path of the generated base
+// package.
+func runGenerated(t *testing.T, golden string, cfg golang.Config, testSrc string) {
+	t.Helper()
+	runGeneratedFiles(t, golden, cfg, map[string]string{"runtime_test.go": testSrc})
+}
+
+// runGeneratedFiles is like runGenerated, but writes the files of extra,
+// keyed by their slash-separated path in the module, instead of a single
+// test file, so that a test can bring stubs of the packages the generated
+// code imports.
+func runGeneratedFiles(t *testing.T, golden string, cfg golang.Config, extra map[string]string) {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("skipping compile-and-run test in short mode")
+	}
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go toolchain not in PATH")
+	}
+
+	ar, err := txtar.ParseFile(filepath.Join("testdata", golden))
+	if err != nil {
+		t.Fatalf("parse txtar: %v", err)
+	}
+	var m model.Model
+	for _, f := range ar.Files {
+		if f.Name == "input.json" {
+			if err := json.Unmarshal(f.Data, &m); err != nil {
+				t.Fatalf("unmarshal input: %v", err)
+			}
+		}
+	}
+
+	out, err := golang.New(&m, cfg).Generate()
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":      "module runtimetest\n\ngo 1.22\n",
+		"protocol.go": string(out.Protocol),
+	}
+	maps.Copy(files, extra)
+	if out.JSON != nil {
+		files["json.go"] = string(out.JSON)
+	}
+	if out.Tests != nil {
+		files["protocol_roundtrip_test.go"] = string(out.Tests)
+	}
+	for path, content := range out.Packages {
+		files[path] = string(content)
+	}
+	for name, content := range files {
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0o755); err != nil {
+			t.Fatalf("create directory for %s: %v", name, err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+
+	cmd := exec.Command(goBin, "test", "./...")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOWORK=off")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("go test generated code: %v\n%s", err, output)
+	}
+}
@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package golang
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/albertocavalcante/lspls/generators/jsonschema"
+)
+
+// schemaIDPrefix is the URI scheme of the $id of every embedded schema,
+// which $refs between them use.
+const schemaIDPrefix = "lsp:"
+
+// writeSchemas writes the Schemas map: the JSON Schema of every generated
+// structure, enumeration, and type alias, keyed by LSP type name. The
+// schemas are those of the jsonschema target, each one standalone with an
+// $id that the others refer to.
+func (g *Generator) writeSchemas(f *goFile) error {
+	b := &jsonschema.Builder{
+		RefPrefix:       schemaIDPrefix,
+		IncludeProposed: g.config.IncludeProposed,
+		MinifyDocs:      g.config.MinifyDocs,
+	}
+	buf := &f.body
+	buf.WriteString("// Schemas maps the name of every generated type to its JSON Schema (draft\n")
+	buf.WriteString("// 2020-12), for validating a message before decoding it. Each schema's $id\n")
+	buf.WriteString("// is \"lsp:\" followed by the type name, and other types are referenced by\n")
+	buf.WriteString("// those ids, so a validator holding every schema resolves the references.\n")
+	buf.WriteString("var Schemas = map[string]string{\n")
+	for _, name := range g.types.keys() {
+		var schema jsonschema.Schema
+		if s, ok := g.structures[name]; ok {
+			schema = b.Structure(s)
+		} else if e, ok := g.enums[name]; ok {
+			schema = b.Enumeration(e)
+		} else if a, ok := g.aliases[name]; ok {
+			schema = b.TypeAlias(a)
+		} else {
+			continue
+		}
+		schema["$id"] = schemaIDPrefix + name
+		data, err := marshalSchema(schema)
+		if err != nil {
+			return fmt.Errorf("schema of %s: %w", name, err)
+		}
+		fmt.Fprintf(buf, "\t%q: %s,\n", name, goStringLiteral(data))
+	}
+	buf.WriteString("}\n\n")
+	return nil
+}
+
+// marshalSchema encodes schema as compact JSON, keeping HTML characters in
+// documentation as is.
+func marshalSchema(schema jsonschema.Schema) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(schema); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// goStringLiteral returns a Go string literal of s: a raw string, which
+// keeps JSON readable, unless s contains a backquote.
+func goStringLiteral(s []byte) string {
+	if bytes.ContainsRune(s, '`') {
+		return strconv.Quote(string(s))
+	}
+	return "`" + string(s) + "`"
+}
+
+// generateSchemasFile produces schemas.go: the Schemas map.
+func (g *Generator) generateSchemasFile() ([]byte, error) {
+	f := newGoFile()
+
+	if err := g.writeSchemas(f); err != nil {
+		return nil, err
+	}
+
+	return g.render(f)
+}
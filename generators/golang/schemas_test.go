@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: MIT
+
+package golang_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/lspls/generators/golang"
+)
+
+// schemasRuntimeTest decodes the schemas generated for
+// testdata/embed_schemas.txtar and checks their properties and references.
+const schemasRuntimeTest = `package protocol
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSchemas(t *testing.T) {
+	for name, data := range Schemas {
+		if !json.Valid([]byte(data)) {
+			t.Errorf("schema of %s is not valid JSON: %s", name, data)
+		}
+	}
+
+	var schema struct {
+		ID         string ` + "`" + `json:"$id"` + "`" + `
+		Properties map[string]struct {
+			Ref string ` + "`" + `json:"$ref"` + "`" + `
+		} ` + "`" + `json:"properties"` + "`" + `
+		Required []string ` + "`" + `json:"required"` + "`" + `
+	}
+	if err := json.Unmarshal([]byte(Schemas["HoverParams"]), &schema); err != nil {
+		t.Fatal(err)
+	}
+	if schema.ID != "lsp:HoverParams" {
+		t.Errorf("$id = %q, want lsp:HoverParams", schema.ID)
+	}
+	if len(schema.Properties) != 3 {
+		t.Errorf("properties = %v, want kind, position, and textDocument", schema.Properties)
+	}
+	if ref := schema.Properties["position"].Ref; ref != "lsp:Position" {
+		t.Errorf("position $ref = %q, want lsp:Position", ref)
+	}
+	if _, ok := Schemas["Position"]; !ok {
+		t.Error("no schema for the referenced Position")
+	}
+	if len(schema.Required) != 2 || schema.Required[0] != "textDocument" || schema.Required[1] != "position" {
+		t.Errorf("required = %q, want [textDocument position]", schema.Required)
+	}
+}
+`
+
+func TestEmbedSchemasRuntime(t *testing.T) {
+	cfg := golang.DefaultConfig()
+	cfg.EmbedSchemas = true
+	runGenerated(t, "embed_schemas.txtar", cfg, schemasRuntimeTest)
+}
@@ -0,0 +1,52 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package golang
+
+import (
+	"fmt"
+
+	"github.com/albertocavalcante/lspls/model"
+)
+
+// semanticTokenLegends maps the enumerations whose values make up a
+// SemanticTokensLegend to the name of their legend function and how a
+// token refers to the legend.
+var semanticTokenLegends = map[string]struct{ fn, use string }{
+	"SemanticTokenTypes":     {"SemanticTokenTypesLegend", "A token's type is its index in the slice."},
+	"SemanticTokenModifiers": {"SemanticTokenModifiersLegend", "A token's modifiers are a bit set of indices into the slice."},
+}
+
+// writeSemanticTokensLegend writes the legend function of enumeration e,
+// if it is SemanticTokenTypes or SemanticTokenModifiers.
+func (g *Generator) writeSemanticTokensLegend(f *goFile, e *model.Enumeration) {
+	legend, ok := semanticTokenLegends[e.Name]
+	if !ok {
+		return
+	}
+	name := g.typeName(e.Name)
+	buf := &f.body
+	fmt.Fprintf(buf, "// %s returns the values of the %s\n", legend.fn, name)
+	buf.WriteString("// constants in declaration order, as advertised in a\n")
+	fmt.Fprintf(buf, "// SemanticTokensLegend. %s\n", legend.use)
+	fmt.Fprintf(buf, "func %s() []string {\n", legend.fn)
+	buf.WriteString("\treturn []string{\n")
+	for _, v := range e.Values {
+		fmt.Fprintf(buf, "\t\tstring(%s%s),\n", name, g.exportName(v.Name))
+	}
+	buf.WriteString("\t}\n")
+	buf.WriteString("}\n\n")
+}
+
+// writeSemanticTokensLegends writes the legend functions of the generated
+// semantic token enumerations to f.
+func (g *Generator) writeSemanticTokensLegends(f *goFile) {
+	for _, name := range g.types.keys() {
+		if e, ok := g.enums[name]; ok {
+			g.writeSemanticTokensLegend(f, e)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+// SPDX-License-Identifier: MIT
+
+package golang_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/lspls/generators/golang"
+)
+
+// semanticTokensRuntimeTest checks the legends generated for
+// testdata/semantic_tokens_helpers.txtar.
+const semanticTokensRuntimeTest = `package protocol
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSemanticTokensLegend(t *testing.T) {
+	if got, want := SemanticTokenTypesLegend(), []string{"namespace", "type", "class"}; !slices.Equal(got, want) {
+		t.Errorf("SemanticTokenTypesLegend() = %v, want %v", got, want)
+	}
+	if got, want := SemanticTokenModifiersLegend(), []string{"declaration", "readonly"}; !slices.Equal(got, want) {
+		t.Errorf("SemanticTokenModifiersLegend() = %v, want %v", got, want)
+	}
+}
+`
+
+func TestSemanticTokensHelpersRuntime(t *testing.T) {
+	cfg := golang.DefaultConfig()
+	cfg.SemanticTokensHelpers = true
+	runGenerated(t, "semantic_tokens_helpers.txtar", cfg, semanticTokensRuntimeTest)
+}
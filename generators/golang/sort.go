@@ -0,0 +1,121 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package golang
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/albertocavalcante/lspls/internal/lspbase"
+	"github.com/albertocavalcante/lspls/model"
+)
+
+// writeSortHelpers writes a Sort<Name> function for structure s if it has
+// an obvious ordering key: Position and Range themselves, or a required
+// property of type Range or Position whose Compare function is generated.
+// Position and Range also get the ComparePosition and CompareRange
+// functions the other helpers use, when hasCompare reports they do.
+//
+// Required string and number properties break ties in declaration order,
+// so that sorting gives one canonical order for test comparisons.
+func (g *Generator) writeSortHelpers(f *goFile, s *model.Structure) {
+	name := g.typeName(s.Name)
+	buf := &f.body
+
+	// Either compare names a comparison function of the element type, or
+	// keys lists comparison expressions over elements a and b.
+	var compare string
+	var keys []string
+	switch {
+	case s.Name == "Position" && g.hasCompare(s.Name):
+		f.use("cmp")
+		compare = "Compare" + name
+		fmt.Fprintf(buf, "// %s orders positions by line, then by character.\n", compare)
+		fmt.Fprintf(buf, "func %s(a, b %s) int {\n", compare, name)
+		buf.WriteString("\treturn cmp.Or(cmp.Compare(a.Line, b.Line), cmp.Compare(a.Character, b.Character))\n")
+		buf.WriteString("}\n\n")
+	case s.Name == "Range" && g.hasCompare(s.Name):
+		f.use("cmp")
+		compare = "Compare" + name
+		position := "Compare" + g.typeName("Position")
+		fmt.Fprintf(buf, "// %s orders ranges by start, then by end.\n", compare)
+		fmt.Fprintf(buf, "func %s(a, b %s) int {\n", compare, name)
+		fmt.Fprintf(buf, "\treturn cmp.Or(%s(a.Start, b.Start), %s(a.End, b.End))\n", position, position)
+		buf.WriteString("}\n\n")
+	default:
+		keys = g.sortKeys(s)
+	}
+	if compare == "" && len(keys) == 0 {
+		return
+	}
+
+	f.use("slices")
+	if len(keys) > 1 {
+		f.use("cmp")
+	}
+	fmt.Fprintf(buf, "// Sort%s sorts s into a canonical order for comparisons in tests.\n", name)
+	fmt.Fprintf(buf, "func Sort%s(s []%s) {\n", name, name)
+	switch {
+	case compare != "":
+		fmt.Fprintf(buf, "\tslices.SortStableFunc(s, %s)\n", compare)
+	case len(keys) == 1:
+		fmt.Fprintf(buf, "\tslices.SortStableFunc(s, func(a, b %s) int {\n", name)
+		fmt.Fprintf(buf, "\t\treturn %s\n", keys[0])
+		buf.WriteString("\t})\n")
+	default:
+		fmt.Fprintf(buf, "\tslices.SortStableFunc(s, func(a, b %s) int {\n", name)
+		fmt.Fprintf(buf, "\t\treturn cmp.Or(\n\t\t\t%s,\n\t\t)\n", strings.Join(keys, ",\n\t\t\t"))
+		buf.WriteString("\t})\n")
+	}
+	buf.WriteString("}\n\n")
+}
+
+// sortKeys returns the comparison expressions ordering elements of s: the
+// first required Range or Position property with a generated Compare
+// function, then every required string or number property. It returns nil
+// if s has no such Range or Position property.
+func (g *Generator) sortKeys(s *model.Structure) []string {
+	var position string
+	var ties []string
+	for _, p := range s.Properties {
+		if p.Optional || p.Type == nil || !g.includeProperty(&p) {
+			continue
+		}
+		field := g.exportName(p.Name)
+		switch {
+		case p.Type.Kind == "reference" && (p.Type.Name == "Range" || p.Type.Name == "Position") && position == "" && g.hasCompare(p.Type.Name):
+			position = fmt.Sprintf("Compare%s(a.%s, b.%s)", g.typeName(p.Type.Name), field, field)
+		case p.Type.Kind == "base" && p.Type.Name != lspbase.TypeBoolean && g.goBaseType(p.Type) != "any" && !g.baseOverridden(p.Type):
+			ties = append(ties, fmt.Sprintf("cmp.Compare(a.%s, b.%s)", field, field))
+		}
+	}
+	if position == "" {
+		return nil
+	}
+	return append([]string{position}, ties...)
+}
+
+// hasCompare reports whether writeSortHelpers generates the Compare<Name>
+// function of the named structure, Position or Range: it must be generated,
+// not merged into another by DedupLiterals, and have the properties the
+// function compares. CompareRange also needs ComparePosition.
+func (g *Generator) hasCompare(name string) bool {
+	s, ok := g.structures[name]
+	if _, generated := g.types.m[name]; !ok || !generated {
+		return false
+	}
+	if _, merged := g.dedupAliases[name]; merged {
+		return false
+	}
+	switch name {
+	case "Position":
+		return isPosition(s)
+	case "Range":
+		return g.isRange(s) && g.hasCompare("Position")
+	}
+	return false
+}
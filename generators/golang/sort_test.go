@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: MIT
+
+package golang_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/lspls/generators/golang"
+)
+
+// sortRuntimeTest exercises the Sort functions generated for
+// testdata/sort_helpers.txtar.
+const sortRuntimeTest = `package protocol
+
+import (
+	"slices"
+	"testing"
+)
+
+func at(line, char uint32) Position { return Position{Line: line, Character: char} }
+
+func TestSortDiagnostic(t *testing.T) {
+	diags := []Diagnostic{
+		{Range: Range{Start: at(4, 0), End: at(4, 3)}, Message: "b"},
+		{Range: Range{Start: at(1, 5), End: at(1, 9)}, Message: "late column"},
+		{Range: Range{Start: at(4, 0), End: at(4, 3)}, Message: "a"},
+		{Range: Range{Start: at(1, 2), End: at(2, 0)}, Message: "early column"},
+		{Range: Range{Start: at(1, 2), End: at(1, 4)}, Message: "shorter"},
+	}
+	SortDiagnostic(diags)
+
+	var got []string
+	for _, d := range diags {
+		got = append(got, d.Message)
+	}
+	want := []string{"shorter", "early column", "late column", "a", "b"}
+	if !slices.Equal(got, want) {
+		t.Errorf("SortDiagnostic order = %q, want %q", got, want)
+	}
+
+	// Any permutation sorts to the same order.
+	reversed := slices.Clone(diags)
+	slices.Reverse(reversed)
+	SortDiagnostic(reversed)
+	if !slices.Equal(reversed, diags) {
+		t.Errorf("SortDiagnostic is not canonical: %v != %v", reversed, diags)
+	}
+}
+
+func TestComparePosition(t *testing.T) {
+	if ComparePosition(at(1, 9), at(2, 0)) >= 0 {
+		t.Error("line should order before character")
+	}
+	if CompareRange(Range{Start: at(1, 0), End: at(1, 1)}, Range{Start: at(1, 0), End: at(1, 1)}) != 0 {
+		t.Error("equal ranges should compare equal")
+	}
+}
+`
+
+func TestSortHelpersRuntime(t *testing.T) {
+	cfg := golang.DefaultConfig()
+	cfg.SortHelpers = true
+	runGenerated(t, "sort_helpers.txtar", cfg, sortRuntimeTest)
+}
+
+// sortDepthRuntimeTest checks that the output of testdata/sort_helpers.txtar
+// compiles with Diagnostic resolved one reference deep: Range is generated
+// but Position, beyond the depth, is not, so there is no ComparePosition
+// for CompareRange and SortDiagnostic to call, and Range.Start is any.
+const sortDepthRuntimeTest = `package protocol
+
+import "testing"
+
+func TestSortDepth(t *testing.T) {
+	d := Diagnostic{Message: "a"}
+	if d.Range.Start != nil {
+		t.Errorf("Range.Start = %v, want nil", d.Range.Start)
+	}
+}
+`
+
+func TestSortHelpersDepthRuntime(t *testing.T) {
+	cfg := golang.DefaultConfig()
+	cfg.SortHelpers = true
+	cfg.Types = []string{"Diagnostic"}
+	cfg.DepDepth = 1
+	runGenerated(t, "sort_helpers.txtar", cfg, sortDepthRuntimeTest)
+}
@@ -0,0 +1,37 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package golang
+
+import "github.com/albertocavalcante/lspls/generator"
+
+// Stats counts what the last call to Generate produced. Structures merged
+// by DedupLiterals count as type aliases, since that is what they become;
+// placeholders for unknown type kinds count only as UnknownKinds.
+func (g *Generator) Stats() generator.Stats {
+	var s generator.Stats
+	for _, name := range g.types.keys() {
+		if _, ok := g.unknownKinds[name]; ok {
+			continue
+		}
+		_, isStruct := g.structures[name]
+		_, merged := g.dedupAliases[name]
+		_, isEnum := g.enums[name]
+		switch {
+		case isStruct && !merged:
+			s.Structures++
+		case isEnum:
+			s.Enumerations++
+		default:
+			s.TypeAliases++
+		}
+	}
+	s.Unions = len(g.orTypes.keys())
+	s.Methods = len(g.methodConsts.keys()) - max(g.lifecycleConsts, 0)
+	s.AnyFallbacks = len(g.degraded)
+	s.UnknownKinds = len(g.unknownKinds)
+	return s
+}
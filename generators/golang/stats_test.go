@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: MIT
+
+package golang
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/albertocavalcante/lspls/generator"
+	"github.com/albertocavalcante/lspls/model"
+)
+
+func TestStats(t *testing.T) {
+	const input = `{
+  "metaData": {"version": "3.17.0"},
+  "requests": [
+    {
+      "method": "textDocument/hover",
+      "messageDirection": "clientToServer",
+      "params": {"kind": "reference", "name": "HoverParams"},
+      "result": {"kind": "reference", "name": "Hover"}
+    }
+  ],
+  "notifications": [
+    {"method": "exit", "messageDirection": "clientToServer"}
+  ],
+  "structures": [
+    {
+      "name": "HoverParams",
+      "properties": [
+        {"name": "position", "type": {"kind": "literal", "value": {"properties": []}}}
+      ]
+    },
+    {
+      "name": "Hover",
+      "properties": [
+        {"name": "contents", "type": {"kind": "or", "items": [
+          {"kind": "base", "name": "string"},
+          {"kind": "reference", "name": "MarkupKind"}
+        ]}},
+        {"name": "range", "type": {"kind": "tuple", "items": []}}
+      ]
+    }
+  ],
+  "enumerations": [
+    {
+      "name": "MarkupKind",
+      "type": {"kind": "base", "name": "string"},
+      "values": [{"name": "PlainText", "value": "plaintext"}]
+    }
+  ],
+  "typeAliases": [
+    {"name": "DocumentUri", "type": {"kind": "base", "name": "string"}}
+  ]
+}`
+	var m model.Model
+	if err := json.Unmarshal([]byte(input), &m); err != nil {
+		t.Fatal(err)
+	}
+	out, err := NewGenerator().Generate(context.Background(), &m, generator.Config{GenerateServer: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := generator.Stats{
+		Structures:   2,
+		Enumerations: 1,
+		TypeAliases:  1,
+		Unions:       1,
+		Methods:      2,
+		AnyFallbacks: 2, // the literal and the tuple
+	}
+	if out.Stats != want {
+		t.Errorf("Stats = %+v, want %+v", out.Stats, want)
+	}
+}
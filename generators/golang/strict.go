@@ -0,0 +1,124 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package golang
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/albertocavalcante/lspls/model"
+)
+
+// writeStrictUnmarshal writes an UnmarshalJSON method for structure s that
+// rejects input missing any required property.
+//
+// Embedded (extends/mixin) structures are decoded separately so that their
+// own UnmarshalJSON, which would otherwise be promoted and decode only the
+// embedded fields, checks their required properties. The structure's own
+// properties are decoded through a shadow struct of pointers into t.
+func (g *Generator) writeStrictUnmarshal(f *goFile, s *model.Structure) {
+	f.use("encoding/json")
+
+	name := g.typeName(s.Name)
+
+	// Optional properties of Tristate are decoded from present: through a
+	// pointer in own, a null would clear the pointer instead.
+	var required []string
+	var props, optionals []model.Property
+	for _, p := range s.Properties {
+		switch {
+		case !g.includeProperty(&p):
+			continue
+		case g.tristate(&p):
+			optionals = append(optionals, p)
+			continue
+		}
+		props = append(props, p)
+		if !p.Optional {
+			required = append(required, fmt.Sprintf("%q", p.Name))
+		}
+	}
+
+	buf := &f.body
+	fmt.Fprintf(buf, "// UnmarshalJSON decodes x into t and reports an error if a required\n")
+	fmt.Fprintf(buf, "// property is missing.\n")
+	fmt.Fprintf(buf, "func (t *%s) UnmarshalJSON(x []byte) error {\n", name)
+
+	if len(required) > 0 || len(optionals) > 0 {
+		buf.WriteString("\tvar present map[string]json.RawMessage\n")
+		buf.WriteString("\tif err := json.Unmarshal(x, &present); err != nil {\n")
+		buf.WriteString("\t\treturn err\n")
+		buf.WriteString("\t}\n")
+	}
+	if len(required) > 0 {
+		f.use("fmt")
+		fmt.Fprintf(buf, "\tfor _, name := range [...]string{%s} {\n", strings.Join(required, ", "))
+		buf.WriteString("\t\tif _, ok := present[name]; !ok {\n")
+		fmt.Fprintf(buf, "\t\t\treturn fmt.Errorf(\"%s: missing required property %%q\", name)\n", name)
+		buf.WriteString("\t\t}\n")
+		buf.WriteString("\t}\n")
+	}
+
+	for _, ext := range slices.Concat(s.Extends, s.Mixins) {
+		if ext.Kind != "reference" || g.omitted(ext.Name) {
+			continue
+		}
+		fmt.Fprintf(buf, "\tif err := json.Unmarshal(x, &t.%s); err != nil {\n", g.typeName(ext.Name))
+		buf.WriteString("\t\treturn err\n")
+		buf.WriteString("\t}\n")
+	}
+
+	if len(props) == 0 && len(optionals) == 0 {
+		buf.WriteString("\treturn json.Unmarshal(x, &struct{}{})\n")
+		buf.WriteString("}\n\n")
+		return
+	}
+	if len(props) == 0 {
+		g.writeOptionalsUnmarshal(buf, optionals)
+		buf.WriteString("}\n\n")
+		return
+	}
+
+	buf.WriteString("\town := struct {\n")
+	for _, p := range props {
+		typ, jsonTag := g.fieldType(&p)
+		fmt.Fprintf(buf, "\t\t%s *%s `json:\"%s\"`\n", g.exportName(p.Name), typ, jsonTag)
+	}
+	buf.WriteString("\t}{")
+	for i, p := range props {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		fmt.Fprintf(buf, "&t.%s", g.exportName(p.Name))
+	}
+	buf.WriteString("}\n")
+	if len(optionals) == 0 {
+		buf.WriteString("\treturn json.Unmarshal(x, &own)\n")
+		buf.WriteString("}\n\n")
+		return
+	}
+	buf.WriteString("\tif err := json.Unmarshal(x, &own); err != nil {\n")
+	buf.WriteString("\t\treturn err\n")
+	buf.WriteString("\t}\n")
+	g.writeOptionalsUnmarshal(buf, optionals)
+	buf.WriteString("}\n\n")
+}
+
+// writeOptionalsUnmarshal writes the end of a strict UnmarshalJSON, which
+// decodes the Optional fields of Tristate properties from present.
+func (g *Generator) writeOptionalsUnmarshal(buf *bytes.Buffer, optionals []model.Property) {
+	for _, p := range optionals {
+		fmt.Fprintf(buf, "\tif raw, ok := present[%q]; ok {\n", p.Name)
+		fmt.Fprintf(buf, "\t\tif err := t.%s.UnmarshalJSON(raw); err != nil {\n", g.exportName(p.Name))
+		buf.WriteString("\t\t\treturn err\n")
+		buf.WriteString("\t\t}\n")
+		buf.WriteString("\t}\n")
+	}
+	buf.WriteString("\treturn nil\n")
+}
@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: MIT
+
+package golang_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/lspls/generators/golang"
+)
+
+// strictRuntimeTest exercises the UnmarshalJSON methods generated for
+// testdata/strict_required.txtar.
+const strictRuntimeTest = `package protocol
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestStrictRequired(t *testing.T) {
+	var loc Location
+	if err := json.Unmarshal([]byte(` + "`" + `{"uri":"file:///a.go","line":1,"character":2,"label":"x"}` + "`" + `), &loc); err != nil {
+		t.Fatalf("complete input: %v", err)
+	}
+	want := Location{Position: Position{Line: 1, Character: 2}, Uri: "file:///a.go", Label: "x"}
+	if loc != want {
+		t.Errorf("decoded %+v, want %+v", loc, want)
+	}
+
+	tests := []struct {
+		input   string
+		missing string
+	}{
+		{` + "`" + `{"line":1,"character":2}` + "`" + `, "Location: missing required property \"uri\""},
+		{` + "`" + `{"uri":"file:///a.go","line":1}` + "`" + `, "Position: missing required property \"character\""},
+	}
+	for _, tt := range tests {
+		var loc Location
+		err := json.Unmarshal([]byte(tt.input), &loc)
+		if err == nil || !strings.Contains(err.Error(), tt.missing) {
+			t.Errorf("Unmarshal(%s) = %v, want error containing %q", tt.input, err, tt.missing)
+		}
+	}
+
+	var e Empty
+	if err := json.Unmarshal([]byte("[]"), &e); err == nil {
+		t.Error("Empty accepted a non-object")
+	}
+}
+`
+
+func TestStrictRequiredRuntime(t *testing.T) {
+	cfg := golang.DefaultConfig()
+	cfg.StrictRequired = true
+	runGenerated(t, "strict_required.txtar", cfg, strictRuntimeTest)
+}
+
+// strictOptionalRuntimeTest checks that the output of
+// testdata/strict_required_optional.txtar, which has no required
+// properties and so no use of fmt, compiles and decodes.
+const strictOptionalRuntimeTest = `package protocol
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestStrictOptional(t *testing.T) {
+	var h HoverOptions
+	if err := json.Unmarshal([]byte(` + "`" + `{"workDoneProgress":true,"label":"x"}` + "`" + `), &h); err != nil {
+		t.Fatal(err)
+	}
+	if h.WorkDoneProgress == nil || !*h.WorkDoneProgress || h.Label != "x" {
+		t.Errorf("decoded %+v", h)
+	}
+	if err := json.Unmarshal([]byte("{}"), &h); err != nil {
+		t.Errorf("empty input: %v", err)
+	}
+}
+`
+
+func TestStrictRequiredOptionalRuntime(t *testing.T) {
+	cfg := golang.DefaultConfig()
+	cfg.StrictRequired = true
+	runGenerated(t, "strict_required_optional.txtar", cfg, strictOptionalRuntimeTest)
+}
@@ -0,0 +1,81 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package golang
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/albertocavalcante/lspls/model"
+)
+
+// writeTextMethods writes MarshalText and UnmarshalText for the string
+// enumeration e, so that it satisfies encoding.TextMarshaler and
+// encoding.TextUnmarshaler wherever it is used as a map key. Unless e
+// supports custom values, UnmarshalText rejects values other than its
+// constants.
+func (g *Generator) writeTextMethods(f *goFile, e *model.Enumeration) {
+	name := g.typeName(e.Name)
+	buf := &f.body
+
+	fmt.Fprintf(buf, "// MarshalText implements encoding.TextMarshaler.\n")
+	fmt.Fprintf(buf, "func (x %s) MarshalText() ([]byte, error) {\n", name)
+	buf.WriteString("\treturn []byte(x), nil\n")
+	buf.WriteString("}\n\n")
+
+	if e.SupportsCustomValues {
+		fmt.Fprintf(buf, "// UnmarshalText implements encoding.TextUnmarshaler. %s supports\n", name)
+		buf.WriteString("// custom values, so any text is accepted.\n")
+		fmt.Fprintf(buf, "func (x *%s) UnmarshalText(text []byte) error {\n", name)
+		fmt.Fprintf(buf, "\t*x = %s(text)\n", name)
+		buf.WriteString("\treturn nil\n")
+		buf.WriteString("}\n\n")
+		return
+	}
+
+	// Constants with the same value would be duplicate cases.
+	var consts []string
+	seen := make(map[any]bool)
+	for _, v := range e.Values {
+		if seen[v.Value] {
+			continue
+		}
+		seen[v.Value] = true
+		consts = append(consts, name+g.exportName(v.Name))
+	}
+	f.use("fmt")
+	fmt.Fprintf(buf, "// UnmarshalText implements encoding.TextUnmarshaler. It reports an\n")
+	fmt.Fprintf(buf, "// error for text that is not one of the %s constants.\n", name)
+	fmt.Fprintf(buf, "func (x *%s) UnmarshalText(text []byte) error {\n", name)
+	fmt.Fprintf(buf, "\tswitch v := %s(text); v {\n", name)
+	if len(consts) > 0 {
+		fmt.Fprintf(buf, "\tcase %s:\n", strings.Join(consts, ", "))
+		buf.WriteString("\t\t*x = v\n")
+		buf.WriteString("\t\treturn nil\n")
+	}
+	buf.WriteString("\t}\n")
+	fmt.Fprintf(buf, "\treturn fmt.Errorf(\"invalid %s %%q\", text)\n", name)
+	buf.WriteString("}\n\n")
+}
+
+// writeFlagValueMethods writes String and Set for the string enumeration
+// e, so that it satisfies flag.Value and can be passed to flag.Var. Set
+// accepts the same text as UnmarshalText.
+func (g *Generator) writeFlagValueMethods(f *goFile, e *model.Enumeration) {
+	name := g.typeName(e.Name)
+	buf := &f.body
+
+	buf.WriteString("// String implements fmt.Stringer and flag.Value.\n")
+	fmt.Fprintf(buf, "func (x %s) String() string {\n", name)
+	buf.WriteString("\treturn string(x)\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// Set implements flag.Value, parsing s like UnmarshalText.\n")
+	fmt.Fprintf(buf, "func (x *%s) Set(s string) error {\n", name)
+	buf.WriteString("\treturn x.UnmarshalText([]byte(s))\n")
+	buf.WriteString("}\n\n")
+}
@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: MIT
+
+package golang_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/lspls/generators/golang"
+)
+
+// enumTextRuntimeTest round-trips maps keyed by the string enumerations
+// generated for testdata/enum_text.txtar.
+const enumTextRuntimeTest = `package protocol
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestEnumMapKeys(t *testing.T) {
+	in := map[MarkupKind]int{MarkupKindPlainText: 1, MarkupKindMarkdown: 2}
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := ` + "`" + `{"markdown":2,"plaintext":1}` + "`" + `; string(data) != want {
+		t.Errorf("Marshal = %s, want %s", data, want)
+	}
+	var out map[MarkupKind]int
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(out, in) {
+		t.Errorf("round trip = %v, want %v", out, in)
+	}
+
+	if err := json.Unmarshal([]byte(` + "`" + `{"html":1}` + "`" + `), &out); err == nil {
+		t.Error("Unmarshal accepted an unknown MarkupKind key")
+	}
+	var kind MarkupKind
+	if err := json.Unmarshal([]byte(` + "`" + `"html"` + "`" + `), &kind); err == nil {
+		t.Error("Unmarshal accepted an unknown MarkupKind value")
+	}
+
+	var custom map[CodeActionKind]bool
+	if err := json.Unmarshal([]byte(` + "`" + `{"source.custom":true}` + "`" + `), &custom); err != nil {
+		t.Fatal(err)
+	}
+	if !custom["source.custom"] {
+		t.Errorf("custom = %v, want source.custom", custom)
+	}
+}
+`
+
+func TestEnumTextRuntime(t *testing.T) {
+	runGenerated(t, "enum_text.txtar", golang.DefaultConfig(), enumTextRuntimeTest)
+}
+
+// flagValueRuntimeTest sets the string enumerations generated for
+// testdata/flag_value_enums.txtar from command-line flags.
+const flagValueRuntimeTest = `package protocol
+
+import (
+	"flag"
+	"io"
+	"testing"
+)
+
+func TestFlagValue(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	markup := MarkupKindPlainText
+	var action CodeActionKind
+	fs.Var(&markup, "markup", "markup kind")
+	fs.Var(&action, "action", "code action kind")
+	if got := fs.Lookup("markup").DefValue; got != "plaintext" {
+		t.Errorf("markup default = %q, want plaintext", got)
+	}
+
+	if err := fs.Parse([]string{"-markup", "markdown", "-action", "source.custom"}); err != nil {
+		t.Fatal(err)
+	}
+	if markup != MarkupKindMarkdown {
+		t.Errorf("markup = %q, want %q", markup, MarkupKindMarkdown)
+	}
+	if action != "source.custom" {
+		t.Errorf("action = %q, want source.custom", action)
+	}
+
+	if err := fs.Parse([]string{"-markup", "html"}); err == nil {
+		t.Error("Parse accepted an unknown MarkupKind")
+	}
+}
+`
+
+func TestFlagValueEnumsRuntime(t *testing.T) {
+	cfg := golang.DefaultConfig()
+	cfg.FlagValueEnums = true
+	runGenerated(t, "flag_value_enums.txtar", cfg, flagValueRuntimeTest)
+}
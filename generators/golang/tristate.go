@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package golang
+
+import (
+	"strings"
+
+	"github.com/albertocavalcante/lspls/model"
+)
+
+// optionalCode is the Optional type of Tristate properties. It refers to
+// itself as Optional, which is replaced by the configured Go name.
+const optionalCode = `// Optional is a property that may be absent, present and null, or present
+// with a value. The zero Optional is absent, and omitzero leaves it out of
+// the JSON encoding.
+type Optional[T any] struct {
+	// Value is the value of a property that is set and not null.
+	Value T
+
+	// Set reports whether the property is present, null or not.
+	Set bool
+
+	// Null reports whether the property is present and null.
+	Null bool
+}
+
+// IsZero reports whether o is absent.
+func (o Optional[T]) IsZero() bool {
+	return !o.Set
+}
+
+// MarshalJSON returns null for an absent or null o, and the encoding of
+// its value otherwise.
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	if !o.Set || o.Null {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.Value)
+}
+
+// UnmarshalJSON sets o to null for the JSON null, and to the decoded value
+// otherwise.
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*o = Optional[T]{Set: true, Null: true}
+		return nil
+	}
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*o = Optional[T]{Value: v, Set: true}
+	return nil
+}
+
+`
+
+// tristate reports whether p is generated as an Optional: Tristate is set
+// and p is both optional and nullable.
+func (g *Generator) tristate(p *model.Property) bool {
+	return g.config.Tristate && p.Optional && p.Type != nil && p.Type.IsOptional()
+}
+
+// fieldType returns the Go type and JSON tag of the field for p.
+func (g *Generator) fieldType(p *model.Property) (typ, tag string) {
+	switch {
+	case g.tristate(p):
+		g.usesOptional = true
+		return g.typeName("Optional") + "[" + g.goType(p.Type.NonNullType(), false) + "]", p.Name + ",omitzero"
+	case p.Optional:
+		return g.goType(p.Type, true), p.Name + ",omitempty"
+	}
+	return g.goType(p.Type, false), p.Name
+}
+
+// writeOptional writes the Optional type to f if a field uses it.
+func (g *Generator) writeOptional(f *goFile) {
+	if !g.usesOptional {
+		return
+	}
+	f.use("encoding/json")
+	f.body.WriteString(strings.ReplaceAll(optionalCode, "Optional", g.typeName("Optional")))
+}
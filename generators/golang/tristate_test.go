@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: MIT
+
+package golang_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/lspls/generators/golang"
+)
+
+// tristateRuntimeTest checks that the Optional fields generated for
+// testdata/tristate.txtar tell an absent property from a null one.
+const tristateRuntimeTest = `package protocol
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestOptionalStates(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		limit Optional[int32]
+		json  string
+	}{
+		{"absent", Optional[int32]{}, ` + "`" + `{"name":"a","version":null}` + "`" + `},
+		{"null", Optional[int32]{Set: true, Null: true}, ` + "`" + `{"name":"a","limit":null,"version":null}` + "`" + `},
+		{"value", Optional[int32]{Value: 4, Set: true}, ` + "`" + `{"name":"a","limit":4,"version":null}` + "`" + `},
+		{"zero value", Optional[int32]{Set: true}, ` + "`" + `{"name":"a","limit":0,"version":null}` + "`" + `},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			in := Settings{Name: "a", Limit: tc.limit}
+			data, err := json.Marshal(in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != tc.json {
+				t.Errorf("Marshal = %s, want %s", data, tc.json)
+			}
+			var got Settings
+			if err := json.Unmarshal(data, &got); err != nil {
+				t.Fatal(err)
+			}
+			if got.Limit != tc.limit || !got.Equal(&in) {
+				t.Errorf("Unmarshal(%s).Limit = %+v, want %+v", data, got.Limit, tc.limit)
+			}
+		})
+	}
+
+	var s Settings
+	if err := json.Unmarshal([]byte(` + "`" + `{"name":"a","version":1,"limit":"x"}` + "`" + `), &s); err == nil {
+		t.Error("string limit decoded without error")
+	}
+}
+`
+
+func TestTristateRuntime(t *testing.T) {
+	for _, strict := range []bool{false, true} {
+		cfg := golang.DefaultConfig()
+		cfg.Tristate = true
+		cfg.GenerateEqual = true
+		cfg.StrictRequired = strict
+		runGenerated(t, "tristate.txtar", cfg, tristateRuntimeTest)
+	}
+}
@@ -5,8 +5,10 @@ package golang
 import (
 	"bytes"
 	"cmp"
+	"encoding/json"
 	"fmt"
 	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/albertocavalcante/lspls/internal/lspbase"
@@ -17,60 +19,51 @@ func (g *Generator) generateStructure(s *model.Structure) {
 	var buf bytes.Buffer
 
 	// Doc comment
-	if s.Documentation != "" {
-		writeDocComment(&buf, s.Documentation)
-	}
-	// Add @since only if not already in documentation (check for version pattern)
-	if s.Since != "" && !strings.Contains(s.Documentation, "@since "+s.Since) {
-		fmt.Fprintf(&buf, "//\n// @since %s\n", s.Since)
-	}
+	doc := g.docs(s.Documentation)
+	writeDocComment(&buf, doc)
+	writeSince(&buf, doc, s.Since)
 
 	// Type declaration
-	fmt.Fprintf(&buf, "type %s struct {\n", exportName(s.Name))
-
-	// Embedded types (extends)
-	for _, ext := range s.Extends {
-		if ext.Kind == "reference" {
-			fmt.Fprintf(&buf, "\t%s\n", exportName(ext.Name))
-		}
-	}
-
-	// Mixins
-	for _, mix := range s.Mixins {
-		if mix.Kind == "reference" {
-			fmt.Fprintf(&buf, "\t%s\n", exportName(mix.Name))
+	fmt.Fprintf(&buf, "type %s struct {\n", g.typeName(s.Name))
+
+	// Embedded types (extends, then mixins)
+	for _, ext := range slices.Concat(s.Extends, s.Mixins) {
+		switch {
+		case ext.Kind != "reference":
+		case g.beyondDepth(ext.Name):
+			fmt.Fprintf(&buf, "\t// Omitted %s, beyond the dependency depth.\n", g.typeName(ext.Name))
+		case g.internal(ext.Name):
+			fmt.Fprintf(&buf, "\t// Omitted internal %s.\n", g.typeName(ext.Name))
+		case g.omitted(ext.Name):
+			fmt.Fprintf(&buf, "\t// Omitted deprecated %s.\n", g.typeName(ext.Name))
+		default:
+			fmt.Fprintf(&buf, "\t%s\n", g.typeName(ext.Name))
 		}
 	}
 
 	// Properties
 	for _, p := range s.Properties {
-		// Skip proposed properties when not including proposed types
-		if p.Proposed && !g.config.IncludeProposed {
+		if !g.includeProperty(&p) {
 			continue
 		}
-		g.generateProperty(&buf, &p)
+		g.generateProperty(&buf, &p, s.Since)
 	}
 
 	buf.WriteString("}\n\n")
 	g.types.set(s.Name, buf.String())
 }
 
-func (g *Generator) generateProperty(buf *bytes.Buffer, p *model.Property) {
+// generateProperty writes the field for p. parentSince is the enclosing
+// structure's version; a property added later gets its own @since line.
+func (g *Generator) generateProperty(buf *bytes.Buffer, p *model.Property, parentSince string) {
 	// Doc comment for property
-	if p.Documentation != "" {
-		for line := range strings.SplitSeq(p.Documentation, "\n") {
-			fmt.Fprintf(buf, "\t// %s\n", line)
-		}
-	}
+	doc := g.docs(p.Documentation)
+	writeMemberDoc(buf, doc, lspbase.MemberSince(p.Since, parentSince, doc))
+	g.writeOmittedNote(buf, "\t", g.omittedRefs(p.Type))
 
 	// Field declaration
-	goName := exportName(p.Name)
-	goType := g.goType(p.Type, p.Optional)
-
-	jsonTag := p.Name
-	if p.Optional {
-		jsonTag += ",omitempty"
-	}
+	goName := g.exportName(p.Name)
+	goType, jsonTag := g.fieldType(p)
 
 	fmt.Fprintf(buf, "\t%s %s `json:\"%s\"`\n", goName, goType, jsonTag)
 }
@@ -78,55 +71,120 @@ func (g *Generator) generateProperty(buf *bytes.Buffer, p *model.Property) {
 func (g *Generator) generateEnumeration(e *model.Enumeration) {
 	// Generate type
 	var typeBuf bytes.Buffer
-	if e.Documentation != "" {
-		writeDocComment(&typeBuf, e.Documentation)
-	}
-	// Add @since only if not already in documentation (check for version pattern)
-	if e.Since != "" && !strings.Contains(e.Documentation, "@since "+e.Since) {
-		fmt.Fprintf(&typeBuf, "//\n// @since %s\n", e.Since)
+	doc := g.docs(e.Documentation)
+	writeDocComment(&typeBuf, doc)
+	writeSince(&typeBuf, doc, e.Since)
+
+	// Enumerations keep the default base types, whose constants their
+	// values are.
+	baseType := g.defaultBaseType(e.Type)
+	fmt.Fprintf(&typeBuf, "type %s %s\n\n", g.typeName(e.Name), baseType)
+
+	// Contiguous integer values become an iota block of their own, kept
+	// next to the type since iota restarts with every const block.
+	if g.config.IotaEnums {
+		if values, offset, ok := iotaValues(e.Values, baseType); ok {
+			g.writeIotaConsts(&typeBuf, e, values, offset)
+			g.types.set(e.Name, typeBuf.String())
+			return
+		}
 	}
-
-	baseType := g.goBaseType(e.Type)
-	fmt.Fprintf(&typeBuf, "type %s %s\n\n", exportName(e.Name), baseType)
 	g.types.set(e.Name, typeBuf.String())
 
 	// Generate constants
 	for _, v := range e.Values {
 		var constBuf bytes.Buffer
-		if v.Documentation != "" {
-			writeDocComment(&constBuf, v.Documentation)
-		}
+		doc := g.docs(v.Documentation)
+		writeDocComment(&constBuf, doc)
+		writeSince(&constBuf, doc, lspbase.MemberSince(v.Since, e.Since, doc))
 
-		constName := exportName(e.Name) + exportName(v.Name)
+		constName := g.typeName(e.Name) + g.exportName(v.Name)
 		constValue := formatConstValue(v.Value, baseType)
-		fmt.Fprintf(&constBuf, "%s %s = %s\n", constName, exportName(e.Name), constValue)
+		fmt.Fprintf(&constBuf, "%s %s = %s\n", constName, g.typeName(e.Name), constValue)
 
 		g.consts.set(constName, constBuf.String())
 	}
 }
 
-func (g *Generator) generateTypeAlias(a *model.TypeAlias) {
-	var buf bytes.Buffer
+// writeIotaConsts writes the constants of enumeration e as an iota const
+// block. values must be sorted and contiguous, starting at offset.
+func (g *Generator) writeIotaConsts(buf *bytes.Buffer, e *model.Enumeration, values []model.EnumValue, offset int64) {
+	typeName := g.typeName(e.Name)
+	buf.WriteString("const (\n")
+	for i, v := range values {
+		doc := g.docs(v.Documentation)
+		writeMemberDoc(buf, doc, lspbase.MemberSince(v.Since, e.Since, doc))
+		constName := typeName + g.exportName(v.Name)
+		switch {
+		case i > 0:
+			fmt.Fprintf(buf, "\t%s\n", constName)
+		case offset > 0:
+			fmt.Fprintf(buf, "\t%s %s = iota + %d\n", constName, typeName, offset)
+		case offset < 0:
+			fmt.Fprintf(buf, "\t%s %s = iota - %d\n", constName, typeName, -offset)
+		default:
+			fmt.Fprintf(buf, "\t%s %s = iota\n", constName, typeName)
+		}
+	}
+	buf.WriteString(")\n\n")
+}
 
-	if a.Documentation != "" {
-		writeDocComment(&buf, a.Documentation)
+// iotaValues reports whether an enumeration with the given values and Go
+// base type can be written as an iota block: it has at least two integer
+// values that are distinct and contiguous. It returns the values sorted by
+// value and the first value.
+func iotaValues(values []model.EnumValue, baseType string) ([]model.EnumValue, int64, bool) {
+	if baseType == "string" || len(values) < 2 {
+		return nil, 0, false
+	}
+	sorted := slices.Clone(values)
+	for _, v := range sorted {
+		if f, ok := v.Value.(float64); !ok || f != float64(int64(f)) {
+			return nil, 0, false
+		}
 	}
-	// Add @since only if not already in documentation (check for version pattern)
-	if a.Since != "" && !strings.Contains(a.Documentation, "@since "+a.Since) {
-		fmt.Fprintf(&buf, "//\n// @since %s\n", a.Since)
+	slices.SortStableFunc(sorted, func(a, b model.EnumValue) int {
+		return cmp.Compare(a.Value.(float64), b.Value.(float64))
+	})
+	offset := int64(sorted[0].Value.(float64))
+	for i, v := range sorted {
+		if int64(v.Value.(float64)) != offset+int64(i) {
+			return nil, 0, false
+		}
 	}
+	return sorted, offset, true
+}
+
+func (g *Generator) generateTypeAlias(a *model.TypeAlias) {
+	var buf bytes.Buffer
+
+	doc := g.docs(a.Documentation)
+	writeDocComment(&buf, doc)
+	writeSince(&buf, doc, a.Since)
 	if a.Deprecated != "" {
-		fmt.Fprintf(&buf, "//\n// Deprecated: %s\n", a.Deprecated)
+		if doc != "" || a.Since != "" {
+			buf.WriteString("//\n")
+		}
+		fmt.Fprintf(&buf, "// Deprecated: %s\n", a.Deprecated)
+	}
+	g.writeOmittedNote(&buf, "", g.omittedRefs(a.Type))
+
+	if ov, ok := g.override(a); ok {
+		buf.WriteString(strings.ReplaceAll(ov.code, a.Name, g.typeName(a.Name)))
+		g.types.set(a.Name, buf.String())
+		return
 	}
 
 	goType := g.goType(a.Type, false)
-	fmt.Fprintf(&buf, "type %s = %s\n\n", exportName(a.Name), goType)
+	fmt.Fprintf(&buf, "type %s = %s\n\n", g.typeName(a.Name), goType)
 
 	g.types.set(a.Name, buf.String())
 }
 
 // goType converts an LSP type to its Go equivalent.
-func (g *Generator) goType(t *model.Type, _ bool) string {
+// When optional is true, boolean and numeric base types become pointers so
+// that an absent value can be distinguished from the zero value.
+func (g *Generator) goType(t *model.Type, optional bool) string {
 	if t == nil {
 		return "any"
 	}
@@ -139,10 +197,25 @@ func (g *Generator) goType(t *model.Type, _ bool) string {
 
 	switch t.Kind {
 	case "base":
+		if optional && isPointerScalar(t.Name) {
+			return "*" + g.goBaseType(t)
+		}
 		return g.goBaseType(t)
 
 	case "reference":
-		return exportName(t.Name)
+		if g.omitted(t.Name) {
+			switch {
+			case g.beyondDepth(t.Name):
+				g.log.Warn("reference beyond dependency depth degraded to any", "name", t.Name, "line", t.Line)
+			case g.internal(t.Name):
+				g.log.Warn("reference to internal type degraded to any", "name", t.Name, "line", t.Line)
+			default:
+				g.log.Warn("reference to deprecated type degraded to any", "name", t.Name, "line", t.Line)
+			}
+			g.degraded[t] = true
+			return "any"
+		}
+		return g.typeName(t.Name)
 
 	case "array":
 		return "[]" + g.goType(t.Element, false)
@@ -158,29 +231,66 @@ func (g *Generator) goType(t *model.Type, _ bool) string {
 	case "literal":
 		// Anonymous struct - for now, use any
 		// TODO: Generate named type
+		g.log.Warn("literal type degraded to any", "line", t.Line)
+		g.degraded[t] = true
 		return "any"
 
 	case "stringLiteral":
 		return "string"
 
+	case "integerLiteral":
+		return "int32"
+
+	case "booleanLiteral":
+		return "bool"
+
 	case "or":
 		// Union type - generate Or_* type with JSON marshaling
 		return g.getOrType(t)
 
 	case "and":
 		// Intersection - use embedded structs
+		g.log.Warn("intersection type degraded to any", "line", t.Line)
+		g.degraded[t] = true
 		return "any"
 
 	case "tuple":
 		// Tuple - use slice for now
+		g.log.Warn("tuple type degraded to []any", "line", t.Line)
+		g.degraded[t] = true
 		return "[]any"
 
 	default:
+		if g.config.UnknownAsInterface {
+			return g.unknownKindType(t)
+		}
+		g.log.Warn("unknown type kind degraded to any", "kind", t.Kind, "line", t.Line)
+		g.degraded[t] = true
 		return "any"
 	}
 }
 
+// goBaseType returns the Go type of the base type t: its entry in
+// TypeOverrides, if any, or the default of defaultBaseType.
 func (g *Generator) goBaseType(t *model.Type) string {
+	if t != nil {
+		if ov, ok := g.config.TypeOverrides[t.Name]; ok {
+			return qualifiedType(ov)
+		}
+	}
+	return g.defaultBaseType(t)
+}
+
+// baseOverridden reports whether the base type t is generated as a type
+// from TypeOverrides, about which nothing is known beyond its name.
+func (g *Generator) baseOverridden(t *model.Type) bool {
+	_, ok := g.config.TypeOverrides[t.Name]
+	return ok
+}
+
+// defaultBaseType returns the Go type of the base type t without
+// TypeOverrides.
+func (g *Generator) defaultBaseType(t *model.Type) string {
 	if t == nil {
 		return "any"
 	}
@@ -198,10 +308,19 @@ func (g *Generator) goBaseType(t *model.Type) string {
 	case lspbase.TypeNull, lspbase.TypeLSPAny:
 		return "any"
 	default:
+		g.log.Warn("unknown base type degraded to any", "name", t.Name, "line", t.Line)
+		g.degraded[t] = true
 		return "any"
 	}
 }
 
+// isPointerScalar reports whether an optional property of the given base type
+// needs a pointer. Strings are excluded: the empty string is not a meaningful
+// value for any optional string property in the protocol.
+func isPointerScalar(name string) bool {
+	return name == lspbase.TypeBoolean || lspbase.IsNumeric(name)
+}
+
 // typeNameForIdent returns a Go-identifier-safe name for a type.
 // This is used when building Or_* type names where []Location or map[K]V
 // would be invalid in an identifier.
@@ -212,10 +331,11 @@ func (g *Generator) typeNameForIdent(t *model.Type) string {
 
 	switch t.Kind {
 	case "base":
-		// Base types are already safe identifiers (int32, string, etc.)
-		return g.goBaseType(t)
+		// Base types are already safe identifiers (int32, string, etc.).
+		// Overrides, which may not be, leave union names unchanged.
+		return g.defaultBaseType(t)
 	case "reference":
-		return exportName(t.Name)
+		return g.exportName(t.Name)
 	case "array":
 		return "Arr" + g.typeNameForIdent(t.Element)
 	case "map":
@@ -229,6 +349,10 @@ func (g *Generator) typeNameForIdent(t *model.Type) string {
 		return "Literal"
 	case "stringLiteral":
 		return "string"
+	case "integerLiteral":
+		return "int32"
+	case "booleanLiteral":
+		return "bool"
 	case "or":
 		// Nested unions are rare, but handle them
 		return "Union"
@@ -248,19 +372,7 @@ func (g *Generator) getOrType(t *model.Type) string {
 		return "any"
 	}
 
-	// Filter out null items (already handled by IsOptional) and
-	// proposed types when IncludeProposed is false
-	var nonNullItems []*model.Type
-	for _, item := range t.Items {
-		if item.Kind == "base" && item.Name == "null" {
-			continue
-		}
-		// Skip proposed reference types when not including proposed features
-		if !g.config.IncludeProposed && item.Kind == "reference" && g.isProposed(item.Name) {
-			continue
-		}
-		nonNullItems = append(nonNullItems, item)
-	}
+	nonNullItems := g.orMembers(t)
 
 	// If only one non-null item, just use that type directly
 	if len(nonNullItems) == 1 {
@@ -276,12 +388,14 @@ func (g *Generator) getOrType(t *model.Type) string {
 	type namePair struct {
 		identName string
 		goType    string
+		item      *model.Type
 	}
 	var pairs []namePair
 	for _, item := range nonNullItems {
 		pairs = append(pairs, namePair{
 			identName: g.typeNameForIdent(item),
 			goType:    g.goType(item, false),
+			item:      item,
 		})
 	}
 
@@ -293,35 +407,50 @@ func (g *Generator) getOrType(t *model.Type) string {
 	// Extract sorted names
 	var identNames []string
 	var itemNames []string
+	var items []*model.Type
 	for _, p := range pairs {
 		identNames = append(identNames, p.identName)
 		itemNames = append(itemNames, p.goType)
+		items = append(items, p.item)
 	}
 
 	// Generate the type name: Or_Type1_Type2_... (using identifier-safe names)
-	typeName := "Or_" + strings.Join(identNames, "_")
+	typeName := g.config.TypePrefix + "Or_" + strings.Join(identNames, "_") + g.config.TypeSuffix
 
 	// Check if we've already registered this type
 	if _, exists := g.orTypes.m[typeName]; !exists {
 		g.orTypes.set(typeName, orTypeInfo{
-			name:      typeName,
-			itemNames: itemNames,
+			name:       typeName,
+			itemNames:  itemNames,
+			identNames: identNames,
+			items:      items,
 		})
 	}
 
 	return typeName
 }
 
-// generateOrTypes generates all registered Or_* union types and their JSON methods.
-func (g *Generator) generateOrTypes() string {
-	var buf bytes.Buffer
-
-	for _, name := range g.orTypes.keys() {
-		info := g.orTypes.get(name)
-		g.generateOrType(&buf, info)
+// orMembers returns the members of an "or" type that appear in the
+// generated union: null (already handled by IsOptional) is dropped, as are
+// proposed references when IncludeProposed is false and deprecated ones
+// under OmitDeprecated, internal ones under OmitInternal, and ones beyond
+// DepDepth.
+func (g *Generator) orMembers(t *model.Type) []*model.Type {
+	var members []*model.Type
+	for _, item := range t.Items {
+		if item.Kind == "base" && item.Name == "null" {
+			continue
+		}
+		// Skip proposed reference types when not including proposed features
+		if !g.config.IncludeProposed && item.Kind == "reference" && g.isProposed(item.Name) {
+			continue
+		}
+		if item.Kind == "reference" && g.omitted(item.Name) {
+			continue
+		}
+		members = append(members, item)
 	}
-
-	return buf.String()
+	return members
 }
 
 // generateOrType generates a single Or_* union type with its MarshalJSON and UnmarshalJSON methods.
@@ -332,6 +461,21 @@ func (g *Generator) generateOrType(buf *bytes.Buffer, info orTypeInfo) {
 	fmt.Fprintf(buf, "\tValue any `json:\"value\"`\n")
 	buf.WriteString("}\n\n")
 
+	// Typed constructors, one per member, so callers cannot store an
+	// unrelated type in Value.
+	seen := make(map[string]bool)
+	for i, name := range info.itemNames {
+		ctor := orConstructorName(info.name, info.identNames[i])
+		if seen[ctor] {
+			continue
+		}
+		seen[ctor] = true
+		fmt.Fprintf(buf, "// %s returns an %s holding a %s.\n", ctor, info.name, name)
+		fmt.Fprintf(buf, "func %s(v %s) %s {\n", ctor, name, info.name)
+		fmt.Fprintf(buf, "\treturn %s{Value: v}\n", info.name)
+		buf.WriteString("}\n\n")
+	}
+
 	// MarshalJSON method
 	fmt.Fprintf(buf, "func (t %s) MarshalJSON() ([]byte, error) {\n", info.name)
 	buf.WriteString("\tswitch x := t.Value.(type) {\n")
@@ -351,7 +495,38 @@ func (g *Generator) generateOrType(buf *bytes.Buffer, info orTypeInfo) {
 	buf.WriteString("\t\tt.Value = nil\n")
 	buf.WriteString("\t\treturn nil\n")
 	buf.WriteString("\t}\n")
+
+	// Members with a literal-valued property are selected by its value;
+	// trial decoding would accept the first of them for any object.
+	discriminated := make(map[int]bool)
+	for i, item := range info.items {
+		prop, ok := g.discriminator(item)
+		if !ok {
+			continue
+		}
+		if len(discriminated) == 0 {
+			buf.WriteString("\tvar fields map[string]json.RawMessage\n")
+			buf.WriteString("\t_ = json.Unmarshal(x, &fields) // stays nil unless x is an object\n")
+		}
+		discriminated[i] = true
+		// Decode the field and compare values, so equivalent encodings
+		// such as escaped strings still select the member.
+		goType, literal := discriminatorValue(prop.Type)
+		fmt.Fprintf(buf, "\tvar d%d %s\n", i, goType)
+		fmt.Fprintf(buf, "\tif json.Unmarshal(fields[%q], &d%d) == nil && d%d == %s {\n", prop.Name, i, i, literal)
+		fmt.Fprintf(buf, "\t\tvar h%d %s\n", i, info.itemNames[i])
+		fmt.Fprintf(buf, "\t\tif err := json.Unmarshal(x, &h%d); err != nil {\n", i)
+		buf.WriteString("\t\t\treturn err\n")
+		buf.WriteString("\t\t}\n")
+		fmt.Fprintf(buf, "\t\tt.Value = h%d\n", i)
+		buf.WriteString("\t\treturn nil\n")
+		buf.WriteString("\t}\n")
+	}
+
 	for i, name := range info.itemNames {
+		if discriminated[i] {
+			continue
+		}
 		fmt.Fprintf(buf, "\tvar h%d %s\n", i, name)
 		fmt.Fprintf(buf, "\tif err := json.Unmarshal(x, &h%d); err == nil {\n", i)
 		fmt.Fprintf(buf, "\t\tt.Value = h%d\n", i)
@@ -362,13 +537,88 @@ func (g *Generator) generateOrType(buf *bytes.Buffer, info orTypeInfo) {
 	buf.WriteString("}\n\n")
 }
 
-func exportName(name string) string {
-	return lspbase.ExportName(name)
+// discriminator returns the literal-valued property that identifies item
+// within a union, if item references a structure that has one.
+func (g *Generator) discriminator(item *model.Type) (model.Property, bool) {
+	if item.Kind != "reference" {
+		return model.Property{}, false
+	}
+	s, ok := g.structures[item.Name]
+	if !ok {
+		return model.Property{}, false
+	}
+	return s.Discriminator()
+}
+
+// discriminatorValue returns the Go type a literal-valued property decodes
+// into and its value as a Go literal.
+func discriminatorValue(t *model.Type) (goType, literal string) {
+	switch t.Kind {
+	case "stringLiteral":
+		s, _ := t.Value.(string)
+		return "string", strconv.Quote(s)
+	case "integerLiteral":
+		n, _ := json.Marshal(t.Value)
+		return "int", string(n)
+	default:
+		return "bool", fmt.Sprint(t.Value == true)
+	}
+}
+
+// orConstructorName returns the constructor name for one member of an Or_*
+// type (e.g., "NewOr_Location_string_FromLocation").
+func orConstructorName(orName, identName string) string {
+	return "New" + orName + "_From" + lspbase.Capitalize(identName)
+}
+
+// exportName returns the Go identifier of an LSP name, cased by the
+// configured acronyms and overrides.
+func (g *Generator) exportName(name string) string {
+	return g.casing.ExportName(name)
 }
 
+// typeName returns the Go name of the named LSP type, with TypePrefix and
+// TypeSuffix applied.
+func (g *Generator) typeName(name string) string {
+	return g.config.TypePrefix + g.exportName(name) + g.config.TypeSuffix
+}
+
+// methodConst returns the name of the Method* constant of the method
+// whose Go name is name, with TypePrefix and TypeSuffix applied.
+func (g *Generator) methodConst(name string) string {
+	return g.config.TypePrefix + "Method" + name + g.config.TypeSuffix
+}
+
+// writeDocComment writes doc as a Go line comment. Empty docs write nothing.
 func writeDocComment(buf *bytes.Buffer, doc string) {
-	for line := range strings.SplitSeq(doc, "\n") {
-		fmt.Fprintf(buf, "// %s\n", line)
+	if doc == "" {
+		return
+	}
+	lspbase.WriteComment(buf, "//", doc)
+}
+
+// writeSince adds an @since line unless doc already mentions that version.
+func writeSince(buf *bytes.Buffer, doc, since string) {
+	if since == "" || strings.Contains(doc, "@since "+since) {
+		return
+	}
+	if doc != "" {
+		buf.WriteString("//\n")
+	}
+	fmt.Fprintf(buf, "// @since %s\n", since)
+}
+
+// writeMemberDoc writes the tab-indented doc comment of a struct field or
+// const block member, followed by an @since line when since is set.
+func writeMemberDoc(buf *bytes.Buffer, doc, since string) {
+	if doc != "" {
+		lspbase.WriteComment(buf, "\t//", doc)
+	}
+	if since != "" {
+		if doc != "" {
+			buf.WriteString("\t//\n")
+		}
+		fmt.Fprintf(buf, "\t// @since %s\n", since)
 	}
 }
 
@@ -385,3 +635,58 @@ func formatConstValue(v any, baseType string) string {
 		return fmt.Sprintf("%v", v)
 	}
 }
+
+// omittedRefs returns the names of omitted types t refers to.
+func (g *Generator) omittedRefs(t *model.Type) []string {
+	if t == nil || !g.config.OmitDeprecated && !g.config.OmitInternal && !g.depthLimited {
+		return nil
+	}
+	var names []string
+	switch t.Kind {
+	case "reference":
+		if g.omitted(t.Name) {
+			names = append(names, t.Name)
+		}
+	case "array":
+		names = g.omittedRefs(t.Element)
+	case "map":
+		names = g.omittedRefs(t.Key)
+		if vt, ok := t.Value.(*model.Type); ok {
+			names = append(names, g.omittedRefs(vt)...)
+		}
+	case "or":
+		// Omitted members are dropped from unions rather than typed as any.
+		for _, item := range t.Items {
+			if item.Kind != "reference" {
+				names = append(names, g.omittedRefs(item)...)
+			}
+		}
+	}
+	return names
+}
+
+// writeOmittedNote writes comment lines, indented by indent, noting that
+// the omitted types names were replaced by any: one each for deprecated
+// types, internal types, and types beyond DepDepth.
+func (g *Generator) writeOmittedNote(buf *bytes.Buffer, indent string, names []string) {
+	var deprecated, internal, beyond []string
+	for _, name := range names {
+		switch {
+		case g.beyondDepth(name):
+			beyond = append(beyond, g.typeName(name))
+		case g.internal(name):
+			internal = append(internal, g.typeName(name))
+		default:
+			deprecated = append(deprecated, g.typeName(name))
+		}
+	}
+	if len(deprecated) > 0 {
+		fmt.Fprintf(buf, "%s// Omitted deprecated %s; typed as any.\n", indent, strings.Join(deprecated, ", "))
+	}
+	if len(internal) > 0 {
+		fmt.Fprintf(buf, "%s// Omitted internal %s; typed as any.\n", indent, strings.Join(internal, ", "))
+	}
+	if len(beyond) > 0 {
+		fmt.Fprintf(buf, "%s// Omitted %s, beyond the dependency depth; typed as any.\n", indent, strings.Join(beyond, ", "))
+	}
+}
@@ -0,0 +1,60 @@
+// SPDX-License-Identifier: MIT
+
+package golang_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/lspls/generators/golang"
+)
+
+// unionRuntimeTest exercises the discriminated unions generated for
+// testdata/union_discriminated.txtar.
+const unionRuntimeTest = `package protocol
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestDiscriminatedUnion(t *testing.T) {
+	input := ` + "`" + `{
+		"documentChanges": [
+			{"kind": "rename", "oldUri": "file:///a", "newUri": "file:///b"},
+			{"kind": "delete", "uri": "file:///c"},
+			{"kind": "create", "uri": "file:///d"},
+			{"uri": "file:///e", "edits": ["x"]},
+			{"kind": "cr\u0065ate", "uri": "file:///f"}
+		],
+		"marker": {"version": 2}
+	}` + "`" + `
+	var edit WorkspaceEdit
+	if err := json.Unmarshal([]byte(input), &edit); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []any{
+		RenameFile{Kind: "rename", OldUri: "file:///a", NewUri: "file:///b"},
+		DeleteFile{Kind: "delete", Uri: "file:///c"},
+		CreateFile{Kind: "create", Uri: "file:///d"},
+	}
+	if got, want := edit.DocumentChanges[4].Value, (CreateFile{Kind: "create", Uri: "file:///f"}); got != want {
+		t.Errorf("documentChanges[4] = %#v, want %#v", got, want)
+	}
+	for i, w := range want {
+		if got := edit.DocumentChanges[i].Value; got != w {
+			t.Errorf("documentChanges[%d] = %#v, want %#v", i, got, w)
+		}
+	}
+	if _, ok := edit.DocumentChanges[3].Value.(TextDocumentEdit); !ok {
+		t.Errorf("documentChanges[3] = %T, want TextDocumentEdit", edit.DocumentChanges[3].Value)
+	}
+	if _, ok := edit.Marker.Value.(VersionMarker); !ok {
+		t.Errorf("marker = %T, want VersionMarker", edit.Marker.Value)
+	}
+}
+`
+
+func TestDiscriminatedUnionRuntime(t *testing.T) {
+	runGenerated(t, "union_discriminated.txtar", golang.DefaultConfig(), unionRuntimeTest)
+}
@@ -0,0 +1,34 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package golang
+
+import (
+	"fmt"
+
+	"github.com/albertocavalcante/lspls/model"
+)
+
+// unknownKindType returns the name of the placeholder type generated for
+// the unrecognized kind of t, defining the type on first use. Its name,
+// UnknownKind_<kind>, marks in the generated code where a newer
+// specification uses a kind that this generator does not know.
+func (g *Generator) unknownKindType(t *model.Type) string {
+	key := "UnknownKind_" + t.Kind
+	name := g.typeName(key)
+	if _, ok := g.unknownKinds[key]; !ok {
+		g.log.Warn("unknown type kind generated as placeholder", "kind", t.Kind, "type", name, "line", t.Line)
+		g.unknownKinds[key] = t.Kind
+		g.types.set(key, fmt.Sprintf(`// %s stands in for values of the type kind %q,
+// which this version of lspls does not recognize. It holds any JSON
+// value. Report the kind to the lspls project so that it can be
+// supported.
+type %s interface{}
+
+`, name, t.Kind, name))
+	}
+	return name
+}
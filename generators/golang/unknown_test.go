@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: MIT
+
+package golang
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/albertocavalcante/lspls/generator"
+	"github.com/albertocavalcante/lspls/model"
+)
+
+func TestUnknownAsInterface(t *testing.T) {
+	// metaModel.json parsing rejects unknown kinds, so the model is built
+	// in code, as a newer specification would be parsed.
+	m := &model.Model{
+		Structures: []*model.Structure{{
+			Name: "Hover",
+			Properties: []model.Property{
+				{Name: "contents", Type: &model.Type{Kind: "conditional"}},
+				{Name: "fallback", Type: &model.Type{Kind: "conditional"}},
+			},
+		}},
+	}
+
+	for _, tc := range []struct {
+		name    string
+		options map[string]string
+		field   string
+		stats   generator.Stats
+	}{
+		{"any", nil, "Contents any", generator.Stats{Structures: 1, AnyFallbacks: 2}},
+		{"placeholder", map[string]string{"unknown_as_interface": "true"}, "Contents UnknownKind_conditional", generator.Stats{Structures: 1, UnknownKinds: 1}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			out, err := NewGenerator().Generate(context.Background(), m, generator.Config{Options: tc.options})
+			if err != nil {
+				t.Fatal(err)
+			}
+			code := string(out.Files["protocol.go"])
+			if !strings.Contains(code, tc.field) {
+				t.Errorf("output lacks %q:\n%s", tc.field, code)
+			}
+			placeholder := strings.Contains(code, "type UnknownKind_conditional interface{}")
+			if want := tc.options != nil; placeholder != want {
+				t.Errorf("placeholder type generated = %v, want %v:\n%s", placeholder, want, code)
+			}
+			if out.Stats != tc.stats {
+				t.Errorf("Stats = %+v, want %+v", out.Stats, tc.stats)
+			}
+		})
+	}
+}
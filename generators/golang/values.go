@@ -0,0 +1,56 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package golang
+
+import (
+	"fmt"
+
+	"github.com/albertocavalcante/lspls/model"
+)
+
+// writeEnumValues writes an All<Name> slice listing the constants of
+// enumeration e in declaration order.
+func (g *Generator) writeEnumValues(f *goFile, e *model.Enumeration) {
+	name := g.typeName(e.Name)
+	buf := &f.body
+	fmt.Fprintf(buf, "// All%s lists the %s constants in declaration order.\n", name, name)
+	fmt.Fprintf(buf, "var All%s = []%s{\n", name, name)
+	for _, v := range e.Values {
+		fmt.Fprintf(buf, "\t%s%s,\n", name, g.exportName(v.Name))
+	}
+	buf.WriteString("}\n\n")
+}
+
+// writeAllEnumValues writes the All<Name> slice of every generated
+// enumeration to f.
+func (g *Generator) writeAllEnumValues(f *goFile) {
+	for _, name := range g.types.keys() {
+		if e, ok := g.enums[name]; ok {
+			g.writeEnumValues(f, e)
+		}
+	}
+}
+
+// generateValuesFile produces values.go: the All<Name> slices of the
+// enumerations.
+func (g *Generator) generateValuesFile() ([]byte, error) {
+	f := newGoFile()
+
+	g.writeAllEnumValues(f)
+
+	return g.render(f)
+}
+
+// hasEnums reports whether any enumeration is generated.
+func (g *Generator) hasEnums() bool {
+	for _, name := range g.types.keys() {
+		if _, ok := g.enums[name]; ok {
+			return true
+		}
+	}
+	return false
+}
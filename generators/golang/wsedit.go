@@ -0,0 +1,311 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package golang
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+
+	"github.com/albertocavalcante/lspls/model"
+)
+
+// workspaceEditTypes are the types ApplyWorkspaceEdit and ApplyTextEdits
+// are written in terms of.
+var workspaceEditTypes = []string{"WorkspaceEdit", "TextEdit", "Range", "Position"}
+
+// writeWorkspaceEditHelpers writes ApplyWorkspaceEdit and ApplyTextEdits.
+// The document changes and text edit unions are switched on by member, so
+// the helpers follow the model: members carrying a range and new text are
+// applied as text edits, other members make ApplyWorkspaceEdit fail.
+func (g *Generator) writeWorkspaceEditHelpers(f *goFile) {
+	for _, name := range workspaceEditTypes {
+		if _, ok := g.types.m[name]; !ok || g.structures[name] == nil {
+			g.log.Warn("workspace edit helpers not generated: type not generated", "name", name)
+			return
+		}
+	}
+	if !g.isTextEdit(g.structures["TextEdit"]) {
+		g.log.Warn("workspace edit helpers not generated: TextEdit needs a range of Positions and a newText string")
+		return
+	}
+	f.use("bytes", "cmp", "fmt", "slices", "unicode/utf8")
+	buf := &f.body
+	edit := g.typeName("WorkspaceEdit")
+	textEdit := g.typeName("TextEdit")
+
+	// The loops calling apply are written first, so apply is only written
+	// when one of them is.
+	var loops bytes.Buffer
+	documentChanges := g.writeDocumentChanges(&loops, textEdit)
+	if key, ok := g.changesKey(); ok {
+		in := "\t"
+		if documentChanges {
+			loops.WriteString("\tif len(edit.DocumentChanges) == 0 {\n")
+			in = "\t\t"
+		}
+		fmt.Fprintf(&loops, "%suris := make([]string, 0, len(edit.Changes))\n", in)
+		fmt.Fprintf(&loops, "%sfor uri := range edit.Changes {\n", in)
+		fmt.Fprintf(&loops, "%s\turis = append(uris, string(uri))\n", in)
+		fmt.Fprintf(&loops, "%s}\n", in)
+		fmt.Fprintf(&loops, "%sslices.Sort(uris)\n", in)
+		fmt.Fprintf(&loops, "%sfor _, uri := range uris {\n", in)
+		fmt.Fprintf(&loops, "%s\tif err := apply(uri, edit.Changes[%s(uri)]); err != nil {\n", in, g.goType(key, false))
+		fmt.Fprintf(&loops, "%s\t\treturn err\n", in)
+		fmt.Fprintf(&loops, "%s\t}\n", in)
+		fmt.Fprintf(&loops, "%s}\n", in)
+		if documentChanges {
+			loops.WriteString("\t}\n")
+		}
+	}
+
+	buf.WriteString("// ApplyWorkspaceEdit applies the text edits of edit to the documents they\n")
+	buf.WriteString("// change. Each document is read once with read, and written with write\n")
+	buf.WriteString("// only after every edit has been applied, so a failing edit leaves all\n")
+	buf.WriteString("// documents untouched. DocumentChanges, when present, are applied in\n")
+	buf.WriteString("// order and take precedence over Changes, whose documents are edited in\n")
+	buf.WriteString("// URI order.\n")
+	buf.WriteString("//\n")
+	buf.WriteString("// It assumes standard edit semantics: see [ApplyTextEdits]. Resource\n")
+	buf.WriteString("// operations (create, rename, and delete) are not supported and make it\n")
+	buf.WriteString("// fail; document versions and change annotations are ignored.\n")
+	fmt.Fprintf(buf, "func ApplyWorkspaceEdit(edit %s, read func(uri string) ([]byte, error), write func(uri string, data []byte) error) error {\n", edit)
+	buf.WriteString("\tdocs := make(map[string][]byte)\n")
+	buf.WriteString("\tvar order []string\n")
+	if loops.Len() > 0 {
+		fmt.Fprintf(buf, "\tapply := func(uri string, edits []%s) error {\n", textEdit)
+		buf.WriteString("\t\ttext, ok := docs[uri]\n")
+		buf.WriteString("\t\tif !ok {\n")
+		buf.WriteString("\t\t\tvar err error\n")
+		buf.WriteString("\t\t\tif text, err = read(uri); err != nil {\n")
+		buf.WriteString("\t\t\t\treturn fmt.Errorf(\"read %s: %w\", uri, err)\n")
+		buf.WriteString("\t\t\t}\n")
+		buf.WriteString("\t\t\torder = append(order, uri)\n")
+		buf.WriteString("\t\t}\n")
+		buf.WriteString("\t\ttext, err := ApplyTextEdits(text, edits)\n")
+		buf.WriteString("\t\tif err != nil {\n")
+		buf.WriteString("\t\t\treturn fmt.Errorf(\"edit %s: %w\", uri, err)\n")
+		buf.WriteString("\t\t}\n")
+		buf.WriteString("\t\tdocs[uri] = text\n")
+		buf.WriteString("\t\treturn nil\n")
+		buf.WriteString("\t}\n\n")
+		buf.Write(loops.Bytes())
+	}
+	buf.WriteString("\tfor _, uri := range order {\n")
+	buf.WriteString("\t\tif err := write(uri, docs[uri]); err != nil {\n")
+	buf.WriteString("\t\t\treturn fmt.Errorf(\"write %s: %w\", uri, err)\n")
+	buf.WriteString("\t\t}\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\treturn nil\n")
+	buf.WriteString("}\n\n")
+
+	g.writeApplyTextEdits(buf, textEdit)
+}
+
+// isTextEdit reports whether s has the range and newText properties of a
+// TextEdit: a required reference to a Range that isRange accepts, and a
+// required string.
+func (g *Generator) isTextEdit(s *model.Structure) bool {
+	r, ok := g.structures["Range"]
+	if !ok || !g.isRange(r) {
+		return false
+	}
+	return hasPropertyShape(s, "range", isRangeRef) && hasPropertyShape(s, "newText", isString)
+}
+
+// changesKey returns the key type of WorkspaceEdit.changes, reporting
+// false unless the property maps a base type to []TextEdit.
+func (g *Generator) changesKey() (*model.Type, bool) {
+	p := g.findProperty("WorkspaceEdit", "changes")
+	if p == nil || p.Type.Kind != "map" || p.Type.Key == nil || p.Type.Key.Kind != "base" {
+		return nil, false
+	}
+	v, ok := p.Type.Value.(*model.Type)
+	if !ok || v.Kind != "array" || v.Element == nil || v.Element.Kind != "reference" || v.Element.Name != "TextEdit" {
+		return nil, false
+	}
+	return p.Type.Key, true
+}
+
+// isRangeRef and isString are property type checks for hasPropertyShape.
+func isRangeRef(t *model.Type) bool { return t.Kind == "reference" && t.Name == "Range" }
+func isString(t *model.Type) bool   { return t.Kind == "base" && t.Name == "string" }
+
+// writeDocumentChanges writes the loop of ApplyWorkspaceEdit over
+// edit.DocumentChanges and reports whether it did: nothing is written
+// unless WorkspaceEdit has the property and it holds TextDocumentEdits.
+func (g *Generator) writeDocumentChanges(buf *bytes.Buffer, textEdit string) bool {
+	p := g.findProperty("WorkspaceEdit", "documentChanges")
+	if p == nil || p.Type.Kind != "array" {
+		return false
+	}
+	if _, ok := g.types.m["TextDocumentEdit"]; !ok {
+		return false
+	}
+	docEdit := g.typeName("TextDocumentEdit")
+	edits := g.findProperty("TextDocumentEdit", "edits")
+	if edits == nil || edits.Type.Kind != "array" {
+		return false
+	}
+	if t := edits.Type.Element; t.Kind != "or" && (t.Kind != "reference" || t.Name != "TextEdit") {
+		return false
+	}
+	if td := g.findProperty("TextDocumentEdit", "textDocument"); td == nil || td.Type.Kind != "reference" || g.findProperty(td.Type.Name, "uri") == nil {
+		return false
+	}
+
+	elem := p.Type.Element
+	switch {
+	case elem.Kind == "reference" && elem.Name == "TextDocumentEdit":
+		buf.WriteString("\tfor _, c := range edit.DocumentChanges {\n")
+	case elem.Kind == "or" && g.hasMember(elem, "TextDocumentEdit"):
+		buf.WriteString("\tfor _, change := range edit.DocumentChanges {\n")
+		fmt.Fprintf(buf, "\t\tc, ok := change.Value.(%s)\n", docEdit)
+		buf.WriteString("\t\tif !ok {\n")
+		buf.WriteString("\t\t\treturn fmt.Errorf(\"unsupported document change %T\", change.Value)\n")
+		buf.WriteString("\t\t}\n")
+	default:
+		return false
+	}
+	fmt.Fprintf(buf, "\t\tedits := make([]%s, 0, len(c.Edits))\n", textEdit)
+	buf.WriteString("\t\tfor _, e := range c.Edits {\n")
+	if t := edits.Type.Element; t.Kind == "or" {
+		buf.WriteString("\t\t\tswitch e := e.Value.(type) {\n")
+		for _, m := range g.orMembers(t) {
+			if m.Kind != "reference" {
+				continue
+			}
+			switch {
+			case m.Name == "TextEdit":
+				fmt.Fprintf(buf, "\t\t\tcase %s:\n", textEdit)
+				buf.WriteString("\t\t\t\tedits = append(edits, e)\n")
+			case g.hasTextEditFields(m.Name):
+				fmt.Fprintf(buf, "\t\t\tcase %s:\n", g.typeName(m.Name))
+				fmt.Fprintf(buf, "\t\t\t\tedits = append(edits, %s{Range: e.Range, NewText: e.NewText})\n", textEdit)
+			}
+		}
+		buf.WriteString("\t\t\tdefault:\n")
+		buf.WriteString("\t\t\t\treturn fmt.Errorf(\"unsupported text edit %T\", e)\n")
+		buf.WriteString("\t\t\t}\n")
+	} else {
+		buf.WriteString("\t\t\tedits = append(edits, e)\n")
+	}
+	buf.WriteString("\t\t}\n")
+	fmt.Fprintf(buf, "\t\tif err := apply(string(c.%s.%s), edits); err != nil {\n", g.exportName("textDocument"), g.exportName("uri"))
+	buf.WriteString("\t\t\treturn err\n")
+	buf.WriteString("\t\t}\n")
+	buf.WriteString("\t}\n")
+	return true
+}
+
+// writeApplyTextEdits writes ApplyTextEdits and the positionOffset
+// function it converts positions with.
+func (g *Generator) writeApplyTextEdits(buf *bytes.Buffer, textEdit string) {
+	position := g.typeName("Position")
+
+	buf.WriteString("// ApplyTextEdits returns text with edits applied, assuming standard edit\n")
+	buf.WriteString("// semantics: every range refers to text before any edit, ranges do not\n")
+	buf.WriteString("// overlap, and characters count UTF-16 code units. Edits inserting at the\n")
+	buf.WriteString("// same position are applied in order. A character past the end of a line\n")
+	buf.WriteString("// refers to the end of the line, and a line past the end of text to the\n")
+	buf.WriteString("// end of text.\n")
+	fmt.Fprintf(buf, "func ApplyTextEdits(text []byte, edits []%s) ([]byte, error) {\n", textEdit)
+	buf.WriteString("\ttype span struct {\n")
+	buf.WriteString("\t\tstart, end int\n")
+	buf.WriteString("\t\tnewText    string\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\tspans := make([]span, 0, len(edits))\n")
+	buf.WriteString("\tfor _, e := range edits {\n")
+	buf.WriteString("\t\tstart, end := positionOffset(text, e.Range.Start), positionOffset(text, e.Range.End)\n")
+	buf.WriteString("\t\tif end < start {\n")
+	buf.WriteString("\t\t\treturn nil, fmt.Errorf(\"edit range %v ends before it starts\", e.Range)\n")
+	buf.WriteString("\t\t}\n")
+	buf.WriteString("\t\tspans = append(spans, span{start, end, e.NewText})\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\tslices.SortStableFunc(spans, func(a, b span) int { return cmp.Compare(a.start, b.start) })\n\n")
+	buf.WriteString("\tvar out bytes.Buffer\n")
+	buf.WriteString("\tlast := 0\n")
+	buf.WriteString("\tfor _, s := range spans {\n")
+	buf.WriteString("\t\tif s.start < last {\n")
+	buf.WriteString("\t\t\treturn nil, fmt.Errorf(\"overlapping edits at offset %d\", s.start)\n")
+	buf.WriteString("\t\t}\n")
+	buf.WriteString("\t\tout.Write(text[last:s.start])\n")
+	buf.WriteString("\t\tout.WriteString(s.newText)\n")
+	buf.WriteString("\t\tlast = s.end\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\tout.Write(text[last:])\n")
+	buf.WriteString("\treturn out.Bytes(), nil\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// positionOffset returns the byte offset of p in text, counting\n")
+	buf.WriteString("// p.Character in UTF-16 code units. Lines end at \"\\n\" or \"\\r\\n\".\n")
+	fmt.Fprintf(buf, "func positionOffset(text []byte, p %s) int {\n", position)
+	buf.WriteString("\toff := 0\n")
+	buf.WriteString("\tfor line := 0; line < int(p.Line); line++ {\n")
+	buf.WriteString("\t\ti := bytes.IndexByte(text[off:], '\\n')\n")
+	buf.WriteString("\t\tif i < 0 {\n")
+	buf.WriteString("\t\t\treturn len(text)\n")
+	buf.WriteString("\t\t}\n")
+	buf.WriteString("\t\toff += i + 1\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\tfor units := 0; units < int(p.Character) && off < len(text); {\n")
+	buf.WriteString("\t\tif text[off] == '\\n' || bytes.HasPrefix(text[off:], []byte(\"\\r\\n\")) {\n")
+	buf.WriteString("\t\t\tbreak\n")
+	buf.WriteString("\t\t}\n")
+	buf.WriteString("\t\tr, size := utf8.DecodeRune(text[off:])\n")
+	buf.WriteString("\t\tif r >= 0x10000 {\n")
+	buf.WriteString("\t\t\tunits += 2\n")
+	buf.WriteString("\t\t} else {\n")
+	buf.WriteString("\t\t\tunits++\n")
+	buf.WriteString("\t\t}\n")
+	buf.WriteString("\t\toff += size\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\treturn off\n")
+	buf.WriteString("}\n\n")
+}
+
+// hasTextEditFields reports whether the named structure has, possibly
+// through the structures it extends and mixes in, the required range and
+// newText properties a TextEdit is built from.
+func (g *Generator) hasTextEditFields(name string) bool {
+	r, t := g.findProperty(name, "range"), g.findProperty(name, "newText")
+	return r != nil && !r.Optional && isRangeRef(r.Type) && t != nil && !t.Optional && isString(t.Type)
+}
+
+// findProperty returns the property called prop of the named structure,
+// looking through the structures it extends and mixes in, or nil.
+func (g *Generator) findProperty(name, prop string) *model.Property {
+	s, ok := g.structures[name]
+	if !ok {
+		return nil
+	}
+	for i := range s.Properties {
+		if s.Properties[i].Name == prop {
+			return &s.Properties[i]
+		}
+	}
+	for _, ext := range slices.Concat(s.Extends, s.Mixins) {
+		if ext.Kind != "reference" {
+			continue
+		}
+		if p := g.findProperty(ext.Name, prop); p != nil {
+			return p
+		}
+	}
+	return nil
+}
+
+// hasMember reports whether the or type t has a generated member
+// referencing the named type.
+func (g *Generator) hasMember(t *model.Type, name string) bool {
+	for _, m := range g.orMembers(t) {
+		if m.Kind == "reference" && m.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,139 @@
+// SPDX-License-Identifier: MIT
+
+package golang_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/lspls/generators/golang"
+)
+
+// workspaceEditRuntimeTest applies edits with the helpers generated for
+// testdata/workspace_edit_helpers.txtar.
+const workspaceEditRuntimeTest = `package protocol
+
+import (
+	"errors"
+	"testing"
+)
+
+func edit(sl, sc, el, ec uint32, text string) TextEdit {
+	return TextEdit{Range: Range{Start: Position{Line: sl, Character: sc}, End: Position{Line: el, Character: ec}}, NewText: text}
+}
+
+func TestApplyTextEdits(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		edits []TextEdit
+		want  string
+	}{
+		{"replace", "hello world\n", []TextEdit{edit(0, 6, 0, 11, "there")}, "hello there\n"},
+		{"unordered", "abc\ndef\n", []TextEdit{edit(1, 0, 1, 1, "D"), edit(0, 0, 0, 1, "A")}, "Abc\nDef\n"},
+		{"same position inserts", "x", []TextEdit{edit(0, 1, 0, 1, "1"), edit(0, 1, 0, 1, "2")}, "x12"},
+		{"delete line", "a\nb\nc\n", []TextEdit{edit(1, 0, 2, 0, "")}, "a\nc\n"},
+		{"utf-16", "é😀x\n", []TextEdit{edit(0, 3, 0, 4, "y")}, "é😀y\n"},
+		{"crlf end of line", "ab\r\ncd", []TextEdit{edit(0, 9, 0, 9, "!")}, "ab!\r\ncd"},
+		{"past end", "ab", []TextEdit{edit(5, 0, 5, 0, "\n")}, "ab\n"},
+	}
+	for _, tt := range tests {
+		got, err := ApplyTextEdits([]byte(tt.text), tt.edits)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if string(got) != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	if _, err := ApplyTextEdits([]byte("abcdef"), []TextEdit{edit(0, 0, 0, 3, ""), edit(0, 2, 0, 4, "")}); err == nil {
+		t.Error("overlapping edits: no error")
+	}
+	if _, err := ApplyTextEdits([]byte("abcdef"), []TextEdit{edit(0, 3, 0, 1, "")}); err == nil {
+		t.Error("reversed range: no error")
+	}
+}
+
+func TestApplyWorkspaceEdit(t *testing.T) {
+	files := map[string]string{"file:///a": "one\ntwo\n", "file:///b": "three\n"}
+	read := func(uri string) ([]byte, error) {
+		text, ok := files[uri]
+		if !ok {
+			return nil, errors.New("not found")
+		}
+		return []byte(text), nil
+	}
+	var written []string
+	write := func(uri string, data []byte) error {
+		written = append(written, uri)
+		files[uri] = string(data)
+		return nil
+	}
+
+	err := ApplyWorkspaceEdit(WorkspaceEdit{Changes: map[string][]TextEdit{
+		"file:///b": {edit(0, 0, 0, 5, "3")},
+		"file:///a": {edit(0, 0, 0, 3, "1"), edit(1, 0, 1, 3, "2")},
+	}}, read, write)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if files["file:///a"] != "1\n2\n" || files["file:///b"] != "3\n" {
+		t.Errorf("changes: got %q", files)
+	}
+	if len(written) != 2 || written[0] != "file:///a" {
+		t.Errorf("changes written in order %v", written)
+	}
+
+	// Edits of later document changes refer to the result of earlier ones,
+	// and documentChanges take precedence over changes.
+	doc := OptionalVersionedTextDocumentIdentifier{TextDocumentIdentifier: TextDocumentIdentifier{Uri: "file:///a"}}
+	written = nil
+	err = ApplyWorkspaceEdit(WorkspaceEdit{
+		Changes: map[string][]TextEdit{"file:///b": {edit(0, 0, 0, 1, "x")}},
+		DocumentChanges: []Or_CreateFile_TextDocumentEdit{
+			{Value: TextDocumentEdit{TextDocument: doc, Edits: []Or_AnnotatedTextEdit_SnippetTextEdit_TextEdit{
+				{Value: edit(0, 1, 0, 1, "0")},
+			}}},
+			{Value: TextDocumentEdit{TextDocument: doc, Edits: []Or_AnnotatedTextEdit_SnippetTextEdit_TextEdit{
+				{Value: AnnotatedTextEdit{TextEdit: edit(0, 0, 0, 2, "ten"), AnnotationId: "a"}},
+			}}},
+		},
+	}, read, write)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if files["file:///a"] != "ten\n2\n" || files["file:///b"] != "3\n" {
+		t.Errorf("document changes: got %q", files)
+	}
+	if len(written) != 1 {
+		t.Errorf("document changes wrote %v, want one document", written)
+	}
+
+	// Nothing is written when any change fails.
+	written = nil
+	for _, change := range []Or_CreateFile_TextDocumentEdit{
+		{Value: CreateFile{Kind: "create", Uri: "file:///c"}},
+		{Value: TextDocumentEdit{TextDocument: doc, Edits: []Or_AnnotatedTextEdit_SnippetTextEdit_TextEdit{
+			{Value: SnippetTextEdit{Range: edit(0, 0, 0, 0, "").Range, Snippet: "$0"}},
+		}}},
+	} {
+		err = ApplyWorkspaceEdit(WorkspaceEdit{DocumentChanges: []Or_CreateFile_TextDocumentEdit{
+			{Value: TextDocumentEdit{TextDocument: doc, Edits: []Or_AnnotatedTextEdit_SnippetTextEdit_TextEdit{{Value: edit(0, 0, 0, 0, "!")}}}},
+			change,
+		}}, read, write)
+		if err == nil {
+			t.Errorf("%T: no error", change.Value)
+		}
+	}
+	if len(written) != 0 {
+		t.Errorf("failed edits wrote %v", written)
+	}
+}
+`
+
+func TestWorkspaceEditHelpersRuntime(t *testing.T) {
+	cfg := golang.DefaultConfig()
+	cfg.WorkspaceEditHelpers = true
+	runGenerated(t, "workspace_edit_helpers.txtar", cfg, workspaceEditRuntimeTest)
+}
@@ -16,8 +16,10 @@ package groovy
 import (
 	"bytes"
 	"fmt"
+	"log/slog"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/albertocavalcante/lspls/generator"
 	"github.com/albertocavalcante/lspls/internal/lspbase"
@@ -28,6 +30,10 @@ import (
 type Codegen struct {
 	model  *model.Model
 	config Config
+	log    *slog.Logger
+
+	// casing derives identifiers from LSP names.
+	casing *lspbase.Casing
 
 	types      *orderedMap[string]
 	typeFilter map[string]bool
@@ -35,6 +41,11 @@ type Codegen struct {
 	// unionTypes tracks generated union wrapper classes to avoid duplicates.
 	unionTypes *orderedMap[unionTypeInfo]
 
+	// aliases indexes the model's type aliases, which references resolve
+	// to their targets, and resolving holds the aliases being resolved.
+	aliases   map[string]*model.TypeAlias
+	resolving map[string]bool
+
 	proposedTypes map[string]bool
 }
 
@@ -57,6 +68,16 @@ func New(m *model.Model, cfg Config) *Codegen {
 		types:         newOrderedMap[string](),
 		unionTypes:    newOrderedMap[unionTypeInfo](),
 		proposedTypes: buildProposedCache(m),
+		casing:        lspbase.NewCasing(cfg.Acronyms, cfg.NameOverrides),
+		aliases:       make(map[string]*model.TypeAlias, len(m.TypeAliases)),
+		resolving:     make(map[string]bool),
+	}
+	for _, a := range m.TypeAliases {
+		c.aliases[a.Name] = a
+	}
+	c.log = cfg.Logger
+	if c.log == nil {
+		c.log = slog.New(slog.DiscardHandler)
 	}
 	if len(cfg.Types) > 0 {
 		c.typeFilter = make(map[string]bool)
@@ -91,6 +112,10 @@ func (g *Codegen) Generate() (*Output, error) {
 		if !g.shouldInclude(s.Name, s.Proposed) {
 			continue
 		}
+		g.log.Debug("generating structure", "name", s.Name)
+		if err := g.checkJSONNames(s); err != nil {
+			return nil, err
+		}
 		g.generateStructure(s)
 	}
 
@@ -98,6 +123,7 @@ func (g *Codegen) Generate() (*Output, error) {
 		if !g.shouldInclude(e.Name, e.Proposed) {
 			continue
 		}
+		g.log.Debug("generating enumeration", "name", e.Name)
 		g.generateEnumeration(e)
 	}
 
@@ -105,10 +131,11 @@ func (g *Codegen) Generate() (*Output, error) {
 		if !g.shouldInclude(a.Name, a.Proposed) {
 			continue
 		}
+		g.log.Debug("generating type alias", "name", a.Name)
 		g.generateTypeAlias(a)
 	}
 
-	return &Output{Groovy: g.emit()}, nil
+	return &Output{Groovy: lspbase.Reindent(g.emit(), "    ", g.config.Indent)}, nil
 }
 
 func (g *Codegen) shouldInclude(name string, proposed bool) bool {
@@ -121,6 +148,24 @@ func (g *Codegen) shouldInclude(name string, proposed bool) bool {
 	return true
 }
 
+// docs returns doc, or the empty string when documentation is minified.
+func (g *Codegen) docs(doc string) string {
+	if g.config.MinifyDocs {
+		return ""
+	}
+	return doc
+}
+
+// deprecated returns msg, the deprecation message of a structure,
+// enumeration, or property, when documentation is minified, and the empty
+// string otherwise, when the documentation carries its own @deprecated tag.
+func (g *Codegen) deprecated(msg string) string {
+	if !g.config.MinifyDocs {
+		return ""
+	}
+	return msg
+}
+
 func (g *Codegen) isProposed(name string) bool {
 	return g.proposedTypes[name]
 }
@@ -130,20 +175,23 @@ func (g *Codegen) isProposed(name string) bool {
 func (g *Codegen) generateStructure(s *model.Structure) {
 	var buf bytes.Buffer
 
-	writeGroovydoc(&buf, s.Documentation, s.Since, "")
+	writeGroovydoc(&buf, g.docs(s.Documentation), s.Since, g.deprecated(s.Deprecated))
 
 	// Collect properties (including inherited ones from extends/mixins)
 	props := g.collectProperties(s)
 
 	fmt.Fprintf(&buf, "@CompileStatic\n")
 	fmt.Fprintf(&buf, "@JsonIgnoreProperties(ignoreUnknown = true)\n")
+	if g.wantsBuilder(props) {
+		fmt.Fprintf(&buf, "@Builder\n")
+	}
 
 	if len(props) == 0 {
-		fmt.Fprintf(&buf, "record %s() {}\n", typeName(s.Name))
+		fmt.Fprintf(&buf, "record %s() {}\n", g.typeName(s.Name))
 	} else {
-		fmt.Fprintf(&buf, "record %s(\n", typeName(s.Name))
+		fmt.Fprintf(&buf, "record %s(\n", g.typeName(s.Name))
 		for i, p := range props {
-			g.generateProperty(&buf, &p, i == len(props)-1)
+			g.generateProperty(&buf, &p, s.Since, i == len(props)-1)
 		}
 		buf.WriteString(") {}\n")
 	}
@@ -151,9 +199,40 @@ func (g *Codegen) generateStructure(s *model.Structure) {
 	g.types.set(s.Name, buf.String())
 }
 
-// collectProperties gathers direct properties. Extends/mixins are flattened
-// into the record because Groovy records don't support multiple inheritance.
+// wantsBuilder reports whether a record with props is annotated with
+// @Builder: Builders is set and at least that many properties are required.
+func (g *Codegen) wantsBuilder(props []model.Property) bool {
+	if g.config.Builders <= 0 {
+		return false
+	}
+	required := 0
+	for _, p := range props {
+		if !p.Optional {
+			required++
+		}
+	}
+	return required >= g.config.Builders
+}
+
+// collectProperties returns the properties of s with those of its extends
+// and mixins flattened in, each JSON name once: when a name repeats, the
+// most-derived property is kept.
 func (g *Codegen) collectProperties(s *model.Structure) []model.Property {
+	props, _ := generator.DedupProperties(g.flattenProperties(s))
+	return props
+}
+
+// checkJSONNames reports the JSON names that flattening gives more than
+// one property of s: as an error under StrictJSONNames, as a warning
+// otherwise.
+func (g *Codegen) checkJSONNames(s *model.Structure) error {
+	_, dups := generator.DedupProperties(g.flattenProperties(s))
+	return generator.CheckJSONNames(g.log, s.Name, dups, g.config.StrictJSONNames)
+}
+
+// flattenProperties gathers direct properties. Extends/mixins are flattened
+// into the record because Groovy records don't support multiple inheritance.
+func (g *Codegen) flattenProperties(s *model.Structure) []model.Property {
 	var props []model.Property
 
 	// Flatten extends
@@ -161,7 +240,7 @@ func (g *Codegen) collectProperties(s *model.Structure) []model.Property {
 		if ext.Kind == "reference" {
 			for _, parent := range g.model.Structures {
 				if parent.Name == ext.Name {
-					props = append(props, g.collectProperties(parent)...)
+					props = append(props, g.flattenProperties(parent)...)
 				}
 			}
 		}
@@ -172,7 +251,7 @@ func (g *Codegen) collectProperties(s *model.Structure) []model.Property {
 		if mix.Kind == "reference" {
 			for _, parent := range g.model.Structures {
 				if parent.Name == mix.Name {
-					props = append(props, g.collectProperties(parent)...)
+					props = append(props, g.flattenProperties(parent)...)
 				}
 			}
 		}
@@ -189,15 +268,27 @@ func (g *Codegen) collectProperties(s *model.Structure) []model.Property {
 	return props
 }
 
-func (g *Codegen) generateProperty(buf *bytes.Buffer, p *model.Property, last bool) {
+// generateProperty writes the record component for p. parentSince is the
+// enclosing structure's version; a property added later gets its own
+// @since line.
+func (g *Codegen) generateProperty(buf *bytes.Buffer, p *model.Property, parentSince string, last bool) {
 	// Groovydoc for property
-	if p.Documentation != "" {
-		for line := range strings.SplitSeq(p.Documentation, "\n") {
-			fmt.Fprintf(buf, "    /** %s */\n", line)
+	doc := g.docs(p.Documentation)
+	if doc != "" {
+		for line := range strings.SplitSeq(doc, "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				fmt.Fprintf(buf, "    /** %s */\n", line)
+			}
 		}
 	}
+	if since := lspbase.MemberSince(p.Since, parentSince, doc); since != "" {
+		fmt.Fprintf(buf, "    /** @since %s */\n", since)
+	}
+	if deprecated := g.deprecated(p.Deprecated); deprecated != "" {
+		fmt.Fprintf(buf, "    /** @deprecated %s */\n", deprecated)
+	}
 
-	name := fieldName(p.Name)
+	name := g.fieldName(p.Name)
 	gt := g.groovyType(p.Type, false)
 
 	// Determine if field needs @JsonProperty (when Groovy name differs from JSON key)
@@ -205,7 +296,7 @@ func (g *Codegen) generateProperty(buf *bytes.Buffer, p *model.Property, last bo
 	needsJSONProperty := name != jsonName
 
 	if needsJSONProperty {
-		fmt.Fprintf(buf, "    @JsonProperty(%q)\n", jsonName)
+		fmt.Fprintf(buf, "    @JsonProperty(%s)\n", stringLiteral(jsonName))
 	}
 
 	// Optional fields: box primitives and set default to null
@@ -227,7 +318,7 @@ func (g *Codegen) generateProperty(buf *bytes.Buffer, p *model.Property, last bo
 func (g *Codegen) generateEnumeration(e *model.Enumeration) {
 	var buf bytes.Buffer
 
-	writeGroovydoc(&buf, e.Documentation, e.Since, "")
+	writeGroovydoc(&buf, g.docs(e.Documentation), e.Since, g.deprecated(e.Deprecated))
 
 	baseType := groovyBaseType(e.Type)
 	isString := baseType == "String"
@@ -242,16 +333,15 @@ func (g *Codegen) generateEnumeration(e *model.Enumeration) {
 	}
 
 	fmt.Fprintf(&buf, "@CompileStatic\n")
-	fmt.Fprintf(&buf, "enum %s {\n", typeName(e.Name))
+	fmt.Fprintf(&buf, "enum %s {\n", g.typeName(e.Name))
 
 	if isString {
 		// String enum with @JsonValue
 		for i, v := range values {
-			if v.Documentation != "" {
-				writeIndentedGroovydoc(&buf, v.Documentation, "    ")
-			}
+			doc := g.docs(v.Documentation)
+			writeIndentedGroovydoc(&buf, doc, lspbase.MemberSince(v.Since, e.Since, doc), "    ")
 			strVal, _ := v.Value.(string)
-			constName := enumConstName(v.Name)
+			constName := g.enumConstName(v.Name)
 			fmt.Fprintf(&buf, "    %s('%s')", constName, strVal)
 			if i < len(values)-1 {
 				buf.WriteString(",")
@@ -260,16 +350,15 @@ func (g *Codegen) generateEnumeration(e *model.Enumeration) {
 		}
 		buf.WriteString("\n")
 		fmt.Fprintf(&buf, "    final String value\n")
-		fmt.Fprintf(&buf, "    %s(String value) { this.value = value }\n", typeName(e.Name))
+		fmt.Fprintf(&buf, "    %s(String value) { this.value = value }\n", g.typeName(e.Name))
 		fmt.Fprintf(&buf, "    @JsonValue\n")
 		fmt.Fprintf(&buf, "    String getValue() { value }\n")
 	} else {
 		// Integer enum with @JsonValue and @JsonCreator
 		for i, v := range values {
-			if v.Documentation != "" {
-				writeIndentedGroovydoc(&buf, v.Documentation, "    ")
-			}
-			constName := enumConstName(v.Name)
+			doc := g.docs(v.Documentation)
+			writeIndentedGroovydoc(&buf, doc, lspbase.MemberSince(v.Since, e.Since, doc), "    ")
+			constName := g.enumConstName(v.Name)
 			intVal := formatIntValue(v.Value)
 			fmt.Fprintf(&buf, "    %s(%s)", constName, intVal)
 			if i < len(values)-1 {
@@ -279,11 +368,11 @@ func (g *Codegen) generateEnumeration(e *model.Enumeration) {
 		}
 		buf.WriteString("\n")
 		fmt.Fprintf(&buf, "    final int value\n")
-		fmt.Fprintf(&buf, "    %s(int value) { this.value = value }\n", typeName(e.Name))
+		fmt.Fprintf(&buf, "    %s(int value) { this.value = value }\n", g.typeName(e.Name))
 		fmt.Fprintf(&buf, "    @JsonValue\n")
 		fmt.Fprintf(&buf, "    int getValue() { value }\n")
 		fmt.Fprintf(&buf, "    @JsonCreator\n")
-		fmt.Fprintf(&buf, "    static %s fromValue(int value) {\n", typeName(e.Name))
+		fmt.Fprintf(&buf, "    static %s fromValue(int value) {\n", g.typeName(e.Name))
 		fmt.Fprintf(&buf, "        values().find { it.value == value }\n")
 		fmt.Fprintf(&buf, "    }\n")
 	}
@@ -294,14 +383,17 @@ func (g *Codegen) generateEnumeration(e *model.Enumeration) {
 }
 
 // -- Type alias -> comment (Groovy has no typealias) --------------------------
+//
+// References to an alias are replaced by its target type (see aliasType),
+// so the comment only documents the alias.
 
 func (g *Codegen) generateTypeAlias(a *model.TypeAlias) {
 	var buf bytes.Buffer
 
-	gt := g.groovyType(a.Type, false)
+	gt := g.aliasType(a)
 
-	writeGroovydoc(&buf, a.Documentation, a.Since, a.Deprecated)
-	fmt.Fprintf(&buf, "// Type alias: %s = %s\n", typeName(a.Name), gt)
+	writeGroovydoc(&buf, g.docs(a.Documentation), a.Since, a.Deprecated)
+	fmt.Fprintf(&buf, "// Type alias: %s = %s\n", g.typeName(a.Name), gt)
 
 	g.types.set(a.Name, buf.String())
 }
@@ -356,6 +448,14 @@ func (g *Codegen) generateUnionDeserializer(buf *bytes.Buffer, info unionTypeInf
 	fmt.Fprintf(buf, "    %s deserialize(JsonParser p, DeserializationContext ctxt) {\n", info.name)
 	fmt.Fprintf(buf, "        JsonNode node = p.readValueAsTree()\n")
 
+	// Structures with a literal-valued property are selected by its value.
+	for _, v := range info.variants {
+		if v.discriminator != "" {
+			fmt.Fprintf(buf, "        if (node.isObject() && %s) return new %s.%sValue(p.codec.treeToValue(node, %s))\n",
+				v.discriminator, info.name, v.identName, v.groovyType)
+		}
+	}
+
 	// Build discrimination logic based on JSON node type
 	hasObject := false
 	hasArray := false
@@ -402,8 +502,12 @@ func (g *Codegen) generatePrimitiveDiscrimination(buf *bytes.Buffer, info unionT
 }
 
 func (g *Codegen) generateObjectDiscrimination(buf *bytes.Buffer, info unionTypeInfo) {
-	// For multiple object types, try each via treeToValue
+	// For multiple object types, try each via treeToValue. Variants with a
+	// discriminator were matched above and are not tried again.
 	for _, v := range info.variants {
+		if v.discriminator != "" {
+			continue
+		}
 		fmt.Fprintf(buf, "        if (node.isObject()) {\n")
 		fmt.Fprintf(buf, "            try {\n")
 		fmt.Fprintf(buf, "                return new %s.%sValue(p.codec.treeToValue(node, %s))\n", info.name, v.identName, v.groovyType)
@@ -482,17 +586,22 @@ func (g *Codegen) collectImports() []string {
 	hasStringEnum := false
 	hasIntEnum := false
 	hasJSONProperty := false
+	hasBuilder := false
 
 	for _, s := range g.model.Structures {
 		if !g.shouldInclude(s.Name, s.Proposed) {
 			continue
 		}
 		hasStructures = true
-		for _, p := range g.collectProperties(s) {
-			if fieldName(p.Name) != p.Name {
+		props := g.collectProperties(s)
+		for _, p := range props {
+			if g.fieldName(p.Name) != p.Name {
 				hasJSONProperty = true
 			}
 		}
+		if g.wantsBuilder(props) {
+			hasBuilder = true
+		}
 	}
 
 	for _, e := range g.model.Enumerations {
@@ -519,6 +628,9 @@ func (g *Codegen) collectImports() []string {
 	if hasJSONProperty {
 		imports = append(imports, "com.fasterxml.jackson.annotation.JsonProperty")
 	}
+	if hasBuilder {
+		imports = append(imports, "groovy.transform.builder.Builder")
+	}
 
 	// Jackson imports for enums
 	if hasStringEnum || hasIntEnum {
@@ -565,6 +677,12 @@ func (g *Codegen) fileHeader() string {
 	if g.config.LSPVersion != "" {
 		lines = append(lines, fmt.Sprintf("// LSP Version: %s", g.config.LSPVersion))
 	}
+	if g.config.ToolVersion != "" {
+		lines = append(lines, fmt.Sprintf("// Generator: lspls %s", g.config.ToolVersion))
+	}
+	if !g.config.Timestamp.IsZero() {
+		lines = append(lines, fmt.Sprintf("// Generated: %s", g.config.Timestamp.UTC().Format(time.RFC3339)))
+	}
 	lines = append(lines, "")
 	return strings.Join(lines, "\n")
 }
@@ -577,26 +695,37 @@ func writeGroovydoc(buf *bytes.Buffer, doc, since, deprecated string) {
 	}
 	buf.WriteString("/**\n")
 	if doc != "" {
-		for line := range strings.SplitSeq(doc, "\n") {
-			fmt.Fprintf(buf, " * %s\n", line)
-		}
+		lspbase.WriteComment(buf, " *", doc)
 	}
-	if since != "" && !strings.Contains(doc, "@since "+since) {
-		fmt.Fprintf(buf, " *\n * @since %s\n", since)
+	hasSince := since != "" && !strings.Contains(doc, "@since "+since)
+	if hasSince {
+		if doc != "" {
+			buf.WriteString(" *\n")
+		}
+		fmt.Fprintf(buf, " * @since %s\n", since)
 	}
 	if deprecated != "" {
-		fmt.Fprintf(buf, " *\n * @deprecated %s\n", deprecated)
+		if doc != "" || hasSince {
+			buf.WriteString(" *\n")
+		}
+		fmt.Fprintf(buf, " * @deprecated %s\n", deprecated)
 	}
 	buf.WriteString(" */\n")
 }
 
-func writeIndentedGroovydoc(buf *bytes.Buffer, doc, indent string) {
-	if doc == "" {
+func writeIndentedGroovydoc(buf *bytes.Buffer, doc, since, indent string) {
+	if doc == "" && since == "" {
 		return
 	}
 	fmt.Fprintf(buf, "%s/**\n", indent)
-	for line := range strings.SplitSeq(doc, "\n") {
-		fmt.Fprintf(buf, "%s * %s\n", indent, line)
+	if doc != "" {
+		lspbase.WriteComment(buf, indent+" *", doc)
+	}
+	if since != "" {
+		if doc != "" {
+			fmt.Fprintf(buf, "%s *\n", indent)
+		}
+		fmt.Fprintf(buf, "%s * @since %s\n", indent, since)
 	}
 	fmt.Fprintf(buf, "%s */\n", indent)
 }
@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 	"slices"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -80,6 +81,7 @@ func runCodegen(input []byte, flags []string) (map[string][]byte, error) {
 		PackageName:     "lsp.protocol",
 		ResolveDeps:     true,
 		IncludeProposed: slices.Contains(flags, "proposed"),
+		MinifyDocs:      slices.Contains(flags, "minify-docs"),
 	}
 
 	for _, f := range flags {
@@ -89,6 +91,15 @@ func runCodegen(input []byte, flags []string) (map[string][]byte, error) {
 		if pkgName, ok := strings.CutPrefix(f, "package="); ok {
 			cfg.PackageName = pkgName
 		}
+		if indent, ok := strings.CutPrefix(f, "indent="); ok {
+			cfg.Indent = "\t"
+			if n, err := strconv.Atoi(indent); err == nil {
+				cfg.Indent = strings.Repeat(" ", n)
+			}
+		}
+		if n, ok := strings.CutPrefix(f, "builders="); ok {
+			cfg.Builders, _ = strconv.Atoi(n)
+		}
 		if f == "no-resolve-deps" {
 			cfg.ResolveDeps = false
 		}
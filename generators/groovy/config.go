@@ -6,11 +6,27 @@
 
 package groovy
 
+import (
+	"log/slog"
+	"time"
+)
+
 // Config holds configuration for Groovy generation.
 type Config struct {
 	// PackageName is the Groovy package name (e.g., "lsp.protocol").
 	PackageName string
 
+	// TypePrefix and TypeSuffix are added to the name of every generated
+	// type, including Or_* unions. JSON property names are unchanged.
+	TypePrefix string
+	TypeSuffix string
+
+	// Acronyms are words cased the same way wherever they appear in a
+	// generated identifier. NameOverrides maps an LSP name to its
+	// identifier, bypassing the other rules.
+	Acronyms      []string
+	NameOverrides map[string]string
+
 	// Types to include (empty means all).
 	Types []string
 
@@ -20,16 +36,46 @@ type Config struct {
 	// IncludeProposed generates types marked as proposed.
 	IncludeProposed bool
 
+	// MinifyDocs omits documentation comments, keeping @since and
+	// @deprecated tags.
+	MinifyDocs bool
+
+	// StrictJSONNames fails generation when flattening extends and mixins
+	// gives more than one property of a structure the same JSON name,
+	// instead of keeping the most-derived one with a warning.
+	StrictJSONNames bool
+
+	// Indent is the indentation unit, such as a tab or two spaces. Empty
+	// uses four spaces.
+	Indent string
+
+	// Builders, when positive, annotates records with at least this many
+	// required properties with @Builder.
+	Builders int
+
 	// Source metadata for header comments.
 	Source     string
 	Ref        string
 	CommitHash string
 	LSPVersion string
+
+	// ToolVersion and Timestamp, when set, add the lspls version and the
+	// generation time to header comments.
+	ToolVersion string
+	Timestamp   time.Time
+
+	// Logger receives per-type progress at debug level and warnings about
+	// types that degrade to Object. If nil, nothing is logged.
+	Logger *slog.Logger
 }
 
 // DefaultMappings provides standard LSP to Groovy type mappings
 // for type aliases that should collapse to a primitive or well-known type.
+// References to other aliases resolve to the aliases' target types.
 var DefaultMappings = map[string]string{
+	"LSPAny":                      "Object",
+	"LSPObject":                   "Map<String, Object>",
+	"LSPArray":                    "List<Object>",
 	"DocumentUri":                 "String",
 	"URI":                         "String",
 	"ChangeAnnotationIdentifier":  "String",
@@ -8,6 +8,8 @@ package groovy
 
 import (
 	"context"
+	"fmt"
+	"strconv"
 
 	"github.com/albertocavalcante/lspls/generator"
 	"github.com/albertocavalcante/lspls/model"
@@ -32,17 +34,54 @@ func (g *Generator) Metadata() generator.Metadata {
 	}
 }
 
+// Describe returns the options, outputs, and requirements of this generator.
+func (g *Generator) Describe() generator.Description {
+	return generator.Description{
+		Options: []generator.OptionInfo{
+			{Key: "package", Flag: "-p", Default: "lsp.protocol", Description: "Groovy package name"},
+			{Key: "builders", Flag: "--builders", Default: "0", Description: "Annotate records with at least n required properties with @Builder"},
+		},
+		Outputs: []string{
+			"Protocol.groovy: classes, enums, and union deserializers",
+			"index.md: type index (directory output, --index)",
+		},
+		Requirements: []string{
+			"Groovy 3+ (@CompileStatic)",
+			"Jackson databind and annotations",
+		},
+	}
+}
+
 // Generate produces Groovy output files from the LSP model.
 func (g *Generator) Generate(ctx context.Context, m *model.Model, cfg generator.Config) (*generator.Output, error) {
+	m, err := generator.ResolveEnumBases(m)
+	if err != nil {
+		return nil, err
+	}
+	builders, err := strconv.Atoi(cfg.Option("builders", "0"))
+	if err != nil {
+		return nil, fmt.Errorf("builders %q: want a number of required properties", cfg.Option("builders", ""))
+	}
 	internalCfg := Config{
 		PackageName:     cfg.Option("package", "lsp.protocol"),
 		Types:           cfg.Types,
+		TypePrefix:      cfg.TypePrefix,
+		TypeSuffix:      cfg.TypeSuffix,
+		Acronyms:        cfg.Acronyms,
+		NameOverrides:   cfg.NameOverrides,
 		ResolveDeps:     cfg.ResolveDeps,
 		IncludeProposed: cfg.IncludeProposed,
+		MinifyDocs:      cfg.MinifyDocs,
+		StrictJSONNames: cfg.StrictJSONNames,
+		Indent:          cfg.Indent,
+		Builders:        builders,
 		Source:          cfg.Source,
 		Ref:             cfg.Ref,
 		CommitHash:      cfg.CommitHash,
 		LSPVersion:      cfg.LSPVersion,
+		ToolVersion:     cfg.ToolVersion,
+		Timestamp:       cfg.Timestamp,
+		Logger:          cfg.Logger,
 	}
 
 	gen := New(m, internalCfg)
@@ -59,5 +98,8 @@ func (g *Generator) Generate(ctx context.Context, m *model.Model, cfg generator.
 	}
 
 	result.Add(filename, out.Groovy)
+	if cfg.Index && cfg.OutputDir != "" {
+		result.Add(generator.IndexFile, generator.MarkdownIndex("LSP Types", generator.Index(m, cfg)))
+	}
 	return result, nil
 }
@@ -10,6 +10,7 @@ import (
 	"cmp"
 	"fmt"
 	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/albertocavalcante/lspls/internal/lspbase"
@@ -44,7 +45,10 @@ func (g *Codegen) groovyTypeInner(t *model.Type) string {
 		if mapped, ok := DefaultMappings[t.Name]; ok {
 			return mapped
 		}
-		return typeName(t.Name)
+		if a, ok := g.aliases[t.Name]; ok {
+			return g.aliasType(a)
+		}
+		return g.typeName(t.Name)
 
 	case "array":
 		return "List<" + g.groovyType(t.Element, false) + ">"
@@ -58,25 +62,63 @@ func (g *Codegen) groovyTypeInner(t *model.Type) string {
 		return fmt.Sprintf("Map<%s, %s>", keyType, valType)
 
 	case "literal":
+		g.log.Warn("literal type degraded to Object", "line", t.Line)
 		return "Object"
 
 	case "stringLiteral":
 		return "String"
 
+	case "integerLiteral":
+		return "int"
+
+	case "booleanLiteral":
+		return "boolean"
+
 	case "or":
 		return g.getOrType(t)
 
 	case "and":
+		g.log.Warn("intersection type degraded to Object", "line", t.Line)
 		return "Object"
 
 	case "tuple":
+		g.log.Warn("tuple type degraded to List<Object>", "line", t.Line)
 		return "List<Object>"
 
 	default:
+		g.log.Warn("unknown type kind degraded to Object", "kind", t.Kind, "line", t.Line)
 		return "Object"
 	}
 }
 
+// aliasType returns the Groovy type a reference to alias a stands for: the
+// type of its target, since Groovy has no typealias. An alias that refers
+// back to itself degrades to Object.
+func (g *Codegen) aliasType(a *model.TypeAlias) string {
+	if g.resolving[a.Name] {
+		g.log.Warn("recursive type alias degraded to Object", "name", a.Name, "line", a.Line)
+		return "Object"
+	}
+	g.resolving[a.Name] = true
+	defer delete(g.resolving, a.Name)
+	return g.groovyType(a.Type, false)
+}
+
+// aliasTarget returns t with references to type aliases followed to their
+// target types, stopping at an alias seen before.
+func (g *Codegen) aliasTarget(t *model.Type) *model.Type {
+	seen := make(map[string]bool)
+	for t.Kind == "reference" && !seen[t.Name] {
+		a, ok := g.aliases[t.Name]
+		if !ok {
+			break
+		}
+		seen[t.Name] = true
+		t = a.Type
+	}
+	return t
+}
+
 // groovyBaseType maps an LSP base type name to a Groovy type.
 func groovyBaseType(t *model.Type) string {
 	switch t.Name {
@@ -113,7 +155,7 @@ func (g *Codegen) typeNameForIdent(t *model.Type) string {
 	case "base":
 		return groovyIdentBaseType(t)
 	case "reference":
-		return typeName(t.Name)
+		return g.casing.ExportName(t.Name)
 	case "array":
 		return "Arr" + g.typeNameForIdent(t.Element)
 	case "map":
@@ -127,6 +169,10 @@ func (g *Codegen) typeNameForIdent(t *model.Type) string {
 		return "Literal"
 	case "stringLiteral":
 		return "String"
+	case "integerLiteral":
+		return "int"
+	case "booleanLiteral":
+		return "boolean"
 	case "or":
 		return "Union"
 	case "and":
@@ -166,8 +212,9 @@ func groovyIdentBaseType(t *model.Type) string {
 
 // unionVariantInfo describes one branch of a union wrapper class.
 type unionVariantInfo struct {
-	identName  string // identifier-safe name (for discrimination)
-	groovyType string // full Groovy type
+	identName     string // identifier-safe name (for discrimination)
+	groovyType    string // full Groovy type
+	discriminator string // condition on node selecting this variant, if any
 }
 
 // getOrType returns the Groovy type name for an "or" union type, registering
@@ -200,8 +247,9 @@ func (g *Codegen) getOrType(t *model.Type) string {
 	var pairs []unionVariantInfo
 	for _, item := range nonNullItems {
 		pairs = append(pairs, unionVariantInfo{
-			identName:  g.typeNameForIdent(item),
-			groovyType: g.groovyType(item, false),
+			identName:     g.typeNameForIdent(item),
+			groovyType:    g.groovyType(item, false),
+			discriminator: g.discriminator(item),
 		})
 	}
 
@@ -224,7 +272,7 @@ func (g *Codegen) getOrType(t *model.Type) string {
 		identNames = append(identNames, p.identName)
 	}
 
-	unionName := "Or_" + strings.Join(identNames, "_")
+	unionName := g.config.TypePrefix + "Or_" + strings.Join(identNames, "_") + g.config.TypeSuffix
 
 	if _, exists := g.unionTypes.m[unionName]; !exists {
 		g.unionTypes.set(unionName, unionTypeInfo{
@@ -236,19 +284,27 @@ func (g *Codegen) getOrType(t *model.Type) string {
 	return unionName
 }
 
-// typeName converts an LSP type name to a valid Groovy class name.
-func typeName(name string) string {
-	return lspbase.ExportName(name)
+// typeName converts an LSP type name to a valid Groovy class name,
+// with TypePrefix and TypeSuffix applied.
+func (g *Codegen) typeName(name string) string {
+	return g.config.TypePrefix + g.casing.ExportName(name) + g.config.TypeSuffix
+}
+
+// stringLiteral returns s as a Groovy string literal. Unlike a Go literal,
+// "$" must be escaped, since it would make a GString, which annotations do
+// not accept.
+func stringLiteral(s string) string {
+	return strings.ReplaceAll(strconv.Quote(s), "$", `\$`)
 }
 
 // fieldName converts an LSP property name to a Groovy property name (camelCase).
-func fieldName(name string) string {
-	return lspbase.StripMeta(name)
+func (g *Codegen) fieldName(name string) string {
+	return g.casing.FieldName(name)
 }
 
 // enumConstName converts an enum value name to a Groovy enum constant (SCREAMING_SNAKE).
-func enumConstName(name string) string {
-	return lspbase.CamelToScreamingSnake(name)
+func (g *Codegen) enumConstName(name string) string {
+	return g.casing.CamelToScreamingSnake(name)
 }
 
 // isPrimitiveGroovyType reports whether a Groovy type is a primitive/boxed type.
@@ -274,3 +330,32 @@ func boxPrimitive(t string) string {
 		return t
 	}
 }
+
+// discriminator returns a condition on the JSON node that identifies item
+// within a union, when item references a structure with a literal-valued
+// property. It returns "" otherwise.
+func (g *Codegen) discriminator(item *model.Type) string {
+	item = g.aliasTarget(item)
+	if item.Kind != "reference" {
+		return ""
+	}
+	for _, s := range g.model.Structures {
+		if s.Name != item.Name {
+			continue
+		}
+		p, ok := s.Discriminator()
+		if !ok {
+			return ""
+		}
+		field := fmt.Sprintf("node.path('%s')", p.Name)
+		switch p.Type.Kind {
+		case "integerLiteral":
+			return fmt.Sprintf("%s.isInt() && %s.intValue() == %v", field, field, p.Type.Value)
+		case "booleanLiteral":
+			return fmt.Sprintf("%s.isBoolean() && %s.booleanValue() == %v", field, field, p.Type.Value)
+		default:
+			return fmt.Sprintf("%s.textValue() == '%v'", field, p.Type.Value)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,133 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+// Package jsonschema generates a JSON Schema document from the LSP
+// specification, with one definition per structure, enumeration, and type
+// alias.
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/albertocavalcante/lspls/generator"
+	"github.com/albertocavalcante/lspls/model"
+)
+
+// Dialect is the JSON Schema version of the generated document.
+const Dialect = "https://json-schema.org/draft/2020-12/schema"
+
+// Codegen generates a JSON Schema document from the LSP model.
+type Codegen struct {
+	model      *model.Model
+	config     Config
+	log        *slog.Logger
+	typeFilter map[string]bool // nil = all types
+}
+
+// New creates a new JSON Schema Codegen.
+func New(m *model.Model, cfg Config) *Codegen {
+	c := &Codegen{
+		model:  m,
+		config: cfg,
+		log:    cfg.Logger,
+	}
+	if c.log == nil {
+		c.log = slog.New(slog.DiscardHandler)
+	}
+	if len(cfg.Types) > 0 {
+		c.typeFilter = make(map[string]bool)
+		for _, t := range cfg.Types {
+			c.typeFilter[t] = true
+		}
+	}
+	return c
+}
+
+// Output contains the generated schema document.
+type Output struct {
+	Schema []byte
+}
+
+// Generate produces the JSON Schema document. Every type is a member of
+// $defs; the document itself has no root type.
+func (g *Codegen) Generate() (*Output, error) {
+	if g.typeFilter != nil && g.config.ResolveDeps {
+		g.typeFilter = generator.ResolveDeps(g.model, g.typeFilter, g.config.IncludeProposed)
+	}
+
+	b := &Builder{
+		RefPrefix:       "#/$defs/",
+		IncludeProposed: g.config.IncludeProposed,
+		MinifyDocs:      g.config.MinifyDocs,
+	}
+	defs := b.Definitions(g.model, g.shouldInclude)
+	g.log.Debug("generated schema definitions", "count", len(defs))
+	doc := Schema{
+		"$schema":  Dialect,
+		"$comment": Comment(g.config.Source, g.config.Ref, g.config.CommitHash, g.config.LSPVersion, g.config.ToolVersion, g.config.Timestamp),
+		"$defs":    defs,
+	}
+	out, err := Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	return &Output{Schema: out}, nil
+}
+
+// shouldInclude returns whether a type should be included in the document.
+func (g *Codegen) shouldInclude(name string, proposed bool) bool {
+	if proposed && !g.config.IncludeProposed {
+		return false
+	}
+	if g.typeFilter != nil && !g.typeFilter[name] {
+		return false
+	}
+	return true
+}
+
+// Comment returns the generated-code notice for a document, naming the
+// specification it was generated from and, when set, the lspls version and
+// generation time. JSON has no comments, so targets place it in a $comment
+// or description keyword.
+func Comment(source, ref, commit, lspVersion, toolVersion string, timestamp time.Time) string {
+	parts := []string{"Code generated by lspls. DO NOT EDIT."}
+	if source != "" {
+		parts = append(parts, fmt.Sprintf("Source: %s", source))
+	}
+	if ref != "" {
+		parts = append(parts, fmt.Sprintf("Ref: %s", ref))
+	}
+	if commit != "" {
+		parts = append(parts, fmt.Sprintf("Commit: %s", commit))
+	}
+	if lspVersion != "" {
+		parts = append(parts, fmt.Sprintf("LSP Version: %s", lspVersion))
+	}
+	if toolVersion != "" {
+		parts = append(parts, fmt.Sprintf("Generator: lspls %s", toolVersion))
+	}
+	if !timestamp.IsZero() {
+		parts = append(parts, fmt.Sprintf("Generated: %s", timestamp.UTC().Format(time.RFC3339)))
+	}
+	return strings.Join(parts, "\n")
+}
+
+// Marshal encodes v as indented JSON followed by a newline. HTML characters
+// in documentation are kept as is.
+func Marshal(v any) ([]byte, error) {
+	var buf strings.Builder
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return nil, fmt.Errorf("encode schema: %w", err)
+	}
+	return []byte(buf.String()), nil
+}
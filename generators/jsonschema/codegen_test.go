@@ -0,0 +1,159 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package jsonschema
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/albertocavalcante/lspls/internal/testutil"
+	"github.com/albertocavalcante/lspls/model"
+	"golang.org/x/tools/txtar"
+)
+
+func TestBuilderType(t *testing.T) {
+	b := &Builder{RefPrefix: "#/$defs/"}
+	tests := []struct {
+		name string
+		typ  string
+		want string
+	}{
+		{"string", `{"kind":"base","name":"string"}`, `{"type":"string"}`},
+		{"uri", `{"kind":"base","name":"DocumentUri"}`, `{"format":"uri","type":"string"}`},
+		{"reference", `{"kind":"reference","name":"Range"}`, `{"$ref":"#/$defs/Range"}`},
+		{"array", `{"kind":"array","element":{"kind":"base","name":"boolean"}}`, `{"items":{"type":"boolean"},"type":"array"}`},
+		{"nullable", `{"kind":"or","items":[{"kind":"base","name":"decimal"},{"kind":"base","name":"null"}]}`, `{"anyOf":[{"type":"number"},{"type":"null"}]}`},
+		{"integerLiteral", `{"kind":"integerLiteral","value":2}`, `{"const":2}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var typ model.Type
+			if err := json.Unmarshal([]byte(tt.typ), &typ); err != nil {
+				t.Fatal(err)
+			}
+			got, err := json.Marshal(b.Type(&typ))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Type(%s) = %s, want %s", tt.typ, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestGenerateValidJSON checks that the document parses and defines every
+// type of the golden input.
+func TestGenerateValidJSON(t *testing.T) {
+	ar, err := txtar.ParseFile(filepath.Join("testdata", "definitions.txtar"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tc, err := testutil.ParseCase("definitions", ar)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files, err := runCodegen(tc.Input, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var doc struct {
+		Schema string                     `json:"$schema"`
+		Defs   map[string]json.RawMessage `json:"$defs"`
+	}
+	if err := json.Unmarshal(files["protocol.schema.json"], &doc); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+	if doc.Schema != Dialect {
+		t.Errorf("$schema = %q, want %q", doc.Schema, Dialect)
+	}
+	var names []string
+	for name := range doc.Defs {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	want := []string{"CodeActionKind", "CreateFile", "DiagnosticSeverity", "Position", "ProgressToken", "Range", "TextDocumentIdentifier", "VersionedTextDocumentIdentifier"}
+	if !slices.Equal(names, want) {
+		t.Errorf("$defs = %v, want %v", names, want)
+	}
+}
+
+// TestCodegen runs txtar-based integration tests.
+func TestCodegen(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("testdata", "*.txtar"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Skip("no txtar files found in testdata/")
+	}
+
+	for _, file := range files {
+		name := strings.TrimSuffix(filepath.Base(file), ".txtar")
+		t.Run(name, func(t *testing.T) {
+			ar, err := txtar.ParseFile(file)
+			if err != nil {
+				t.Fatalf("parse txtar: %v", err)
+			}
+
+			tc, err := testutil.ParseCase(name, ar)
+			if err != nil {
+				t.Fatalf("parse case: %v", err)
+			}
+
+			if *update {
+				got, err := runCodegen(tc.Input, tc.Flags)
+				if err != nil {
+					t.Fatalf("generate: %v", err)
+				}
+				content := testutil.FormatArchive(testutil.UpdateArchive(ar, got))
+				if err := os.WriteFile(file, content, 0o644); err != nil {
+					t.Fatalf("write updated file: %v", err)
+				}
+				t.Logf("updated %s", file)
+				return
+			}
+
+			tc.Run(t, runCodegen)
+		})
+	}
+}
+
+var update = flag.Bool("update", false, "update golden files")
+
+// runCodegen generates a schema document from input JSON.
+func runCodegen(input []byte, flags []string) (map[string][]byte, error) {
+	var m model.Model
+	if err := json.Unmarshal(input, &m); err != nil {
+		return nil, err
+	}
+
+	cfg := Config{
+		IncludeProposed: slices.Contains(flags, "proposed"),
+		MinifyDocs:      slices.Contains(flags, "minify-docs"),
+	}
+	for _, f := range flags {
+		if typesStr, ok := strings.CutPrefix(f, "types="); ok {
+			cfg.Types = strings.Split(typesStr, ";")
+		}
+		if val, ok := strings.CutPrefix(f, "resolve-deps="); ok {
+			cfg.ResolveDeps = val == "true"
+		}
+	}
+
+	out, err := New(&m, cfg).Generate()
+	if err != nil {
+		return nil, err
+	}
+	return map[string][]byte{"protocol.schema.json": out.Schema}, nil
+}
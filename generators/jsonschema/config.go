@@ -0,0 +1,42 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package jsonschema
+
+import (
+	"log/slog"
+	"time"
+)
+
+// Config holds configuration for JSON Schema generation.
+type Config struct {
+	// Types to include (empty means all).
+	Types []string
+
+	// ResolveDeps includes transitively referenced types.
+	ResolveDeps bool
+
+	// IncludeProposed generates types marked as proposed.
+	IncludeProposed bool
+
+	// MinifyDocs omits description keywords.
+	MinifyDocs bool
+
+	// Source metadata for the $comment keyword.
+	Source     string
+	Ref        string
+	CommitHash string
+	LSPVersion string
+
+	// ToolVersion and Timestamp, when set, add the lspls version and the
+	// generation time to header comments.
+	ToolVersion string
+	Timestamp   time.Time
+
+	// Logger receives per-type progress at debug level. If nil, nothing is
+	// logged.
+	Logger *slog.Logger
+}
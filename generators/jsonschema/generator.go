@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package jsonschema
+
+import (
+	"context"
+
+	"github.com/albertocavalcante/lspls/generator"
+	"github.com/albertocavalcante/lspls/model"
+)
+
+// Generator implements [generator.Generator] for JSON Schema generation.
+type Generator struct{}
+
+// NewGenerator creates a new JSON Schema generator.
+func NewGenerator() *Generator {
+	return &Generator{}
+}
+
+// Metadata returns information about this generator.
+func (g *Generator) Metadata() generator.Metadata {
+	return generator.Metadata{
+		Name:           "jsonschema",
+		Version:        "1.0.0",
+		Description:    "Generate a JSON Schema (draft 2020-12) document from LSP specification",
+		FileExtensions: []string{".json"},
+		URL:            "https://github.com/albertocavalcante/lspls",
+	}
+}
+
+// Describe returns the options, outputs, and requirements of this generator.
+func (g *Generator) Describe() generator.Description {
+	return generator.Description{
+		Outputs: []string{
+			"protocol.schema.json: one definition per type",
+			"index.md: type index (directory output, --index)",
+		},
+		Requirements: []string{
+			"a JSON Schema draft 2020-12 validator",
+		},
+	}
+}
+
+// Generate produces the schema document from the LSP model.
+func (g *Generator) Generate(ctx context.Context, m *model.Model, cfg generator.Config) (*generator.Output, error) {
+	m, err := generator.ResolveEnumBases(m)
+	if err != nil {
+		return nil, err
+	}
+	internalCfg := Config{
+		Types:           cfg.Types,
+		ResolveDeps:     cfg.ResolveDeps,
+		IncludeProposed: cfg.IncludeProposed,
+		MinifyDocs:      cfg.MinifyDocs,
+		Source:          cfg.Source,
+		Ref:             cfg.Ref,
+		CommitHash:      cfg.CommitHash,
+		LSPVersion:      cfg.LSPVersion,
+		ToolVersion:     cfg.ToolVersion,
+		Timestamp:       cfg.Timestamp,
+		Logger:          cfg.Logger,
+	}
+
+	out, err := New(m, internalCfg).Generate()
+	if err != nil {
+		return nil, err
+	}
+
+	result := generator.NewOutput()
+	filename := "protocol.schema.json"
+	if cfg.OutputFile != "" {
+		filename = cfg.OutputFile
+	}
+	result.Add(filename, out.Schema)
+	if cfg.Index && cfg.OutputDir != "" {
+		result.Add(generator.IndexFile, generator.MarkdownIndex("LSP Types", generator.Index(m, cfg)))
+	}
+	return result, nil
+}
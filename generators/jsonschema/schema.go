@@ -0,0 +1,207 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package jsonschema
+
+import (
+	"github.com/albertocavalcante/lspls/model"
+)
+
+// Schema is a JSON Schema object. encoding/json writes its keys sorted, so
+// marshaled schemas are deterministic.
+type Schema = map[string]any
+
+// Builder converts LSP model types into JSON Schema (draft 2020-12) objects.
+// OpenAPI 3.1 schema objects use the same vocabulary, so the OpenAPI target
+// shares it and only changes RefPrefix.
+type Builder struct {
+	// RefPrefix is prepended to type names in $ref values, such as
+	// "#/$defs/" or "#/components/schemas/".
+	RefPrefix string
+
+	// IncludeProposed keeps proposed properties and enum values.
+	IncludeProposed bool
+
+	// MinifyDocs omits description keywords.
+	MinifyDocs bool
+}
+
+// Definitions returns a schema for every structure, enumeration, and type
+// alias of m for which include reports true, keyed by type name.
+func (b *Builder) Definitions(m *model.Model, include func(name string, proposed bool) bool) map[string]Schema {
+	defs := make(map[string]Schema)
+	for _, s := range m.Structures {
+		if include(s.Name, s.Proposed) {
+			defs[s.Name] = b.Structure(s)
+		}
+	}
+	for _, e := range m.Enumerations {
+		if include(e.Name, e.Proposed) {
+			defs[e.Name] = b.Enumeration(e)
+		}
+	}
+	for _, a := range m.TypeAliases {
+		if include(a.Name, a.Proposed) {
+			defs[a.Name] = b.TypeAlias(a)
+		}
+	}
+	return defs
+}
+
+// Structure returns the schema of s. Properties are listed under
+// "properties" and non-optional ones under "required". Extended and mixed-in
+// structures are combined with allOf.
+func (b *Builder) Structure(s *model.Structure) Schema {
+	own := b.object(s.Properties)
+	var bases []any
+	for _, t := range s.Extends {
+		bases = append(bases, b.Type(t))
+	}
+	for _, t := range s.Mixins {
+		bases = append(bases, b.Type(t))
+	}
+
+	schema := own
+	if len(bases) > 0 {
+		schema = Schema{"allOf": append(bases, own)}
+	}
+	b.describe(schema, s.Documentation)
+	return schema
+}
+
+// Enumeration returns the schema of e. Enumerations that support custom
+// values only constrain the base type and list their values as examples.
+func (b *Builder) Enumeration(e *model.Enumeration) Schema {
+	schema := b.Type(e.Type)
+	var values []any
+	for _, v := range e.Values {
+		if v.Proposed && !b.IncludeProposed {
+			continue
+		}
+		values = append(values, v.Value)
+	}
+	if e.SupportsCustomValues {
+		schema["examples"] = values
+	} else {
+		schema["enum"] = values
+	}
+	b.describe(schema, e.Documentation)
+	return schema
+}
+
+// TypeAlias returns the schema of a's type, with a's documentation.
+func (b *Builder) TypeAlias(a *model.TypeAlias) Schema {
+	schema := b.Type(a.Type)
+	b.describe(schema, a.Documentation)
+	if a.Deprecated != "" {
+		schema["deprecated"] = true
+	}
+	return schema
+}
+
+// Type returns the schema of t. A nil type, or one of an unknown kind,
+// admits any value.
+func (b *Builder) Type(t *model.Type) Schema {
+	if t == nil {
+		return Schema{}
+	}
+	switch t.Kind {
+	case "base":
+		return baseSchema(t.Name)
+	case "reference":
+		return Schema{"$ref": b.RefPrefix + t.Name}
+	case "array":
+		return Schema{"type": "array", "items": b.Type(t.Element)}
+	case "map":
+		value, _ := t.Value.(*model.Type)
+		return Schema{"type": "object", "additionalProperties": b.Type(value)}
+	case "and":
+		return Schema{"allOf": b.types(t.Items)}
+	case "or":
+		return Schema{"anyOf": b.types(t.Items)}
+	case "tuple":
+		return Schema{
+			"type":        "array",
+			"prefixItems": b.types(t.Items),
+			"minItems":    len(t.Items),
+			"maxItems":    len(t.Items),
+		}
+	case "literal":
+		lit, _ := t.Value.(model.Literal)
+		return b.object(lit.Properties)
+	case "stringLiteral", "integerLiteral", "booleanLiteral":
+		return Schema{"const": t.Value}
+	}
+	return Schema{}
+}
+
+// types returns the schemas of items.
+func (b *Builder) types(items []*model.Type) []any {
+	schemas := make([]any, len(items))
+	for i, item := range items {
+		schemas[i] = b.Type(item)
+	}
+	return schemas
+}
+
+// object returns an object schema with the given properties.
+func (b *Builder) object(props []model.Property) Schema {
+	properties := make(map[string]any)
+	var required []string
+	for _, p := range props {
+		if p.Proposed && !b.IncludeProposed {
+			continue
+		}
+		schema := b.Type(p.Type)
+		b.describe(schema, p.Documentation)
+		if p.Deprecated != "" {
+			schema["deprecated"] = true
+		}
+		properties[p.Name] = schema
+		if !p.Optional {
+			required = append(required, p.Name)
+		}
+	}
+
+	schema := Schema{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// describe sets the description keyword of schema to doc, unless doc is
+// empty or documentation is minified.
+func (b *Builder) describe(schema Schema, doc string) {
+	if doc != "" && !b.MinifyDocs {
+		schema["description"] = doc
+	}
+}
+
+// baseSchema returns the schema of an LSP base type. The integer ranges
+// follow the specification: integer is a signed 32-bit value and uinteger
+// is non-negative and below 2^31.
+func baseSchema(name string) Schema {
+	switch name {
+	case "string":
+		return Schema{"type": "string"}
+	case "integer":
+		return Schema{"type": "integer", "format": "int32"}
+	case "uinteger":
+		return Schema{"type": "integer", "minimum": 0, "maximum": 2147483647}
+	case "decimal":
+		return Schema{"type": "number"}
+	case "boolean":
+		return Schema{"type": "boolean"}
+	case "null":
+		return Schema{"type": "null"}
+	case "URI", "DocumentUri":
+		return Schema{"type": "string", "format": "uri"}
+	case "RegExp":
+		return Schema{"type": "string", "format": "regex"}
+	}
+	return Schema{}
+}
@@ -9,7 +9,8 @@
 // The generated code uses idiomatic Kotlin patterns:
 //   - data class for LSP structures
 //   - enum class with explicit values for enumerations
-//   - sealed class with @Serializable subtypes for union ("or") types
+//   - sealed class with @Serializable subtypes for union ("or") types, or
+//     a sealed interface implemented by the member data classes
 //   - typealias for LSP type aliases
 //   - kotlinx.serialization annotations for JSON round-tripping
 package kotlin
@@ -17,8 +18,10 @@ package kotlin
 import (
 	"bytes"
 	"fmt"
+	"log/slog"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/albertocavalcante/lspls/generator"
 	"github.com/albertocavalcante/lspls/internal/lspbase"
@@ -29,6 +32,10 @@ import (
 type Codegen struct {
 	model  *model.Model
 	config Config
+	log    *slog.Logger
+
+	// casing derives identifiers from LSP names.
+	casing *lspbase.Casing
 
 	types      *orderedMap[string]
 	typeFilter map[string]bool
@@ -36,6 +43,10 @@ type Codegen struct {
 	// sealedTypes tracks generated sealed classes to avoid duplicates.
 	sealedTypes *orderedMap[sealedTypeInfo]
 
+	// sealedSupers maps structure names to the sealed interfaces their
+	// data classes implement, under SealedInterfaces.
+	sealedSupers map[string][]string
+
 	proposedTypes map[string]bool
 }
 
@@ -43,6 +54,7 @@ type Codegen struct {
 type sealedTypeInfo struct {
 	name     string              // e.g. "Or_Int_String"
 	variants []sealedVariantInfo // sorted variant descriptors
+	iface    bool                // sealed interface implemented by the member data classes
 }
 
 // Output contains the generated Kotlin content.
@@ -57,7 +69,13 @@ func New(m *model.Model, cfg Config) *Codegen {
 		config:        cfg,
 		types:         newOrderedMap[string](),
 		sealedTypes:   newOrderedMap[sealedTypeInfo](),
+		sealedSupers:  make(map[string][]string),
 		proposedTypes: buildProposedCache(m),
+		casing:        lspbase.NewCasing(cfg.Acronyms, cfg.NameOverrides),
+	}
+	c.log = cfg.Logger
+	if c.log == nil {
+		c.log = slog.New(slog.DiscardHandler)
 	}
 	if len(cfg.Types) > 0 {
 		c.typeFilter = make(map[string]bool)
@@ -84,6 +102,11 @@ func buildProposedCache(m *model.Model) map[string]bool {
 
 // Generate produces the Kotlin source file.
 func (g *Codegen) Generate() (*Output, error) {
+	switch g.config.EncodeDefault {
+	case "", "never", "always":
+	default:
+		return nil, fmt.Errorf("encode default %q: want never or always", g.config.EncodeDefault)
+	}
 	if g.typeFilter != nil && g.config.ResolveDeps {
 		g.typeFilter = generator.ResolveDeps(g.model, g.typeFilter, g.config.IncludeProposed)
 	}
@@ -92,6 +115,10 @@ func (g *Codegen) Generate() (*Output, error) {
 		if !g.shouldInclude(s.Name, s.Proposed) {
 			continue
 		}
+		g.log.Debug("generating structure", "name", s.Name)
+		if err := g.checkJSONNames(s); err != nil {
+			return nil, err
+		}
 		g.generateStructure(s)
 	}
 
@@ -99,6 +126,7 @@ func (g *Codegen) Generate() (*Output, error) {
 		if !g.shouldInclude(e.Name, e.Proposed) {
 			continue
 		}
+		g.log.Debug("generating enumeration", "name", e.Name)
 		g.generateEnumeration(e)
 	}
 
@@ -106,10 +134,19 @@ func (g *Codegen) Generate() (*Output, error) {
 		if !g.shouldInclude(a.Name, a.Proposed) {
 			continue
 		}
+		g.log.Debug("generating type alias", "name", a.Name)
 		g.generateTypeAlias(a)
 	}
 
-	return &Output{Kotlin: g.emit()}, nil
+	// The sealed interfaces a data class implements are only known once
+	// every union has been seen, so their members are generated again.
+	for _, s := range g.model.Structures {
+		if len(g.sealedSupers[s.Name]) > 0 && g.shouldInclude(s.Name, s.Proposed) {
+			g.generateStructure(s)
+		}
+	}
+
+	return &Output{Kotlin: lspbase.Reindent(g.emit(), "    ", g.config.Indent)}, nil
 }
 
 func (g *Codegen) shouldInclude(name string, proposed bool) bool {
@@ -122,6 +159,24 @@ func (g *Codegen) shouldInclude(name string, proposed bool) bool {
 	return true
 }
 
+// docs returns doc, or the empty string when documentation is minified.
+func (g *Codegen) docs(doc string) string {
+	if g.config.MinifyDocs {
+		return ""
+	}
+	return doc
+}
+
+// deprecated returns msg, the deprecation message of a structure,
+// enumeration, or property, when documentation is minified, and the empty
+// string otherwise, when the documentation carries its own @deprecated tag.
+func (g *Codegen) deprecated(msg string) string {
+	if !g.config.MinifyDocs {
+		return ""
+	}
+	return msg
+}
+
 func (g *Codegen) isProposed(name string) bool {
 	return g.proposedTypes[name]
 }
@@ -131,30 +186,109 @@ func (g *Codegen) isProposed(name string) bool {
 func (g *Codegen) generateStructure(s *model.Structure) {
 	var buf bytes.Buffer
 
-	writeKdoc(&buf, s.Documentation, s.Since, "")
+	writeKdoc(&buf, g.docs(s.Documentation), s.Since, g.deprecated(s.Deprecated))
 
 	// Collect properties (including inherited ones from extends/mixins)
 	props := g.collectProperties(s)
 
+	// Sealed interfaces of the unions the structure is a member of
+	supers := ""
+	if names := g.sealedSupers[s.Name]; len(names) > 0 {
+		supers = " : " + strings.Join(slices.Sorted(slices.Values(names)), ", ")
+	}
+
 	if len(props) == 0 {
 		// Empty class (no properties)
 		fmt.Fprintf(&buf, "@Serializable\n")
-		fmt.Fprintf(&buf, "class %s\n", typeName(s.Name))
+		fmt.Fprintf(&buf, "class %s%s\n", g.typeName(s.Name), supers)
 	} else {
 		fmt.Fprintf(&buf, "@Serializable\n")
-		fmt.Fprintf(&buf, "data class %s(\n", typeName(s.Name))
+		fmt.Fprintf(&buf, "data class %s(\n", g.typeName(s.Name))
 		for i, p := range props {
-			g.generateProperty(&buf, &p, i == len(props)-1)
+			g.generateProperty(&buf, &p, s.Since, i == len(props)-1)
+		}
+		if g.wantsBuilder(props) {
+			fmt.Fprintf(&buf, ")%s {\n", supers)
+			g.generateBuilder(&buf, s.Name, props)
+			buf.WriteString("}\n")
+		} else {
+			fmt.Fprintf(&buf, ")%s\n", supers)
 		}
-		buf.WriteString(")\n")
 	}
 
 	g.types.set(s.Name, buf.String())
 }
 
-// collectProperties gathers direct properties. Extends/mixins are flattened
-// into the data class because Kotlin data classes cannot extend other data classes.
+// wantsBuilder reports whether a data class with props gets a builder:
+// Builders is set and at least that many properties are required.
+func (g *Codegen) wantsBuilder(props []model.Property) bool {
+	if g.config.Builders <= 0 {
+		return false
+	}
+	required := 0
+	for _, p := range props {
+		if !p.Optional {
+			required++
+		}
+	}
+	return required >= g.config.Builders
+}
+
+// generateBuilder writes the body of the data class for name: a Builder
+// with a nullable var per property, whose build() fails with the JSON name
+// of a required property left unset, and a companion builder { } function.
+func (g *Codegen) generateBuilder(buf *bytes.Buffer, name string, props []model.Property) {
+	typeName := g.typeName(name)
+
+	buf.WriteString("    class Builder {\n")
+	for _, p := range props {
+		kt := g.kotlinType(p.Type, false)
+		if !strings.HasSuffix(kt, "?") {
+			kt += "?"
+		}
+		fmt.Fprintf(buf, "        var %s: %s = null\n", g.fieldName(p.Name), kt)
+	}
+	buf.WriteString("\n")
+	fmt.Fprintf(buf, "        fun build(): %s = %s(\n", typeName, typeName)
+	for i, p := range props {
+		field := g.fieldName(p.Name)
+		value := field
+		if !p.Optional && !strings.HasSuffix(g.kotlinType(p.Type, false), "?") {
+			value = fmt.Sprintf("requireNotNull(%s) { %s }", field, stringLiteral(p.Name+" is required"))
+		}
+		fmt.Fprintf(buf, "            %s = %s", field, value)
+		if i < len(props)-1 {
+			buf.WriteString(",")
+		}
+		buf.WriteString("\n")
+	}
+	buf.WriteString("        )\n")
+	buf.WriteString("    }\n")
+	buf.WriteString("\n")
+	buf.WriteString("    companion object {\n")
+	fmt.Fprintf(buf, "        fun builder(block: Builder.() -> Unit): %s = Builder().apply(block).build()\n", typeName)
+	buf.WriteString("    }\n")
+}
+
+// collectProperties returns the properties of s with those of its extends
+// and mixins flattened in, each JSON name once: when a name repeats, the
+// most-derived property is kept.
 func (g *Codegen) collectProperties(s *model.Structure) []model.Property {
+	props, _ := generator.DedupProperties(g.flattenProperties(s))
+	return props
+}
+
+// checkJSONNames reports the JSON names that flattening gives more than
+// one property of s: as an error under StrictJSONNames, as a warning
+// otherwise.
+func (g *Codegen) checkJSONNames(s *model.Structure) error {
+	_, dups := generator.DedupProperties(g.flattenProperties(s))
+	return generator.CheckJSONNames(g.log, s.Name, dups, g.config.StrictJSONNames)
+}
+
+// flattenProperties gathers direct properties. Extends/mixins are flattened
+// into the data class because Kotlin data classes cannot extend other data classes.
+func (g *Codegen) flattenProperties(s *model.Structure) []model.Property {
 	var props []model.Property
 
 	// Flatten extends
@@ -162,7 +296,7 @@ func (g *Codegen) collectProperties(s *model.Structure) []model.Property {
 		if ext.Kind == "reference" {
 			for _, parent := range g.model.Structures {
 				if parent.Name == ext.Name {
-					props = append(props, g.collectProperties(parent)...)
+					props = append(props, g.flattenProperties(parent)...)
 				}
 			}
 		}
@@ -173,7 +307,7 @@ func (g *Codegen) collectProperties(s *model.Structure) []model.Property {
 		if mix.Kind == "reference" {
 			for _, parent := range g.model.Structures {
 				if parent.Name == mix.Name {
-					props = append(props, g.collectProperties(parent)...)
+					props = append(props, g.flattenProperties(parent)...)
 				}
 			}
 		}
@@ -190,15 +324,26 @@ func (g *Codegen) collectProperties(s *model.Structure) []model.Property {
 	return props
 }
 
-func (g *Codegen) generateProperty(buf *bytes.Buffer, p *model.Property, last bool) {
+// generateProperty writes the constructor parameter for p. parentSince is
+// the enclosing structure's version; a property added later gets its own
+// @since line.
+func (g *Codegen) generateProperty(buf *bytes.Buffer, p *model.Property, parentSince string, last bool) {
 	// KDoc for property
-	if p.Documentation != "" {
-		for line := range strings.SplitSeq(p.Documentation, "\n") {
-			fmt.Fprintf(buf, "    // %s\n", line)
+	doc := g.docs(p.Documentation)
+	if doc != "" {
+		lspbase.WriteComment(buf, "    //", doc)
+	}
+	if since := lspbase.MemberSince(p.Since, parentSince, doc); since != "" {
+		if doc != "" {
+			buf.WriteString("    //\n")
 		}
+		fmt.Fprintf(buf, "    // @since %s\n", since)
+	}
+	if deprecated := g.deprecated(p.Deprecated); deprecated != "" {
+		fmt.Fprintf(buf, "    // @deprecated %s\n", deprecated)
 	}
 
-	name := fieldName(p.Name)
+	name := g.fieldName(p.Name)
 	kt := g.kotlinType(p.Type, false)
 
 	// Determine if field needs @SerialName (when Kotlin name differs from JSON key)
@@ -206,11 +351,14 @@ func (g *Codegen) generateProperty(buf *bytes.Buffer, p *model.Property, last bo
 	needsSerialName := name != jsonName
 
 	if needsSerialName {
-		fmt.Fprintf(buf, "    @SerialName(%q)\n", jsonName)
+		fmt.Fprintf(buf, "    @SerialName(%s)\n", stringLiteral(jsonName))
 	}
 
 	// Optional fields get a default of null and nullable type
 	if p.Optional {
+		if mode := g.encodeDefaultMode(); mode != "" {
+			fmt.Fprintf(buf, "    @EncodeDefault(EncodeDefault.Mode.%s)\n", mode)
+		}
 		// If the type is already nullable, don't double-up
 		if !strings.HasSuffix(kt, "?") {
 			kt += "?"
@@ -226,12 +374,43 @@ func (g *Codegen) generateProperty(buf *bytes.Buffer, p *model.Property, last bo
 	buf.WriteString("\n")
 }
 
+// encodeDefaultMode returns the EncodeDefault.Mode constant for optional
+// properties, or "" when they are not annotated.
+func (g *Codegen) encodeDefaultMode() string {
+	switch g.config.EncodeDefault {
+	case "never":
+		return "NEVER"
+	case "always":
+		return "ALWAYS"
+	}
+	return ""
+}
+
+// usesEncodeDefault reports whether any generated property is annotated
+// with @EncodeDefault.
+func (g *Codegen) usesEncodeDefault() bool {
+	if g.encodeDefaultMode() == "" {
+		return false
+	}
+	for _, s := range g.model.Structures {
+		if !g.shouldInclude(s.Name, s.Proposed) {
+			continue
+		}
+		for _, p := range g.collectProperties(s) {
+			if p.Optional {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // ── Enumeration → enum class ────────────────────────────────────────
 
 func (g *Codegen) generateEnumeration(e *model.Enumeration) {
 	var buf bytes.Buffer
 
-	writeKdoc(&buf, e.Documentation, e.Since, "")
+	writeKdoc(&buf, g.docs(e.Documentation), e.Since, g.deprecated(e.Deprecated))
 
 	baseType := kotlinBaseType(e.Type)
 	isString := baseType == "String"
@@ -248,14 +427,13 @@ func (g *Codegen) generateEnumeration(e *model.Enumeration) {
 	if isString {
 		// String enum: use @Serializable enum with @SerialName on each entry
 		fmt.Fprintf(&buf, "@Serializable\n")
-		fmt.Fprintf(&buf, "enum class %s {\n", typeName(e.Name))
+		fmt.Fprintf(&buf, "enum class %s {\n", g.typeName(e.Name))
 		for i, v := range values {
-			if v.Documentation != "" {
-				writeIndentedKdoc(&buf, v.Documentation, "    ")
-			}
+			doc := g.docs(v.Documentation)
+			writeIndentedKdoc(&buf, doc, lspbase.MemberSince(v.Since, e.Since, doc), "    ")
 			strVal, _ := v.Value.(string)
-			constName := enumConstName(v.Name)
-			fmt.Fprintf(&buf, "    @SerialName(%q)\n", strVal)
+			constName := g.enumConstName(v.Name)
+			fmt.Fprintf(&buf, "    @SerialName(%s)\n", stringLiteral(strVal))
 			fmt.Fprintf(&buf, "    %s", constName)
 			if i < len(values)-1 {
 				buf.WriteString(",")
@@ -267,13 +445,12 @@ func (g *Codegen) generateEnumeration(e *model.Enumeration) {
 		buf.WriteString("}\n")
 	} else {
 		// Integer enum: enum class with explicit value property
-		fmt.Fprintf(&buf, "@Serializable(with = %sSerializer::class)\n", typeName(e.Name))
-		fmt.Fprintf(&buf, "enum class %s(val value: %s) {\n", typeName(e.Name), baseType)
+		fmt.Fprintf(&buf, "@Serializable(with = %sSerializer::class)\n", g.typeName(e.Name))
+		fmt.Fprintf(&buf, "enum class %s(val value: %s) {\n", g.typeName(e.Name), baseType)
 		for i, v := range values {
-			if v.Documentation != "" {
-				writeIndentedKdoc(&buf, v.Documentation, "    ")
-			}
-			constName := enumConstName(v.Name)
+			doc := g.docs(v.Documentation)
+			writeIndentedKdoc(&buf, doc, lspbase.MemberSince(v.Since, e.Since, doc), "    ")
+			constName := g.enumConstName(v.Name)
 			intVal := formatIntValue(v.Value)
 			fmt.Fprintf(&buf, "    %s(%s)", constName, intVal)
 			if i < len(values)-1 {
@@ -287,7 +464,7 @@ func (g *Codegen) generateEnumeration(e *model.Enumeration) {
 		// Companion object for lookup by value
 		buf.WriteString("\n")
 		fmt.Fprintf(&buf, "    companion object {\n")
-		fmt.Fprintf(&buf, "        fun fromValue(value: %s): %s =\n", baseType, typeName(e.Name))
+		fmt.Fprintf(&buf, "        fun fromValue(value: %s): %s =\n", baseType, g.typeName(e.Name))
 		fmt.Fprintf(&buf, "            entries.first { it.value == value }\n")
 		fmt.Fprintf(&buf, "    }\n")
 		buf.WriteString("}\n")
@@ -301,7 +478,7 @@ func (g *Codegen) generateEnumeration(e *model.Enumeration) {
 }
 
 func (g *Codegen) generateIntEnumSerializer(buf *bytes.Buffer, e *model.Enumeration, baseType string) {
-	name := typeName(e.Name)
+	name := g.typeName(e.Name)
 	serializerType := baseType + ".serializer()"
 
 	fmt.Fprintf(buf, "object %sSerializer : KSerializer<%s> {\n", name, name)
@@ -321,10 +498,10 @@ func (g *Codegen) generateIntEnumSerializer(buf *bytes.Buffer, e *model.Enumerat
 func (g *Codegen) generateTypeAlias(a *model.TypeAlias) {
 	var buf bytes.Buffer
 
-	writeKdoc(&buf, a.Documentation, a.Since, a.Deprecated)
+	writeKdoc(&buf, g.docs(a.Documentation), a.Since, a.Deprecated)
 
 	kt := g.kotlinType(a.Type, false)
-	fmt.Fprintf(&buf, "typealias %s = %s\n", typeName(a.Name), kt)
+	fmt.Fprintf(&buf, "typealias %s = %s\n", g.typeName(a.Name), kt)
 
 	g.types.set(a.Name, buf.String())
 }
@@ -352,6 +529,11 @@ func (g *Codegen) generateSealedType(buf *bytes.Buffer, info sealedTypeInfo) {
 	fmt.Fprintf(buf, "/**\n * Union type: %s\n */\n", strings.Join(memberTypes, " | "))
 
 	fmt.Fprintf(buf, "@Serializable(with = %sSerializer::class)\n", info.name)
+	if info.iface {
+		fmt.Fprintf(buf, "sealed interface %s\n\n", info.name)
+		g.generateSealedSerializer(buf, info)
+		return
+	}
 	fmt.Fprintf(buf, "sealed class %s {\n", info.name)
 
 	for _, v := range info.variants {
@@ -371,6 +553,13 @@ func (g *Codegen) generateSealedSerializer(buf *bytes.Buffer, info sealedTypeInf
 	fmt.Fprintf(buf, "    override fun selectDeserializer(element: JsonElement): DeserializationStrategy<%s> {\n",
 		info.name)
 
+	// Structures with a literal-valued property are selected by its value.
+	for _, v := range info.variants {
+		if v.discriminator != "" {
+			fmt.Fprintf(buf, "        if (%s) return %s\n", v.discriminator, variantSerializer(info, v))
+		}
+	}
+
 	// Build discrimination logic based on JSON element type
 	// For base-type unions (e.g. Int | String) we check the JSON primitive kind.
 	// For reference-type unions (e.g. TextEdit | AnnotatedTextEdit) we try object shape.
@@ -401,35 +590,52 @@ func (g *Codegen) generateSealedSerializer(buf *bytes.Buffer, info sealedTypeInf
 	fmt.Fprintf(buf, "}\n")
 }
 
+// variantSerializer returns the serializer of variant v of the union info:
+// the member's own serializer for a sealed interface, and the serializer of
+// its Value wrapper for a sealed class.
+func variantSerializer(info sealedTypeInfo, v sealedVariantInfo) string {
+	if info.iface {
+		return v.kotlinType + ".serializer()"
+	}
+	return info.name + "." + v.identName + "Value.serializer()"
+}
+
 func (g *Codegen) generatePrimitiveDiscrimination(buf *bytes.Buffer, info sealedTypeInfo) {
 	buf.WriteString("        return when {\n")
 	for _, v := range info.variants {
 		switch v.kotlinType {
 		case "Int", "UInt":
 			fmt.Fprintf(buf, "            element is JsonPrimitive && element.intOrNull != null ->\n")
-			fmt.Fprintf(buf, "                %s.%sValue.serializer()\n", info.name, v.identName)
+			fmt.Fprintf(buf, "                %s\n", variantSerializer(info, v))
 		case "Boolean":
 			fmt.Fprintf(buf, "            element is JsonPrimitive && element.booleanOrNull != null ->\n")
-			fmt.Fprintf(buf, "                %s.%sValue.serializer()\n", info.name, v.identName)
+			fmt.Fprintf(buf, "                %s\n", variantSerializer(info, v))
 		case "Double":
 			fmt.Fprintf(buf, "            element is JsonPrimitive && element.doubleOrNull != null ->\n")
-			fmt.Fprintf(buf, "                %s.%sValue.serializer()\n", info.name, v.identName)
+			fmt.Fprintf(buf, "                %s\n", variantSerializer(info, v))
 		default: // String and string-like
 			fmt.Fprintf(buf, "            element is JsonPrimitive && element.isString ->\n")
-			fmt.Fprintf(buf, "                %s.%sValue.serializer()\n", info.name, v.identName)
+			fmt.Fprintf(buf, "                %s\n", variantSerializer(info, v))
 		}
 	}
-	fmt.Fprintf(buf, "            else -> %s.%sValue.serializer()\n", info.name, info.variants[0].identName)
+	fmt.Fprintf(buf, "            else -> %s\n", variantSerializer(info, info.variants[0]))
 	buf.WriteString("        }\n")
 }
 
 func (g *Codegen) generateObjectDiscrimination(buf *bytes.Buffer, info sealedTypeInfo) {
-	// For multiple object types, return the first variant as default.
-	// Full field-based discrimination would require knowing the object schemas
-	// which would add significant complexity for marginal benefit —
-	// the user's deserializer can handle mismatches at runtime.
-	fmt.Fprintf(buf, "        return %s.%sValue.serializer()\n",
-		info.name, info.variants[0].identName)
+	// Variants with a discriminator were matched above. Of the rest, return
+	// the first as default; full shape-based discrimination would require
+	// knowing the object schemas, which would add significant complexity
+	// for marginal benefit — the user's deserializer can handle mismatches
+	// at runtime.
+	fallback := info.variants[0]
+	for _, v := range info.variants {
+		if v.discriminator == "" {
+			fallback = v
+			break
+		}
+	}
+	fmt.Fprintf(buf, "        return %s\n", variantSerializer(info, fallback))
 }
 
 func (g *Codegen) generateMixedDiscrimination(buf *bytes.Buffer, info sealedTypeInfo) {
@@ -438,18 +644,15 @@ func (g *Codegen) generateMixedDiscrimination(buf *bytes.Buffer, info sealedType
 	for _, v := range info.variants {
 		switch {
 		case strings.HasPrefix(v.kotlinType, "List<"):
-			fmt.Fprintf(buf, "            is JsonArray -> %s.%sValue.serializer()\n",
-				info.name, v.identName)
+			fmt.Fprintf(buf, "            is JsonArray -> %s\n", variantSerializer(info, v))
 		case isPrimitiveKotlinType(v.kotlinType):
-			fmt.Fprintf(buf, "            is JsonPrimitive -> %s.%sValue.serializer()\n",
-				info.name, v.identName)
+			fmt.Fprintf(buf, "            is JsonPrimitive -> %s\n", variantSerializer(info, v))
 		default:
-			fmt.Fprintf(buf, "            is JsonObject -> %s.%sValue.serializer()\n",
-				info.name, v.identName)
+			fmt.Fprintf(buf, "            is JsonObject -> %s\n", variantSerializer(info, v))
 		}
 	}
 
-	fmt.Fprintf(buf, "            else -> %s.%sValue.serializer()\n", info.name, info.variants[0].identName)
+	fmt.Fprintf(buf, "            else -> %s\n", variantSerializer(info, info.variants[0]))
 	buf.WriteString("        }\n")
 }
 
@@ -459,6 +662,10 @@ func (g *Codegen) emit() []byte {
 	var buf bytes.Buffer
 
 	buf.WriteString(g.fileHeader())
+	// @EncodeDefault is an experimental kotlinx.serialization API.
+	if g.usesEncodeDefault() {
+		buf.WriteString("@file:OptIn(ExperimentalSerializationApi::class)\n\n")
+	}
 	fmt.Fprintf(&buf, "package %s\n\n", g.config.PackageName)
 
 	// Collect which imports we need
@@ -498,7 +705,7 @@ func (g *Codegen) collectImports() []string {
 			continue
 		}
 		for _, p := range g.collectProperties(s) {
-			if fieldName(p.Name) != p.Name {
+			if g.fieldName(p.Name) != p.Name {
 				needsSerialName = true
 				break
 			}
@@ -520,6 +727,12 @@ func (g *Codegen) collectImports() []string {
 	if needsSerialName {
 		imports = append(imports, "kotlinx.serialization.SerialName")
 	}
+	if g.usesEncodeDefault() {
+		imports = append(imports,
+			"kotlinx.serialization.EncodeDefault",
+			"kotlinx.serialization.ExperimentalSerializationApi",
+		)
+	}
 
 	// Check if any integer enum exists (needs KSerializer etc.)
 	hasIntEnum := false
@@ -553,9 +766,13 @@ func (g *Codegen) collectImports() []string {
 		needsPrimitive := false
 		needsArray := false
 		needsObject := false
+		needsDiscriminator := false
 		for _, name := range g.sealedTypes.keys() {
 			info := g.sealedTypes.get(name)
 			for _, v := range info.variants {
+				if v.discriminator != "" {
+					needsDiscriminator = true
+				}
 				switch {
 				case isPrimitiveKotlinType(v.kotlinType):
 					needsPrimitive = true
@@ -578,6 +795,9 @@ func (g *Codegen) collectImports() []string {
 		if needsObject {
 			imports = append(imports, "kotlinx.serialization.json.JsonObject")
 		}
+		if needsDiscriminator && !needsPrimitive {
+			imports = append(imports, "kotlinx.serialization.json.JsonPrimitive")
+		}
 	}
 
 	slices.Sort(imports)
@@ -599,6 +819,12 @@ func (g *Codegen) fileHeader() string {
 	if g.config.LSPVersion != "" {
 		lines = append(lines, fmt.Sprintf("// LSP Version: %s", g.config.LSPVersion))
 	}
+	if g.config.ToolVersion != "" {
+		lines = append(lines, fmt.Sprintf("// Generator: lspls %s", g.config.ToolVersion))
+	}
+	if !g.config.Timestamp.IsZero() {
+		lines = append(lines, fmt.Sprintf("// Generated: %s", g.config.Timestamp.UTC().Format(time.RFC3339)))
+	}
 	lines = append(lines, "")
 	return strings.Join(lines, "\n")
 }
@@ -611,26 +837,37 @@ func writeKdoc(buf *bytes.Buffer, doc, since, deprecated string) {
 	}
 	buf.WriteString("/**\n")
 	if doc != "" {
-		for line := range strings.SplitSeq(doc, "\n") {
-			fmt.Fprintf(buf, " * %s\n", line)
-		}
+		lspbase.WriteComment(buf, " *", doc)
 	}
-	if since != "" && !strings.Contains(doc, "@since "+since) {
-		fmt.Fprintf(buf, " *\n * @since %s\n", since)
+	hasSince := since != "" && !strings.Contains(doc, "@since "+since)
+	if hasSince {
+		if doc != "" {
+			buf.WriteString(" *\n")
+		}
+		fmt.Fprintf(buf, " * @since %s\n", since)
 	}
 	if deprecated != "" {
-		fmt.Fprintf(buf, " *\n * @deprecated %s\n", deprecated)
+		if doc != "" || hasSince {
+			buf.WriteString(" *\n")
+		}
+		fmt.Fprintf(buf, " * @deprecated %s\n", deprecated)
 	}
 	buf.WriteString(" */\n")
 }
 
-func writeIndentedKdoc(buf *bytes.Buffer, doc, indent string) {
-	if doc == "" {
+func writeIndentedKdoc(buf *bytes.Buffer, doc, since, indent string) {
+	if doc == "" && since == "" {
 		return
 	}
 	fmt.Fprintf(buf, "%s/**\n", indent)
-	for line := range strings.SplitSeq(doc, "\n") {
-		fmt.Fprintf(buf, "%s * %s\n", indent, line)
+	if doc != "" {
+		lspbase.WriteComment(buf, indent+" *", doc)
+	}
+	if since != "" {
+		if doc != "" {
+			fmt.Fprintf(buf, "%s *\n", indent)
+		}
+		fmt.Fprintf(buf, "%s * @since %s\n", indent, since)
 	}
 	fmt.Fprintf(buf, "%s */\n", indent)
 }
@@ -4,13 +4,16 @@ package kotlin_test
 
 import (
 	"encoding/json"
+	"errors"
 	"flag"
 	"os"
 	"path/filepath"
 	"slices"
+	"strconv"
 	"strings"
 	"testing"
 
+	"github.com/albertocavalcante/lspls/generator"
 	"github.com/albertocavalcante/lspls/generators/kotlin"
 	"github.com/albertocavalcante/lspls/internal/testutil"
 	"github.com/albertocavalcante/lspls/model"
@@ -70,6 +73,24 @@ func TestCodegen(t *testing.T) {
 	}
 }
 
+// TestStrictJSONNames checks that StrictJSONNames turns the collision of
+// testdata/json_name_collision.txtar into an error.
+func TestStrictJSONNames(t *testing.T) {
+	ar, err := txtar.ParseFile(filepath.Join("testdata", "json_name_collision.txtar"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var m model.Model
+	if err := json.Unmarshal(ar.Files[0].Data, &m); err != nil {
+		t.Fatal(err)
+	}
+	_, err = kotlin.New(&m, kotlin.Config{PackageName: "lsp.protocol", StrictJSONNames: true}).Generate()
+	var dup *generator.DuplicateJSONNameError
+	if !errors.As(err, &dup) || dup.Structure != "TextDocumentItem" || dup.Name != "uri" {
+		t.Errorf("Generate() error = %v, want a DuplicateJSONNameError for TextDocumentItem.uri", err)
+	}
+}
+
 func runCodegen(input []byte, flags []string) (map[string][]byte, error) {
 	var m model.Model
 	if err := json.Unmarshal(input, &m); err != nil {
@@ -80,6 +101,7 @@ func runCodegen(input []byte, flags []string) (map[string][]byte, error) {
 		PackageName:     "lsp.protocol",
 		ResolveDeps:     true,
 		IncludeProposed: slices.Contains(flags, "proposed"),
+		MinifyDocs:      slices.Contains(flags, "minify-docs"),
 	}
 
 	for _, f := range flags {
@@ -89,6 +111,27 @@ func runCodegen(input []byte, flags []string) (map[string][]byte, error) {
 		if pkgName, ok := strings.CutPrefix(f, "package="); ok {
 			cfg.PackageName = pkgName
 		}
+		if prefix, ok := strings.CutPrefix(f, "type-prefix="); ok {
+			cfg.TypePrefix = prefix
+		}
+		if suffix, ok := strings.CutPrefix(f, "type-suffix="); ok {
+			cfg.TypeSuffix = suffix
+		}
+		if mode, ok := strings.CutPrefix(f, "encode-default="); ok {
+			cfg.EncodeDefault = mode
+		}
+		if indent, ok := strings.CutPrefix(f, "indent="); ok {
+			cfg.Indent = "\t"
+			if n, err := strconv.Atoi(indent); err == nil {
+				cfg.Indent = strings.Repeat(" ", n)
+			}
+		}
+		if n, ok := strings.CutPrefix(f, "builders="); ok {
+			cfg.Builders, _ = strconv.Atoi(n)
+		}
+		if f == "sealed-interface" {
+			cfg.SealedInterfaces = true
+		}
 		if f == "no-resolve-deps" {
 			cfg.ResolveDeps = false
 		}
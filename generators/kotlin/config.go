@@ -6,11 +6,27 @@
 
 package kotlin
 
+import (
+	"log/slog"
+	"time"
+)
+
 // Config holds configuration for Kotlin generation.
 type Config struct {
 	// PackageName is the Kotlin package name (e.g., "lsp.protocol").
 	PackageName string
 
+	// TypePrefix and TypeSuffix are added to the name of every generated
+	// type, including Or_* unions. JSON property names are unchanged.
+	TypePrefix string
+	TypeSuffix string
+
+	// Acronyms are words cased the same way wherever they appear in a
+	// generated identifier. NameOverrides maps an LSP name to its
+	// identifier, bypassing the other rules.
+	Acronyms      []string
+	NameOverrides map[string]string
+
 	// Types to include (empty means all).
 	Types []string
 
@@ -20,11 +36,52 @@ type Config struct {
 	// IncludeProposed generates types marked as proposed.
 	IncludeProposed bool
 
+	// MinifyDocs omits documentation comments, keeping @since and
+	// @deprecated tags.
+	MinifyDocs bool
+
+	// StrictJSONNames fails generation when flattening extends and mixins
+	// gives more than one property of a structure the same JSON name,
+	// instead of keeping the most-derived one with a warning.
+	StrictJSONNames bool
+
+	// Indent is the indentation unit, such as a tab or two spaces. Empty
+	// uses four spaces.
+	Indent string
+
+	// EncodeDefault, when "never" or "always", annotates every optional
+	// property with @EncodeDefault in that mode, making explicit whether a
+	// null default is encoded. When empty, kotlinx.serialization's
+	// encodeDefaults setting decides.
+	EncodeDefault string
+
+	// Builders, when positive, adds a Builder class and a companion
+	// builder { } function to data classes with at least this many
+	// required properties, so callers can set them by name in any order.
+	Builders int
+
+	// SealedInterfaces generates unions whose members all reference
+	// structures as sealed interfaces that the data classes of the members
+	// implement, instead of sealed classes wrapping each member in a
+	// <Member>Value class. Values of the union are then the member data
+	// classes themselves, decoded by a serializer keyed on their
+	// literal-valued properties.
+	SealedInterfaces bool
+
 	// Source metadata for header comments.
 	Source     string
 	Ref        string
 	CommitHash string
 	LSPVersion string
+
+	// ToolVersion and Timestamp, when set, add the lspls version and the
+	// generation time to header comments.
+	ToolVersion string
+	Timestamp   time.Time
+
+	// Logger receives per-type progress at debug level and warnings about
+	// types that degrade to Any. If nil, nothing is logged.
+	Logger *slog.Logger
 }
 
 // DefaultMappings provides standard LSP to Kotlin type mappings
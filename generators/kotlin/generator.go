@@ -8,6 +8,8 @@ package kotlin
 
 import (
 	"context"
+	"fmt"
+	"strconv"
 
 	"github.com/albertocavalcante/lspls/generator"
 	"github.com/albertocavalcante/lspls/model"
@@ -32,17 +34,58 @@ func (g *Generator) Metadata() generator.Metadata {
 	}
 }
 
+// Describe returns the options, outputs, and requirements of this generator.
+func (g *Generator) Describe() generator.Description {
+	return generator.Description{
+		Options: []generator.OptionInfo{
+			{Key: "package", Flag: "-p", Default: "lsp.protocol", Description: "Kotlin package name"},
+			{Key: "encode_default", Flag: "--encode-default", Default: "", Description: "Annotate optional properties with @EncodeDefault: never or always"},
+			{Key: "builders", Flag: "--builders", Default: "0", Description: "Add a Builder and a builder { } function to data classes with at least n required properties"},
+			{Key: "sealed_interfaces", Flag: "--kotlin-sealed-interface", Default: "false", Description: "Generate unions of structures as sealed interfaces that the member data classes implement"},
+		},
+		Outputs: []string{
+			"Protocol.kt: data classes, enums, and union serializers",
+			"index.md: type index (directory output, --index)",
+		},
+		Requirements: []string{
+			"Kotlin 1.9+",
+			"kotlinx.serialization plugin and kotlinx-serialization-json",
+		},
+	}
+}
+
 // Generate produces Kotlin output files from the LSP model.
 func (g *Generator) Generate(ctx context.Context, m *model.Model, cfg generator.Config) (*generator.Output, error) {
+	m, err := generator.ResolveEnumBases(m)
+	if err != nil {
+		return nil, err
+	}
+	builders, err := strconv.Atoi(cfg.Option("builders", "0"))
+	if err != nil {
+		return nil, fmt.Errorf("builders %q: want a number of required properties", cfg.Option("builders", ""))
+	}
 	internalCfg := Config{
-		PackageName:     cfg.Option("package", "lsp.protocol"),
-		Types:           cfg.Types,
-		ResolveDeps:     cfg.ResolveDeps,
-		IncludeProposed: cfg.IncludeProposed,
-		Source:          cfg.Source,
-		Ref:             cfg.Ref,
-		CommitHash:      cfg.CommitHash,
-		LSPVersion:      cfg.LSPVersion,
+		PackageName:      cfg.Option("package", "lsp.protocol"),
+		Types:            cfg.Types,
+		TypePrefix:       cfg.TypePrefix,
+		TypeSuffix:       cfg.TypeSuffix,
+		Acronyms:         cfg.Acronyms,
+		NameOverrides:    cfg.NameOverrides,
+		ResolveDeps:      cfg.ResolveDeps,
+		IncludeProposed:  cfg.IncludeProposed,
+		MinifyDocs:       cfg.MinifyDocs,
+		StrictJSONNames:  cfg.StrictJSONNames,
+		Indent:           cfg.Indent,
+		Builders:         builders,
+		SealedInterfaces: cfg.Option("sealed_interfaces", "false") == "true",
+		EncodeDefault:    cfg.Option("encode_default", ""),
+		Source:           cfg.Source,
+		Ref:              cfg.Ref,
+		CommitHash:       cfg.CommitHash,
+		LSPVersion:       cfg.LSPVersion,
+		ToolVersion:      cfg.ToolVersion,
+		Timestamp:        cfg.Timestamp,
+		Logger:           cfg.Logger,
 	}
 
 	gen := New(m, internalCfg)
@@ -59,5 +102,8 @@ func (g *Generator) Generate(ctx context.Context, m *model.Model, cfg generator.
 	}
 
 	result.Add(filename, out.Kotlin)
+	if cfg.Index && cfg.OutputDir != "" {
+		result.Add(generator.IndexFile, generator.MarkdownIndex("LSP Types", generator.Index(m, cfg)))
+	}
 	return result, nil
 }
@@ -10,6 +10,7 @@ import (
 	"cmp"
 	"fmt"
 	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/albertocavalcante/lspls/internal/lspbase"
@@ -47,7 +48,7 @@ func (g *Codegen) kotlinTypeInner(t *model.Type) string {
 		if mapped, ok := DefaultMappings[t.Name]; ok {
 			return mapped
 		}
-		return typeName(t.Name)
+		return g.typeName(t.Name)
 
 	case "array":
 		return "List<" + g.kotlinType(t.Element, false) + ">"
@@ -61,21 +62,31 @@ func (g *Codegen) kotlinTypeInner(t *model.Type) string {
 		return fmt.Sprintf("Map<%s, %s>", keyType, valType)
 
 	case "literal":
+		g.log.Warn("literal type degraded to Any", "line", t.Line)
 		return "Any"
 
 	case "stringLiteral":
 		return "String"
 
+	case "integerLiteral":
+		return "Int"
+
+	case "booleanLiteral":
+		return "Boolean"
+
 	case "or":
 		return g.getOrType(t)
 
 	case "and":
+		g.log.Warn("intersection type degraded to Any", "line", t.Line)
 		return "Any"
 
 	case "tuple":
+		g.log.Warn("tuple type degraded to List<Any>", "line", t.Line)
 		return "List<Any>"
 
 	default:
+		g.log.Warn("unknown type kind degraded to Any", "kind", t.Kind, "line", t.Line)
 		return "Any"
 	}
 }
@@ -116,7 +127,7 @@ func (g *Codegen) typeNameForIdent(t *model.Type) string {
 	case "base":
 		return kotlinBaseType(t)
 	case "reference":
-		return typeName(t.Name)
+		return g.casing.ExportName(t.Name)
 	case "array":
 		return "Arr" + g.typeNameForIdent(t.Element)
 	case "map":
@@ -130,6 +141,10 @@ func (g *Codegen) typeNameForIdent(t *model.Type) string {
 		return "Literal"
 	case "stringLiteral":
 		return "String"
+	case "integerLiteral":
+		return "Int"
+	case "booleanLiteral":
+		return "Boolean"
 	case "or":
 		return "Union"
 	case "and":
@@ -143,8 +158,9 @@ func (g *Codegen) typeNameForIdent(t *model.Type) string {
 
 // sealedVariantInfo describes one branch of a sealed class.
 type sealedVariantInfo struct {
-	identName  string // identifier-safe name (for the value class name)
-	kotlinType string // full Kotlin type
+	identName     string // identifier-safe name (for the value class name)
+	kotlinType    string // full Kotlin type
+	discriminator string // condition on element selecting this variant, if any
 }
 
 // getOrType returns the Kotlin type name for an "or" union type, registering
@@ -177,8 +193,9 @@ func (g *Codegen) getOrType(t *model.Type) string {
 	var pairs []sealedVariantInfo
 	for _, item := range nonNullItems {
 		pairs = append(pairs, sealedVariantInfo{
-			identName:  g.typeNameForIdent(item),
-			kotlinType: g.kotlinType(item, false),
+			identName:     g.typeNameForIdent(item),
+			kotlinType:    g.kotlinType(item, false),
+			discriminator: g.discriminator(item),
 		})
 	}
 
@@ -191,29 +208,84 @@ func (g *Codegen) getOrType(t *model.Type) string {
 		identNames = append(identNames, p.identName)
 	}
 
-	sealedName := "Or_" + strings.Join(identNames, "_")
+	sealedName := g.config.TypePrefix + "Or_" + strings.Join(identNames, "_") + g.config.TypeSuffix
 
 	if _, exists := g.sealedTypes.m[sealedName]; !exists {
+		iface := g.config.SealedInterfaces && g.allStructures(nonNullItems)
 		g.sealedTypes.set(sealedName, sealedTypeInfo{
 			name:     sealedName,
 			variants: pairs,
+			iface:    iface,
 		})
+		if iface {
+			for _, item := range nonNullItems {
+				g.sealedSupers[item.Name] = append(g.sealedSupers[item.Name], sealedName)
+			}
+		}
 	}
 
 	return sealedName
 }
 
-// typeName converts an LSP type name to a valid Kotlin class name.
-func typeName(name string) string {
-	return lspbase.ExportName(name)
+// allStructures reports whether every item references a structure that is
+// generated as a data class, which can then implement a sealed interface.
+func (g *Codegen) allStructures(items []*model.Type) bool {
+	for _, item := range items {
+		if item.Kind != "reference" {
+			return false
+		}
+		if _, mapped := DefaultMappings[item.Name]; mapped {
+			return false
+		}
+		if !slices.ContainsFunc(g.model.Structures, func(s *model.Structure) bool { return s.Name == item.Name }) {
+			return false
+		}
+	}
+	return true
+}
+
+// typeName converts an LSP type name to a valid Kotlin class name,
+// with TypePrefix and TypeSuffix applied.
+func (g *Codegen) typeName(name string) string {
+	return g.config.TypePrefix + g.casing.ExportName(name) + g.config.TypeSuffix
+}
+
+// stringLiteral returns s as a Kotlin string literal. Unlike a Go literal,
+// "$" must be escaped, since it starts a string template.
+func stringLiteral(s string) string {
+	return strings.ReplaceAll(strconv.Quote(s), "$", `\$`)
 }
 
 // fieldName converts an LSP property name to a Kotlin property name (camelCase).
-func fieldName(name string) string {
-	return lspbase.StripMeta(name)
+func (g *Codegen) fieldName(name string) string {
+	return g.casing.FieldName(name)
 }
 
 // enumConstName converts an enum value name to a Kotlin enum constant (SCREAMING_SNAKE).
-func enumConstName(name string) string {
-	return lspbase.CamelToScreamingSnake(name)
+func (g *Codegen) enumConstName(name string) string {
+	return g.casing.CamelToScreamingSnake(name)
+}
+
+// discriminator returns a condition on the JSON element that identifies
+// item within a union, when item references a structure with a
+// literal-valued property. It returns "" otherwise.
+func (g *Codegen) discriminator(item *model.Type) string {
+	if item.Kind != "reference" {
+		return ""
+	}
+	for _, s := range g.model.Structures {
+		if s.Name != item.Name {
+			continue
+		}
+		p, ok := s.Discriminator()
+		if !ok {
+			return ""
+		}
+		value := fmt.Sprint(p.Type.Value)
+		if p.Type.Kind == "stringLiteral" {
+			value = stringLiteral(value)
+		}
+		return fmt.Sprintf("element is JsonObject && element[%s] == JsonPrimitive(%s)", stringLiteral(p.Name), value)
+	}
+	return ""
 }
@@ -0,0 +1,380 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+// Package markdown generates a human-readable protocol reference: the
+// requests and notifications grouped by namespace, and a table of the
+// properties of every structure and the values of every enumeration.
+// Types are written in the TypeScript notation of the specification.
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/albertocavalcante/lspls/generator"
+	"github.com/albertocavalcante/lspls/generators/jsonschema"
+	"github.com/albertocavalcante/lspls/model"
+)
+
+// Codegen generates a Markdown reference from the LSP model.
+type Codegen struct {
+	model      *model.Model
+	config     Config
+	log        *slog.Logger
+	typeFilter map[string]bool // nil = all types
+}
+
+// New creates a new Markdown Codegen.
+func New(m *model.Model, cfg Config) *Codegen {
+	c := &Codegen{
+		model:  m,
+		config: cfg,
+		log:    cfg.Logger,
+	}
+	if c.log == nil {
+		c.log = slog.New(slog.DiscardHandler)
+	}
+	if len(cfg.Types) > 0 {
+		c.typeFilter = make(map[string]bool)
+		for _, t := range cfg.Types {
+			c.typeFilter[t] = true
+		}
+	}
+	return c
+}
+
+// shouldInclude returns whether a type should be included in the document.
+func (g *Codegen) shouldInclude(name string, proposed bool) bool {
+	if proposed && !g.config.IncludeProposed {
+		return false
+	}
+	if g.typeFilter != nil && !g.typeFilter[name] {
+		return false
+	}
+	return true
+}
+
+// Output contains the generated Markdown document.
+type Output struct {
+	Document []byte
+}
+
+// method is a request or notification row of the methods tables.
+type method struct {
+	name, kind, direction string
+	params, result        *model.Type
+	doc, since            string
+	proposed              bool
+}
+
+// Generate produces the Markdown document. Methods are listed only without
+// a type filter, since a filtered document lacks the types they refer to.
+func (g *Codegen) Generate() (*Output, error) {
+	if g.typeFilter != nil && g.config.ResolveDeps {
+		g.typeFilter = generator.ResolveDeps(g.model, g.typeFilter, g.config.IncludeProposed)
+	}
+
+	var buf bytes.Buffer
+	comment := jsonschema.Comment(g.config.Source, g.config.Ref, g.config.CommitHash, g.config.LSPVersion, g.config.ToolVersion, g.config.Timestamp)
+	fmt.Fprintf(&buf, "<!--\n%s\n-->\n\n", comment)
+	title := g.config.Title
+	if title == "" {
+		title = "Language Server Protocol"
+		if g.config.LSPVersion != "" {
+			title += " " + g.config.LSPVersion
+		}
+	}
+	fmt.Fprintf(&buf, "# %s\n", title)
+
+	if g.typeFilter == nil {
+		g.writeMethods(&buf)
+	}
+
+	var structures []*model.Structure
+	for _, s := range g.model.Structures {
+		if g.shouldInclude(s.Name, s.Proposed) {
+			structures = append(structures, s)
+		}
+	}
+	if len(structures) > 0 {
+		buf.WriteString("\n## Structures\n")
+		for _, s := range structures {
+			g.log.Debug("documenting structure", "name", s.Name)
+			g.writeStructure(&buf, s)
+		}
+	}
+
+	var enums []*model.Enumeration
+	for _, e := range g.model.Enumerations {
+		if g.shouldInclude(e.Name, e.Proposed) {
+			enums = append(enums, e)
+		}
+	}
+	if len(enums) > 0 {
+		buf.WriteString("\n## Enumerations\n")
+		for _, e := range enums {
+			g.log.Debug("documenting enumeration", "name", e.Name)
+			g.writeEnumeration(&buf, e)
+		}
+	}
+
+	var aliases []*model.TypeAlias
+	for _, a := range g.model.TypeAliases {
+		if g.shouldInclude(a.Name, a.Proposed) {
+			aliases = append(aliases, a)
+		}
+	}
+	if len(aliases) > 0 {
+		buf.WriteString("\n## Type Aliases\n")
+		for _, a := range aliases {
+			g.log.Debug("documenting type alias", "name", a.Name)
+			g.writeTypeAlias(&buf, a)
+		}
+	}
+
+	return &Output{Document: buf.Bytes()}, nil
+}
+
+// writeMethods writes a table of the requests and notifications of each
+// namespace, the part of the method name before the first slash. Methods
+// without one are under "general".
+func (g *Codegen) writeMethods(buf *bytes.Buffer) {
+	byNamespace := make(map[string][]method)
+	add := func(m method) {
+		if m.proposed && !g.config.IncludeProposed {
+			return
+		}
+		ns, _, ok := strings.Cut(m.name, "/")
+		if !ok {
+			ns = "general"
+		}
+		byNamespace[ns] = append(byNamespace[ns], m)
+	}
+	for _, r := range g.model.Requests {
+		add(method{r.Method, "request", r.Direction, r.Params, r.Result, r.Documentation, r.Since, r.Proposed})
+	}
+	for _, n := range g.model.Notifications {
+		add(method{n.Method, "notification", n.Direction, n.Params, nil, n.Documentation, n.Since, n.Proposed})
+	}
+	if len(byNamespace) == 0 {
+		return
+	}
+
+	buf.WriteString("\n## Methods\n")
+	for _, ns := range slices.Sorted(maps.Keys(byNamespace)) {
+		methods := byNamespace[ns]
+		slices.SortFunc(methods, func(a, b method) int { return strings.Compare(a.name, b.name) })
+		fmt.Fprintf(buf, "\n### %s\n\n", ns)
+		buf.WriteString("| Method | Kind | Direction | Params | Result | Since | Description |\n")
+		buf.WriteString("|--------|------|-----------|--------|--------|-------|-------------|\n")
+		for _, m := range methods {
+			params, result := "", ""
+			if m.params != nil {
+				params = codeCell(typeString(m.params))
+			}
+			if m.result != nil {
+				result = codeCell(typeString(m.result))
+			}
+			fmt.Fprintf(buf, "| %s | %s | %s | %s | %s | %s | %s |\n",
+				codeCell(m.name), m.kind, direction(m.direction), params, result, m.since, g.description(m.doc, "", m.proposed))
+		}
+	}
+}
+
+// writeStructure writes the heading, documentation, and property table of s.
+func (g *Codegen) writeStructure(buf *bytes.Buffer, s *model.Structure) {
+	g.writeHeading(buf, s.Name, s.Documentation, s.Deprecated, s.Proposed)
+	if len(s.Extends) > 0 {
+		fmt.Fprintf(buf, "Extends %s.\n\n", typeList(s.Extends))
+	}
+	if len(s.Mixins) > 0 {
+		fmt.Fprintf(buf, "Mixes in %s.\n\n", typeList(s.Mixins))
+	}
+	var props []model.Property
+	for _, p := range s.Properties {
+		if !p.Proposed || g.config.IncludeProposed {
+			props = append(props, p)
+		}
+	}
+	if len(props) == 0 {
+		buf.WriteString("No properties.\n")
+		return
+	}
+	buf.WriteString("| Name | Type | Optional | Since | Description |\n")
+	buf.WriteString("|------|------|----------|-------|-------------|\n")
+	for _, p := range props {
+		optional := ""
+		if p.Optional {
+			optional = "yes"
+		}
+		fmt.Fprintf(buf, "| %s | %s | %s | %s | %s |\n",
+			codeCell(p.Name), codeCell(typeString(p.Type)), optional, p.Since, g.description(p.Documentation, p.Deprecated, p.Proposed))
+	}
+}
+
+// writeEnumeration writes the heading, documentation, and value table of e.
+func (g *Codegen) writeEnumeration(buf *bytes.Buffer, e *model.Enumeration) {
+	g.writeHeading(buf, e.Name, e.Documentation, e.Deprecated, e.Proposed)
+	fmt.Fprintf(buf, "Type: %s.", code(typeString(e.Type)))
+	if e.SupportsCustomValues {
+		buf.WriteString(" Custom values are allowed.")
+	}
+	buf.WriteString("\n\n")
+	buf.WriteString("| Name | Value | Since | Description |\n")
+	buf.WriteString("|------|-------|-------|-------------|\n")
+	for _, v := range e.Values {
+		if v.Proposed && !g.config.IncludeProposed {
+			continue
+		}
+		fmt.Fprintf(buf, "| %s | %s | %s | %s |\n",
+			codeCell(v.Name), codeCell(literal(v.Value)), v.Since, g.description(v.Documentation, "", v.Proposed))
+	}
+}
+
+// writeTypeAlias writes the heading, documentation, and type of a.
+func (g *Codegen) writeTypeAlias(buf *bytes.Buffer, a *model.TypeAlias) {
+	g.writeHeading(buf, a.Name, a.Documentation, a.Deprecated, a.Proposed)
+	fmt.Fprintf(buf, "Type: %s.\n", code(typeString(a.Type)))
+}
+
+// writeHeading writes the heading of a type followed by its documentation
+// and deprecation notice.
+func (g *Codegen) writeHeading(buf *bytes.Buffer, name, doc, deprecated string, proposed bool) {
+	fmt.Fprintf(buf, "\n### %s\n\n", name)
+	if proposed {
+		buf.WriteString("**Proposed.**\n\n")
+	}
+	if deprecated != "" {
+		fmt.Fprintf(buf, "**Deprecated:** %s\n\n", deprecated)
+	}
+	if doc = strings.TrimSpace(doc); doc != "" && !g.config.MinifyDocs {
+		buf.WriteString(doc + "\n\n")
+	}
+}
+
+// description returns the table cell describing a property, enumeration
+// value, or method: the first paragraph of its documentation, after its
+// deprecation notice.
+func (g *Codegen) description(doc, deprecated string, proposed bool) string {
+	var parts []string
+	if proposed {
+		parts = append(parts, "**Proposed.**")
+	}
+	if deprecated != "" {
+		parts = append(parts, "**Deprecated:** "+generator.Summary(deprecated))
+	}
+	if !g.config.MinifyDocs {
+		if s := generator.Summary(doc); s != "" {
+			parts = append(parts, s)
+		}
+	}
+	return escapeCell(strings.Join(parts, " "))
+}
+
+// typeString returns t in the TypeScript notation of the specification,
+// such as "Position[]" or "string | null".
+func typeString(t *model.Type) string {
+	if t == nil {
+		return "unknown"
+	}
+	switch t.Kind {
+	case "base", "reference":
+		return t.Name
+	case "array":
+		elem := typeString(t.Element)
+		if t.Element != nil && (t.Element.Kind == "or" || t.Element.Kind == "and") {
+			elem = "(" + elem + ")"
+		}
+		return elem + "[]"
+	case "map":
+		value, _ := t.Value.(*model.Type)
+		return fmt.Sprintf("{ [key: %s]: %s }", typeString(t.Key), typeString(value))
+	case "literal":
+		lit, _ := t.Value.(model.Literal)
+		if len(lit.Properties) == 0 {
+			return "{}"
+		}
+		props := make([]string, len(lit.Properties))
+		for i, p := range lit.Properties {
+			opt := ""
+			if p.Optional {
+				opt = "?"
+			}
+			props[i] = p.Name + opt + ": " + typeString(p.Type)
+		}
+		return "{ " + strings.Join(props, "; ") + " }"
+	case "stringLiteral", "integerLiteral", "booleanLiteral":
+		return literal(t.Value)
+	case "or", "and", "tuple":
+		items := make([]string, len(t.Items))
+		for i, item := range t.Items {
+			items[i] = typeString(item)
+		}
+		switch t.Kind {
+		case "or":
+			return strings.Join(items, " | ")
+		case "and":
+			return strings.Join(items, " & ")
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	}
+	return t.Kind
+}
+
+// typeList returns the types of an extends or mixins list as links to
+// their headings.
+func typeList(types []*model.Type) string {
+	links := make([]string, len(types))
+	for i, t := range types {
+		name := typeString(t)
+		links[i] = fmt.Sprintf("[%s](#%s)", code(name), strings.ToLower(name))
+	}
+	return strings.Join(links, ", ")
+}
+
+// literal returns an enumeration value or literal type value as written in
+// the specification: strings quoted, numbers without exponents.
+func literal(v any) string {
+	switch v := v.(type) {
+	case string:
+		return strconv.Quote(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return fmt.Sprint(v)
+}
+
+// direction returns a message direction of the specification for readers.
+func direction(d string) string {
+	switch d {
+	case "clientToServer":
+		return "client → server"
+	case "serverToClient":
+		return "server → client"
+	}
+	return d
+}
+
+// code returns s as a code span.
+func code(s string) string {
+	return "`" + s + "`"
+}
+
+// codeCell returns s as a code span of a table cell.
+func codeCell(s string) string {
+	return escapeCell(code(s))
+}
+
+// escapeCell escapes the pipes of s, which end a table cell even inside a
+// code span.
+func escapeCell(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
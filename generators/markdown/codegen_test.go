@@ -0,0 +1,116 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package markdown
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/albertocavalcante/lspls/internal/testutil"
+	"github.com/albertocavalcante/lspls/model"
+	"golang.org/x/tools/txtar"
+)
+
+// TestStructureTable checks the property table of a structure: a row per
+// property with its type, whether it is optional, its version, and the
+// first paragraph of its documentation.
+func TestStructureTable(t *testing.T) {
+	m := &model.Model{Structures: []*model.Structure{{
+		Name: "Diagnostic",
+		Properties: []model.Property{
+			{Name: "message", Type: &model.Type{Kind: "base", Name: "string"}, Documentation: "The message.\n\nMore."},
+			{Name: "tags", Type: &model.Type{Kind: "array", Element: &model.Type{Kind: "reference", Name: "DiagnosticTag"}}, Optional: true, Since: "3.15.0"},
+			{Name: "code", Type: &model.Type{Kind: "or", Items: []*model.Type{{Kind: "base", Name: "integer"}, {Kind: "base", Name: "string"}}}, Optional: true},
+		},
+	}}}
+	out, err := New(m, Config{}).Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "### Diagnostic\n\n" +
+		"| Name | Type | Optional | Since | Description |\n" +
+		"|------|------|----------|-------|-------------|\n" +
+		"| `message` | `string` |  |  | The message. |\n" +
+		"| `tags` | `DiagnosticTag[]` | yes | 3.15.0 |  |\n" +
+		"| `code` | `integer \\| string` | yes |  |  |\n"
+	if got := string(out.Document); !strings.Contains(got, want) {
+		t.Errorf("document does not contain the table\n%s\ngot:\n%s", want, got)
+	}
+}
+
+// TestCodegen runs txtar-based integration tests.
+func TestCodegen(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("testdata", "*.txtar"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Skip("no txtar files found in testdata/")
+	}
+
+	for _, file := range files {
+		name := strings.TrimSuffix(filepath.Base(file), ".txtar")
+		t.Run(name, func(t *testing.T) {
+			ar, err := txtar.ParseFile(file)
+			if err != nil {
+				t.Fatalf("parse txtar: %v", err)
+			}
+
+			tc, err := testutil.ParseCase(name, ar)
+			if err != nil {
+				t.Fatalf("parse case: %v", err)
+			}
+
+			if *update {
+				got, err := runCodegen(tc.Input, tc.Flags)
+				if err != nil {
+					t.Fatalf("generate: %v", err)
+				}
+				content := testutil.FormatArchive(testutil.UpdateArchive(ar, got))
+				if err := os.WriteFile(file, content, 0o644); err != nil {
+					t.Fatalf("write updated file: %v", err)
+				}
+				t.Logf("updated %s", file)
+				return
+			}
+
+			tc.Run(t, runCodegen)
+		})
+	}
+}
+
+var update = flag.Bool("update", false, "update golden files")
+
+// runCodegen generates a Markdown reference from input JSON.
+func runCodegen(input []byte, flags []string) (map[string][]byte, error) {
+	var m model.Model
+	if err := json.Unmarshal(input, &m); err != nil {
+		return nil, err
+	}
+
+	cfg := Config{
+		LSPVersion:      m.Version.Version,
+		IncludeProposed: slices.Contains(flags, "proposed"),
+		MinifyDocs:      slices.Contains(flags, "minify-docs"),
+	}
+	for _, f := range flags {
+		if typesStr, ok := strings.CutPrefix(f, "types="); ok {
+			cfg.Types = strings.Split(typesStr, ";")
+		}
+	}
+
+	out, err := New(&m, cfg).Generate()
+	if err != nil {
+		return nil, err
+	}
+	return map[string][]byte{"protocol.md": out.Document}, nil
+}
@@ -0,0 +1,46 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package markdown
+
+import (
+	"log/slog"
+	"time"
+)
+
+// Config holds configuration for Markdown generation.
+type Config struct {
+	// Title is the heading of the document.
+	Title string
+
+	// Types to include (empty means all). Methods are listed only when
+	// Types is empty.
+	Types []string
+
+	// ResolveDeps includes transitively referenced types.
+	ResolveDeps bool
+
+	// IncludeProposed includes proposed types and methods.
+	IncludeProposed bool
+
+	// MinifyDocs leaves the description columns empty.
+	MinifyDocs bool
+
+	// Source metadata for the header comment.
+	Source     string
+	Ref        string
+	CommitHash string
+	LSPVersion string
+
+	// ToolVersion and Timestamp, when set, add the lspls version and the
+	// generation time to the header comment.
+	ToolVersion string
+	Timestamp   time.Time
+
+	// Logger receives per-type progress at debug level. If nil, nothing is
+	// logged.
+	Logger *slog.Logger
+}
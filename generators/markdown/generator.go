@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package markdown
+
+import (
+	"context"
+
+	"github.com/albertocavalcante/lspls/generator"
+	"github.com/albertocavalcante/lspls/model"
+)
+
+// Generator implements [generator.Generator] for Markdown generation.
+type Generator struct{}
+
+// NewGenerator creates a new Markdown generator.
+func NewGenerator() *Generator {
+	return &Generator{}
+}
+
+// Metadata returns information about this generator.
+func (g *Generator) Metadata() generator.Metadata {
+	return generator.Metadata{
+		Name:           "markdown",
+		Version:        "1.0.0",
+		Description:    "Generate a Markdown protocol reference from LSP specification",
+		FileExtensions: []string{".md"},
+		URL:            "https://github.com/albertocavalcante/lspls",
+	}
+}
+
+// Describe returns the options, outputs, and requirements of this generator.
+func (g *Generator) Describe() generator.Description {
+	return generator.Description{
+		Options: []generator.OptionInfo{
+			{Key: "title", Default: "", Description: "Heading of the document (default: Language Server Protocol)"},
+		},
+		Outputs: []string{
+			"protocol.md: methods by namespace, structures, enumerations, and type aliases",
+		},
+		Requirements: []string{
+			"a Markdown renderer with GitHub-flavored tables",
+		},
+	}
+}
+
+// Generate produces the Markdown reference from the LSP model.
+func (g *Generator) Generate(ctx context.Context, m *model.Model, cfg generator.Config) (*generator.Output, error) {
+	internalCfg := Config{
+		Title:           cfg.Option("title", ""),
+		Types:           cfg.Types,
+		ResolveDeps:     cfg.ResolveDeps,
+		IncludeProposed: cfg.IncludeProposed,
+		MinifyDocs:      cfg.MinifyDocs,
+		Source:          cfg.Source,
+		Ref:             cfg.Ref,
+		CommitHash:      cfg.CommitHash,
+		LSPVersion:      cfg.LSPVersion,
+		ToolVersion:     cfg.ToolVersion,
+		Timestamp:       cfg.Timestamp,
+		Logger:          cfg.Logger,
+	}
+
+	out, err := New(m, internalCfg).Generate()
+	if err != nil {
+		return nil, err
+	}
+
+	filename := "protocol.md"
+	if cfg.OutputFile != "" {
+		filename = cfg.OutputFile
+	}
+	return generator.Single(filename, out.Document), nil
+}
@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+// Package openapi generates an OpenAPI 3.1 document whose
+// components.schemas section describes every LSP structure, enumeration,
+// and type alias, for inspecting the protocol in API tooling.
+package openapi
+
+import (
+	"log/slog"
+
+	"github.com/albertocavalcante/lspls/generator"
+	"github.com/albertocavalcante/lspls/generators/jsonschema"
+	"github.com/albertocavalcante/lspls/model"
+)
+
+// Version is the OpenAPI version of the generated document.
+const Version = "3.1.0"
+
+// Codegen generates an OpenAPI document from the LSP model.
+type Codegen struct {
+	model      *model.Model
+	config     Config
+	log        *slog.Logger
+	typeFilter map[string]bool // nil = all types
+}
+
+// New creates a new OpenAPI Codegen.
+func New(m *model.Model, cfg Config) *Codegen {
+	c := &Codegen{
+		model:  m,
+		config: cfg,
+		log:    cfg.Logger,
+	}
+	if c.log == nil {
+		c.log = slog.New(slog.DiscardHandler)
+	}
+	if len(cfg.Types) > 0 {
+		c.typeFilter = make(map[string]bool)
+		for _, t := range cfg.Types {
+			c.typeFilter[t] = true
+		}
+	}
+	return c
+}
+
+// shouldInclude returns whether a type should be included in the document.
+func (g *Codegen) shouldInclude(name string, proposed bool) bool {
+	if proposed && !g.config.IncludeProposed {
+		return false
+	}
+	if g.typeFilter != nil && !g.typeFilter[name] {
+		return false
+	}
+	return true
+}
+
+// Output contains the generated OpenAPI document.
+type Output struct {
+	Document []byte
+}
+
+// Generate produces the OpenAPI document. LSP is not an HTTP API, so the
+// document has no paths; OpenAPI 3.1 allows that when components are
+// present.
+//
+// OpenAPI 3.1 schema objects are JSON Schema draft 2020-12, so the schemas
+// come from the JSON Schema target's builder with references rewritten to
+// #/components/schemas/. Nullable types are unions with {"type": "null"}
+// rather than the OpenAPI 3.0 nullable keyword.
+func (g *Codegen) Generate() (*Output, error) {
+	if g.typeFilter != nil && g.config.ResolveDeps {
+		g.typeFilter = generator.ResolveDeps(g.model, g.typeFilter, g.config.IncludeProposed)
+	}
+
+	b := &jsonschema.Builder{
+		RefPrefix:       "#/components/schemas/",
+		IncludeProposed: g.config.IncludeProposed,
+		MinifyDocs:      g.config.MinifyDocs,
+	}
+	schemas := b.Definitions(g.model, g.shouldInclude)
+	g.log.Debug("generated component schemas", "count", len(schemas))
+
+	title := g.config.Title
+	if title == "" {
+		title = "Language Server Protocol"
+	}
+	version := g.config.LSPVersion
+	if version == "" {
+		version = "unknown"
+	}
+
+	doc := map[string]any{
+		"openapi": Version,
+		"info": map[string]any{
+			"title":       title,
+			"version":     version,
+			"description": jsonschema.Comment(g.config.Source, g.config.Ref, g.config.CommitHash, g.config.LSPVersion, g.config.ToolVersion, g.config.Timestamp),
+		},
+		"components": map[string]any{
+			"schemas": schemas,
+		},
+	}
+	out, err := jsonschema.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	return &Output{Document: out}, nil
+}
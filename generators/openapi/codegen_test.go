@@ -0,0 +1,141 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package openapi
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/albertocavalcante/lspls/internal/testutil"
+	"github.com/albertocavalcante/lspls/model"
+	"golang.org/x/tools/txtar"
+)
+
+// TestGenerateValidJSON checks that the document parses as OpenAPI 3.1,
+// defines every type of the golden input, and only references schemas it
+// defines.
+func TestGenerateValidJSON(t *testing.T) {
+	ar, err := txtar.ParseFile(filepath.Join("testdata", "components.txtar"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tc, err := testutil.ParseCase("components", ar)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files, err := runCodegen(tc.Input, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := files["openapi.json"]
+
+	var doc struct {
+		OpenAPI string `json:"openapi"`
+		Info    struct {
+			Title   string `json:"title"`
+			Version string `json:"version"`
+		} `json:"info"`
+		Components struct {
+			Schemas map[string]json.RawMessage `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+	if doc.OpenAPI != Version || doc.Info.Title == "" || doc.Info.Version == "" {
+		t.Errorf("openapi = %q, info = %+v; want version %s and a title and version", doc.OpenAPI, doc.Info, Version)
+	}
+
+	var names []string
+	for name := range doc.Components.Schemas {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	want := []string{"CodeActionKind", "CreateFile", "DiagnosticSeverity", "Position", "ProgressToken", "Range", "TextDocumentIdentifier", "VersionedTextDocumentIdentifier"}
+	if !slices.Equal(names, want) {
+		t.Errorf("components.schemas = %v, want %v", names, want)
+	}
+
+	for _, ref := range strings.Split(string(data), `"$ref": "`)[1:] {
+		name, ok := strings.CutPrefix(ref[:strings.IndexByte(ref, '"')], "#/components/schemas/")
+		if !ok || doc.Components.Schemas[name] == nil {
+			t.Errorf("dangling $ref %q", ref[:strings.IndexByte(ref, '"')])
+		}
+	}
+}
+
+// TestCodegen runs txtar-based integration tests.
+func TestCodegen(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("testdata", "*.txtar"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Skip("no txtar files found in testdata/")
+	}
+
+	for _, file := range files {
+		name := strings.TrimSuffix(filepath.Base(file), ".txtar")
+		t.Run(name, func(t *testing.T) {
+			ar, err := txtar.ParseFile(file)
+			if err != nil {
+				t.Fatalf("parse txtar: %v", err)
+			}
+
+			tc, err := testutil.ParseCase(name, ar)
+			if err != nil {
+				t.Fatalf("parse case: %v", err)
+			}
+
+			if *update {
+				got, err := runCodegen(tc.Input, tc.Flags)
+				if err != nil {
+					t.Fatalf("generate: %v", err)
+				}
+				content := testutil.FormatArchive(testutil.UpdateArchive(ar, got))
+				if err := os.WriteFile(file, content, 0o644); err != nil {
+					t.Fatalf("write updated file: %v", err)
+				}
+				t.Logf("updated %s", file)
+				return
+			}
+
+			tc.Run(t, runCodegen)
+		})
+	}
+}
+
+var update = flag.Bool("update", false, "update golden files")
+
+// runCodegen generates an OpenAPI document from input JSON.
+func runCodegen(input []byte, flags []string) (map[string][]byte, error) {
+	var m model.Model
+	if err := json.Unmarshal(input, &m); err != nil {
+		return nil, err
+	}
+
+	cfg := Config{
+		LSPVersion:      m.Version.Version,
+		IncludeProposed: slices.Contains(flags, "proposed"),
+	}
+	for _, f := range flags {
+		if typesStr, ok := strings.CutPrefix(f, "types="); ok {
+			cfg.Types = strings.Split(typesStr, ";")
+		}
+	}
+
+	out, err := New(&m, cfg).Generate()
+	if err != nil {
+		return nil, err
+	}
+	return map[string][]byte{"openapi.json": out.Document}, nil
+}
@@ -0,0 +1,45 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package openapi
+
+import (
+	"log/slog"
+	"time"
+)
+
+// Config holds configuration for OpenAPI generation.
+type Config struct {
+	// Title is the info.title of the document.
+	Title string
+
+	// Types to include (empty means all).
+	Types []string
+
+	// ResolveDeps includes transitively referenced types.
+	ResolveDeps bool
+
+	// IncludeProposed generates types marked as proposed.
+	IncludeProposed bool
+
+	// MinifyDocs omits description keywords.
+	MinifyDocs bool
+
+	// Source metadata for info.description and info.version.
+	Source     string
+	Ref        string
+	CommitHash string
+	LSPVersion string
+
+	// ToolVersion and Timestamp, when set, add the lspls version and the
+	// generation time to header comments.
+	ToolVersion string
+	Timestamp   time.Time
+
+	// Logger receives per-type progress at debug level. If nil, nothing is
+	// logged.
+	Logger *slog.Logger
+}
@@ -9,8 +9,10 @@ package proto
 
 import (
 	"fmt"
+	"log/slog"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/albertocavalcante/lspls/generator"
 	"github.com/albertocavalcante/lspls/internal/lspbase"
@@ -21,18 +23,27 @@ import (
 type Codegen struct {
 	model           *model.Model
 	config          Config
+	log             *slog.Logger
 	resolver        *TypeResolver
 	typeFilter      map[string]bool   // nil = all types
 	pendingWrappers map[string]string // Helper messages generated on-the-fly (name -> definition)
+	enumValueNames  map[string]bool   // Enum value names emitted so far; they share the package scope
+	casing          *lspbase.Casing   // Derives identifiers from LSP names
 }
 
 // New creates a new proto Codegen.
 func New(m *model.Model, cfg Config) *Codegen {
+	casing := lspbase.NewCasing(cfg.Acronyms, cfg.NameOverrides)
 	c := &Codegen{
 		model:           m,
 		config:          cfg,
-		resolver:        NewTypeResolver(m, cfg.IncludeProposed, cfg.TypeOverrides),
+		resolver:        NewTypeResolver(m, cfg.IncludeProposed, cfg.TypeOverrides, casing),
 		pendingWrappers: make(map[string]string),
+		casing:          casing,
+	}
+	c.log = cfg.Logger
+	if c.log == nil {
+		c.log = slog.New(slog.DiscardHandler)
 	}
 	if len(cfg.Types) > 0 {
 		c.typeFilter = make(map[string]bool)
@@ -61,6 +72,11 @@ func (g *Codegen) shouldInclude(name string, proposed bool) bool {
 
 // Generate produces the proto3 definitions.
 func (g *Codegen) Generate() (*Output, error) {
+	switch g.config.FieldNumbering {
+	case "", FieldNumberingSequential, FieldNumberingStable:
+	default:
+		return nil, fmt.Errorf("field numbering %q: want sequential or stable", g.config.FieldNumbering)
+	}
 	// Resolve transitive dependencies if filtering
 	if g.typeFilter != nil && g.config.ResolveDeps {
 		g.typeFilter = generator.ResolveDeps(g.model, g.typeFilter, g.config.IncludeProposed)
@@ -103,6 +119,7 @@ func (g *Codegen) Generate() (*Output, error) {
 		if !g.shouldInclude(enum.Name, enum.Proposed) {
 			continue
 		}
+		g.log.Debug("generating enum", "name", enum.Name)
 		b.WriteString(g.generateEnum(enum))
 		b.WriteString("\n")
 	}
@@ -112,6 +129,7 @@ func (g *Codegen) Generate() (*Output, error) {
 		if !g.shouldInclude(structure.Name, structure.Proposed) {
 			continue
 		}
+		g.log.Debug("generating message", "name", structure.Name)
 		b.WriteString(g.generateMessage(structure))
 		b.WriteString("\n")
 	}
@@ -127,6 +145,7 @@ func (g *Codegen) Generate() (*Output, error) {
 				continue
 			}
 
+			g.log.Debug("generating union", "name", alias.Name)
 			b.WriteString(g.generateUnion(alias))
 			b.WriteString("\n")
 		}
@@ -147,7 +166,23 @@ func (g *Codegen) Generate() (*Output, error) {
 		}
 	}
 
-	return &Output{Proto: []byte(b.String())}, nil
+	return &Output{Proto: lspbase.Reindent([]byte(b.String()), "  ", g.config.Indent)}, nil
+}
+
+// docs returns doc, or only its @since and @deprecated lines when
+// documentation is minified.
+func (g *Codegen) docs(doc string) string {
+	if !g.config.MinifyDocs {
+		return doc
+	}
+	var tags []string
+	for line := range strings.SplitSeq(doc, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "@since") || strings.HasPrefix(line, "@deprecated") {
+			tags = append(tags, line)
+		}
+	}
+	return strings.Join(tags, "\n")
 }
 
 // generateUnion produces a oneof message for a union type.
@@ -155,13 +190,11 @@ func (g *Codegen) generateUnion(alias *model.TypeAlias) string {
 	var b strings.Builder
 
 	// Documentation
-	if alias.Documentation != "" {
-		for _, line := range strings.Split(alias.Documentation, "\n") {
-			b.WriteString(fmt.Sprintf("// %s\n", line))
-		}
+	if doc := g.docs(alias.Documentation); doc != "" {
+		lspbase.WriteComment(&b, "//", doc)
 	}
 
-	msgName := toProtoMessageName(alias.Name)
+	msgName := toProtoMessageName(g.casing, alias.Name)
 	b.WriteString(fmt.Sprintf("message %s {\n", msgName))
 	b.WriteString("  oneof value {\n")
 
@@ -184,6 +217,7 @@ func (g *Codegen) generateUnion(alias *model.TypeAlias) string {
 		}
 
 		if err != nil {
+			g.log.Warn("skipped union member", "union", alias.Name, "kind", item.Kind, "line", item.Line, "err", err)
 			b.WriteString(fmt.Sprintf("    // skipped %v: %v\n", item, err))
 		} else {
 			b.WriteString(line)
@@ -204,7 +238,7 @@ func (g *Codegen) generateUnionArrayField(item *model.Type, fieldNum int) (strin
 
 	// Wrapper naming: ArrayOf_Type
 	clean := strings.ReplaceAll(elem, ".", "_")
-	wrapper := "ArrayOf_" + toProtoMessageName(clean)
+	wrapper := "ArrayOf_" + toProtoMessageName(g.casing, clean)
 
 	// Register wrapper on-the-fly
 	if _, exists := g.pendingWrappers[wrapper]; !exists {
@@ -215,7 +249,7 @@ func (g *Codegen) generateUnionArrayField(item *model.Type, fieldNum int) (strin
 		g.pendingWrappers[wrapper] = wb.String()
 	}
 
-	return fmt.Sprintf("    %s %s_list = %d;\n", wrapper, toProtoFieldName(clean), fieldNum), nil
+	return fmt.Sprintf("    %s %s_list = %d;\n", wrapper, toProtoFieldName(g.casing, clean), fieldNum), nil
 }
 
 func (g *Codegen) generateUnionMapField(item *model.Type, fieldNum int) (string, error) {
@@ -230,7 +264,7 @@ func (g *Codegen) generateUnionMapField(item *model.Type, fieldNum int) (string,
 
 	cleanKey := strings.ReplaceAll(key, ".", "_")
 	cleanVal := strings.ReplaceAll(val, ".", "_")
-	wrapper := fmt.Sprintf("MapOf_%s_%s", toProtoMessageName(cleanKey), toProtoMessageName(cleanVal))
+	wrapper := fmt.Sprintf("MapOf_%s_%s", toProtoMessageName(g.casing, cleanKey), toProtoMessageName(g.casing, cleanVal))
 
 	// Register wrapper on-the-fly
 	if _, exists := g.pendingWrappers[wrapper]; !exists {
@@ -241,7 +275,7 @@ func (g *Codegen) generateUnionMapField(item *model.Type, fieldNum int) (string,
 		g.pendingWrappers[wrapper] = wb.String()
 	}
 
-	return fmt.Sprintf("    %s %s_map = %d;\n", wrapper, toProtoFieldName(cleanVal), fieldNum), nil
+	return fmt.Sprintf("    %s %s_map = %d;\n", wrapper, toProtoFieldName(g.casing, cleanVal), fieldNum), nil
 }
 
 func (g *Codegen) generateUnionStandardField(item *model.Type, fieldNum int) (string, error) {
@@ -255,7 +289,7 @@ func (g *Codegen) generateUnionStandardField(item *model.Type, fieldNum int) (st
 	case "base":
 		fieldName = item.Name + "_value"
 	case "reference":
-		fieldName = toProtoFieldName(item.Name)
+		fieldName = toProtoFieldName(g.casing, item.Name)
 	default:
 		fieldName = "value"
 	}
@@ -279,6 +313,12 @@ func (g *Codegen) generateHeader() string {
 	if g.config.LSPVersion != "" {
 		b.WriteString(fmt.Sprintf("// LSP Version: %s\n", g.config.LSPVersion))
 	}
+	if g.config.ToolVersion != "" {
+		b.WriteString(fmt.Sprintf("// Generator: lspls %s\n", g.config.ToolVersion))
+	}
+	if !g.config.Timestamp.IsZero() {
+		b.WriteString(fmt.Sprintf("// Generated: %s\n", g.config.Timestamp.UTC().Format(time.RFC3339)))
+	}
 	b.WriteString("\nsyntax = \"proto3\";\n")
 	return b.String()
 }
@@ -287,30 +327,40 @@ func (g *Codegen) generateMessage(s *model.Structure) string {
 	var b strings.Builder
 
 	// Documentation
-	if s.Documentation != "" {
-		for _, line := range strings.Split(s.Documentation, "\n") {
-			b.WriteString(fmt.Sprintf("// %s\n", line))
-		}
+	if doc := g.docs(s.Documentation); doc != "" {
+		lspbase.WriteComment(&b, "//", doc)
 	}
 
-	b.WriteString(fmt.Sprintf("message %s {\n", toProtoMessageName(s.Name)))
+	b.WriteString(fmt.Sprintf("message %s {\n", toProtoMessageName(g.casing, s.Name)))
 
-	fieldNum := 1
-	for _, prop := range s.Properties {
-		protoType, err := g.convertType(prop.Type)
+	// Convert every property first: the field numbers depend on which
+	// properties are kept.
+	protoTypes := make([]string, len(s.Properties))
+	errs := make([]error, len(s.Properties))
+	var names []string
+	for i, prop := range s.Properties {
+		protoTypes[i], errs[i] = g.convertType(prop.Type)
+		if errs[i] == nil {
+			names = append(names, prop.Name)
+		}
+	}
+	fieldNums := g.fieldNumbers(s.Name, names)
+
+	for i, prop := range s.Properties {
+		protoType, err := protoTypes[i], errs[i]
 		if err != nil {
 			// Skip fields we can't convert
+			g.log.Warn("skipped field", "message", s.Name, "field", prop.Name, "line", prop.Line, "err", err)
 			b.WriteString(fmt.Sprintf("  // %s: skipped (%s)\n", prop.Name, err))
 			continue
 		}
 
-		fieldName := toProtoFieldName(prop.Name)
+		fieldName := toProtoFieldName(g.casing, prop.Name)
+		fieldNum := fieldNums[prop.Name]
 
 		// Add field documentation (all lines)
-		if prop.Documentation != "" {
-			for _, line := range strings.Split(prop.Documentation, "\n") {
-				b.WriteString(fmt.Sprintf("  // %s\n", line))
-			}
+		if doc := g.docs(prop.Documentation); doc != "" {
+			lspbase.WriteComment(&b, "  //", doc)
 		}
 
 		// In proto3:
@@ -325,7 +375,6 @@ func (g *Codegen) generateMessage(s *model.Structure) string {
 		} else {
 			b.WriteString(fmt.Sprintf("  %s %s = %d;\n", protoType, fieldName, fieldNum))
 		}
-		fieldNum++
 	}
 
 	b.WriteString("}\n")
@@ -336,20 +385,21 @@ func (g *Codegen) generateEnum(e *model.Enumeration) string {
 	var b strings.Builder
 
 	// Documentation
-	if e.Documentation != "" {
-		for _, line := range strings.Split(e.Documentation, "\n") {
-			b.WriteString(fmt.Sprintf("// %s\n", line))
-		}
+	if doc := g.docs(e.Documentation); doc != "" {
+		lspbase.WriteComment(&b, "//", doc)
 	}
 
-	enumName := toProtoMessageName(e.Name)
+	enumName := toProtoMessageName(g.casing, e.Name)
 	b.WriteString(fmt.Sprintf("enum %s {\n", enumName))
 
-	prefix := toEnumPrefix(e.Name)
+	prefix := toEnumPrefix(g.casing, e.Name)
+	unspecified := prefix + "_UNSPECIFIED"
 
-	// Check if any defined value is already 0
+	// Check if any defined value is already 0, or is a string value
+	// named Unspecified that can take 0 instead of an added sentinel.
 	hasZeroValue := false
-	for _, v := range e.Values {
+	zeroString := -1
+	for i, v := range e.Values {
 		switch val := v.Value.(type) {
 		case float64:
 			if int(val) == 0 {
@@ -359,20 +409,40 @@ func (g *Codegen) generateEnum(e *model.Enumeration) string {
 			if val == 0 {
 				hasZeroValue = true
 			}
+		case string:
+			if zeroString < 0 && toEnumValueName(g.casing, prefix, v.Name) == unspecified {
+				zeroString = i
+			}
 		}
 	}
 
+	// Values claim their names before the sentinel, so that a value
+	// named Unspecified keeps its name.
+	names := make([]string, len(e.Values))
+	for i, v := range e.Values {
+		names[i] = g.uniqueEnumValueName(toEnumValueName(g.casing, prefix, v.Name))
+	}
+
 	// Proto3 requires first value to be 0
 	// Only add UNSPECIFIED if no existing value is 0
-	if !hasZeroValue {
-		b.WriteString(fmt.Sprintf("  %s_UNSPECIFIED = 0;\n", prefix))
+	order := make([]int, 0, len(e.Values))
+	if zeroString >= 0 {
+		order = append(order, zeroString)
+	} else if !hasZeroValue {
+		b.WriteString(fmt.Sprintf("  %s = 0;\n", g.uniqueEnumValueName(unspecified)))
+	}
+	for i := range e.Values {
+		if i != zeroString {
+			order = append(order, i)
+		}
 	}
 
 	// Track next sequential value for string enums
 	nextSeqValue := 1
 
-	for _, v := range e.Values {
-		valueName := toEnumValueName(prefix, v.Name)
+	for _, i := range order {
+		v := e.Values[i]
+		valueName := names[i]
 
 		// Get the numeric value
 		var numValue int
@@ -383,15 +453,20 @@ func (g *Codegen) generateEnum(e *model.Enumeration) string {
 			numValue = val
 		case string:
 			// String enums - assign sequential numbers
+			if i == zeroString {
+				numValue = 0
+				break
+			}
 			numValue = nextSeqValue
 			nextSeqValue++
 		default:
 			// Unknown type - skip
+			g.log.Warn("skipped enum value", "enum", e.Name, "value", v.Name, "line", v.Line)
 			continue
 		}
 
-		if v.Documentation != "" {
-			b.WriteString(fmt.Sprintf("  // %s\n", strings.Split(v.Documentation, "\n")[0]))
+		if doc := g.docs(v.Documentation); doc != "" {
+			b.WriteString(fmt.Sprintf("  // %s\n", strings.Split(doc, "\n")[0]))
 		}
 		b.WriteString(fmt.Sprintf("  %s = %d;\n", valueName, numValue))
 	}
@@ -416,7 +491,7 @@ func (g *Codegen) convertType(t *model.Type) (string, error) {
 
 		// Check if the resolved type is known
 		if !g.resolver.IsKnown(protoType) && !g.resolver.IsKnown(t.Name) {
-			return "", fmt.Errorf("references proposed type %q (use --proposed to include)", t.Name)
+			return "", &generator.UnresolvedTypeError{Name: t.Name, Line: t.Line, Reason: "is proposed or not defined (use --proposed to include proposed types)"}
 		}
 
 		return protoType, nil
@@ -442,7 +517,7 @@ func (g *Codegen) convertType(t *model.Type) (string, error) {
 			// Type aliases in LSP are typically strings (e.g., DocumentUri)
 			keyTypeStr = "string"
 		default:
-			return "", fmt.Errorf("unsupported map key type: %s", t.Key.Kind)
+			return "", fmt.Errorf("map key: %w", &generator.UnsupportedKindError{Kind: t.Key.Kind, Line: t.Key.Line})
 		}
 
 		valType, ok := t.Value.(*model.Type)
@@ -460,7 +535,7 @@ func (g *Codegen) convertType(t *model.Type) (string, error) {
 
 			// Naming convention: MapArray_{ElementType}
 			cleanType := strings.ReplaceAll(elemType, ".", "_")
-			wrapperName := "MapArray_" + toProtoMessageName(cleanType)
+			wrapperName := "MapArray_" + toProtoMessageName(g.casing, cleanType)
 
 			// Define wrapper message if not already present
 			if _, exists := g.pendingWrappers[wrapperName]; !exists {
@@ -522,7 +597,7 @@ func (g *Codegen) convertType(t *model.Type) (string, error) {
 		return "google.protobuf.ListValue", nil
 
 	default:
-		return "", fmt.Errorf("unsupported type kind: %s", t.Kind)
+		return "", &generator.UnsupportedKindError{Kind: t.Kind, Line: t.Line}
 	}
 }
 
@@ -547,23 +622,42 @@ func convertBaseType(name string) (string, error) {
 }
 
 // toProtoMessageName converts an LSP type name to a proto message name.
-func toProtoMessageName(name string) string {
+func toProtoMessageName(c *lspbase.Casing, name string) string {
 	name = strings.TrimPrefix(name, "$")
-	return lspbase.Capitalize(name)
+	return c.Capitalize(name)
 }
 
 // toProtoFieldName converts an LSP field name to a proto field name (snake_case).
-func toProtoFieldName(name string) string {
-	return lspbase.CamelToSnake(name)
+func toProtoFieldName(c *lspbase.Casing, name string) string {
+	return c.CamelToSnake(name)
 }
 
 // toEnumPrefix converts an enum name to a SCREAMING_SNAKE_CASE prefix.
-func toEnumPrefix(name string) string {
-	return lspbase.CamelToScreamingSnake(name)
+func toEnumPrefix(c *lspbase.Casing, name string) string {
+	return c.CamelToScreamingSnake(name)
+}
+
+// uniqueEnumValueName returns name, or name with the first free numeric
+// suffix if an enum value already has it. Proto enum values are scoped to
+// the package rather than to their enum, so FooBar.Baz and Foo.BarBaz
+// would otherwise both be FOO_BAR_BAZ.
+func (g *Codegen) uniqueEnumValueName(name string) string {
+	if g.enumValueNames == nil {
+		g.enumValueNames = make(map[string]bool)
+	}
+	unique := name
+	for n := 2; g.enumValueNames[unique]; n++ {
+		unique = fmt.Sprintf("%s_%d", name, n)
+	}
+	if unique != name {
+		g.log.Warn("renamed colliding enum value", "name", name, "renamed", unique)
+	}
+	g.enumValueNames[unique] = true
+	return unique
 }
 
 // toEnumValueName creates a proto enum value name.
-func toEnumValueName(prefix, name string) string {
-	valuePart := toEnumPrefix(name)
+func toEnumValueName(c *lspbase.Casing, prefix, name string) string {
+	valuePart := toEnumPrefix(c, name)
 	return prefix + "_" + valuePart
 }
@@ -8,12 +8,18 @@ package proto
 
 import (
 	"encoding/json"
+	"errors"
 	"flag"
+	"log/slog"
 	"os"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 	"testing"
 
+	"github.com/albertocavalcante/lspls/generator"
+	"github.com/albertocavalcante/lspls/internal/lspbase"
 	"github.com/albertocavalcante/lspls/internal/testutil"
 	"github.com/albertocavalcante/lspls/model"
 	"golang.org/x/tools/txtar"
@@ -51,6 +57,30 @@ func TestConvertBaseType(t *testing.T) {
 	}
 }
 
+func TestConvertTypeErrors(t *testing.T) {
+	m := &model.Model{}
+	g := New(m, Config{PackageName: "lsp"})
+
+	_, err := g.convertType(&model.Type{Kind: "reference", Name: "Missing", Line: 3})
+	var unresolved *generator.UnresolvedTypeError
+	if !errors.As(err, &unresolved) || unresolved.Name != "Missing" || unresolved.Line != 3 {
+		t.Errorf("convertType(Missing) error = %#v, want *UnresolvedTypeError for Missing at line 3", err)
+	}
+
+	mapType := &model.Type{
+		Kind:  "map",
+		Key:   &model.Type{Kind: "tuple", Line: 5},
+		Value: &model.Type{Kind: "base", Name: "string"},
+	}
+	for _, typ := range []*model.Type{{Kind: "literalUnion", Line: 5}, mapType} {
+		_, err := g.convertType(typ)
+		var unsupported *generator.UnsupportedKindError
+		if !errors.As(err, &unsupported) || unsupported.Line != 5 {
+			t.Errorf("convertType(%s) error = %#v, want *UnsupportedKindError at line 5", typ.Kind, err)
+		}
+	}
+}
+
 func TestToProtoMessageName(t *testing.T) {
 	tests := []struct {
 		name string
@@ -64,7 +94,7 @@ func TestToProtoMessageName(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := toProtoMessageName(tt.name)
+			got := toProtoMessageName(nil, tt.name)
 			if got != tt.want {
 				t.Errorf("toProtoMessageName(%q) = %q, want %q", tt.name, got, tt.want)
 			}
@@ -86,7 +116,7 @@ func TestToProtoFieldName(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := toProtoFieldName(tt.name)
+			got := toProtoFieldName(nil, tt.name)
 			if got != tt.want {
 				t.Errorf("toProtoFieldName(%q) = %q, want %q", tt.name, got, tt.want)
 			}
@@ -94,6 +124,19 @@ func TestToProtoFieldName(t *testing.T) {
 	}
 }
 
+func TestProtoNamesWithAcronyms(t *testing.T) {
+	casing := lspbase.NewCasing([]string{"URI"}, nil)
+	if got, want := toProtoMessageName(casing, "DocumentUri"), "DocumentURI"; got != want {
+		t.Errorf("toProtoMessageName = %q, want %q", got, want)
+	}
+	if got, want := toProtoFieldName(casing, "baseURI"), "base_uri"; got != want {
+		t.Errorf("toProtoFieldName = %q, want %q", got, want)
+	}
+	if got, want := toEnumValueName(casing, "URI_SCHEME", "fileURI"), "URI_SCHEME_FILE_URI"; got != want {
+		t.Errorf("toEnumValueName = %q, want %q", got, want)
+	}
+}
+
 func TestGenerateMessage(t *testing.T) {
 	g := &Codegen{
 		config: Config{PackageName: "lsp"},
@@ -125,6 +168,37 @@ func TestGenerateMessage(t *testing.T) {
 	}
 }
 
+// TestGenerateWarnings checks that skipped fields and renamed enum values
+// are logged as warnings, which --fail-on-warn turns into an error.
+func TestGenerateWarnings(t *testing.T) {
+	m := &model.Model{
+		Structures: []*model.Structure{{
+			Name: "Settings",
+			Properties: []model.Property{
+				{Name: "name", Type: &model.Type{Kind: "base", Name: "string"}},
+				{Name: "reset", Type: &model.Type{Kind: "base", Name: "null"}, Line: 7},
+			},
+		}},
+		Enumerations: []*model.Enumeration{
+			{Name: "FooBar", Type: &model.Type{Kind: "base", Name: "integer"}, Values: []model.EnumValue{{Name: "Baz", Value: 1.0}}},
+			{Name: "Foo", Type: &model.Type{Kind: "base", Name: "integer"}, Values: []model.EnumValue{{Name: "BarBaz", Value: 1.0}}},
+		},
+	}
+	var warnings generator.Warnings
+	cfg := Config{PackageName: "lsp", Logger: slog.New(warnings.Handler(slog.DiscardHandler))}
+	if _, err := New(m, cfg).Generate(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"renamed colliding enum value name=FOO_BAR_BAZ renamed=FOO_BAR_BAZ_2",
+		"skipped field message=Settings field=reset line=7 err=null type cannot be represented in proto3",
+	}
+	if got := warnings.List(); !slices.Equal(got, want) {
+		t.Errorf("warnings = %q, want %q", got, want)
+	}
+}
+
 func TestGenerateEnum(t *testing.T) {
 	g := &Codegen{
 		config: Config{PackageName: "lsp"},
@@ -258,6 +332,25 @@ func runCodegen(input []byte, flags []string) (map[string][]byte, error) {
 				}
 			}
 		}
+		if indent, ok := strings.CutPrefix(f, "indent="); ok {
+			cfg.Indent = "\t"
+			if n, err := strconv.Atoi(indent); err == nil {
+				cfg.Indent = strings.Repeat(" ", n)
+			}
+		}
+		if val, ok := strings.CutPrefix(f, "field-numbering="); ok {
+			cfg.FieldNumbering = val
+		}
+		if val, ok := strings.CutPrefix(f, "field-map="); ok {
+			fieldMap, err := ParseFieldMap(strings.ReplaceAll(val, ";", "\n"))
+			if err != nil {
+				return nil, err
+			}
+			cfg.FieldMap = fieldMap
+		}
+		if f == "minify-docs" {
+			cfg.MinifyDocs = true
+		}
 		if val, ok := strings.CutPrefix(f, "resolve-deps="); ok {
 			cfg.ResolveDeps = val == "true"
 		}
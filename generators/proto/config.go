@@ -6,6 +6,11 @@
 
 package proto
 
+import (
+	"log/slog"
+	"time"
+)
+
 // Config holds configuration for proto generation.
 type Config struct {
 	// PackageName is the proto package name (e.g., "lsp").
@@ -17,21 +22,53 @@ type Config struct {
 	// Types to include (empty means all).
 	Types []string
 
+	// Acronyms are words cased the same way wherever they appear in a
+	// message, field, or enum value name. NameOverrides maps an LSP type
+	// name to its message name, bypassing the other rules.
+	Acronyms      []string
+	NameOverrides map[string]string
+
+	// FieldNumbering is how message fields are numbered:
+	// FieldNumberingSequential, the default when empty, or
+	// FieldNumberingStable.
+	FieldNumbering string
+
+	// FieldMap pins field numbers by "Message.field" specification names,
+	// as parsed by ParseFieldMap. The other fields are numbered around them.
+	FieldMap map[string]int
+
 	// ResolveDeps includes transitively referenced types.
 	ResolveDeps bool
 
 	// IncludeProposed generates types marked as proposed.
 	IncludeProposed bool
 
+	// MinifyDocs omits documentation comments, keeping their @since and
+	// @deprecated lines.
+	MinifyDocs bool
+
+	// Indent is the indentation unit, such as a tab or two spaces. Empty
+	// uses two spaces.
+	Indent string
+
 	// Source metadata for header comments.
 	Source     string
 	Ref        string
 	CommitHash string
 	LSPVersion string
 
+	// ToolVersion and Timestamp, when set, add the lspls version and the
+	// generation time to header comments.
+	ToolVersion string
+	Timestamp   time.Time
+
 	// TypeOverrides allows custom mapping of LSP types to Proto types.
 	// If set, these override DefaultMappings.
 	TypeOverrides map[string]string
+
+	// Logger receives per-type progress at debug level and warnings about
+	// skipped fields. If nil, nothing is logged.
+	Logger *slog.Logger
 }
 
 // DefaultMappings provides standard LSP to Proto type mappings.
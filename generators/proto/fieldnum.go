@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package proto
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Field numbering modes of Config.FieldNumbering.
+const (
+	// FieldNumberingSequential numbers the fields of a message 1, 2, 3, ...
+	// in declaration order.
+	FieldNumberingSequential = "sequential"
+
+	// FieldNumberingStable numbers each field from a hash of its name, so
+	// reordering or inserting properties leaves the other numbers alone.
+	FieldNumberingStable = "stable"
+)
+
+const (
+	// maxStableFieldNumber bounds hashed field numbers below the range
+	// 19000-19999 that Protocol Buffers reserves for itself.
+	maxStableFieldNumber = 18999
+
+	// maxFieldNumber is the largest field number Protocol Buffers allows.
+	maxFieldNumber = 1<<29 - 1
+)
+
+// ParseFieldMap parses Message.field=number entries, separated by newlines
+// or commas, into a map for Config.FieldMap. Message and field are the
+// specification names of a structure and one of its properties; # starts
+// a comment.
+func ParseFieldMap(s string) (map[string]int, error) {
+	fields := make(map[string]int)
+	numbers := make(map[string]string) // "Message.number" -> field
+	for i, line := range strings.Split(s, "\n") {
+		line, _, _ = strings.Cut(line, "#")
+		for entry := range strings.SplitSeq(line, ",") {
+			entry = strings.TrimSpace(entry)
+			if entry == "" {
+				continue
+			}
+			key, num, ok := strings.Cut(entry, "=")
+			key = strings.TrimSpace(key)
+			msg, field, dot := strings.Cut(key, ".")
+			if !ok || !dot || msg == "" || field == "" {
+				return nil, fmt.Errorf("field map line %d: %q: want Message.field=number", i+1, entry)
+			}
+			n, err := strconv.Atoi(strings.TrimSpace(num))
+			if err != nil || n < 1 || n > maxFieldNumber || (n >= 19000 && n <= 19999) {
+				return nil, fmt.Errorf("field map line %d: %q: want a field number from 1 to %d outside 19000-19999", i+1, entry, maxFieldNumber)
+			}
+			if _, dup := fields[key]; dup {
+				return nil, fmt.Errorf("field map line %d: %s is mapped twice", i+1, key)
+			}
+			numKey := msg + "." + strconv.Itoa(n)
+			if other, dup := numbers[numKey]; dup {
+				return nil, fmt.Errorf("field map line %d: %s and %s.%s both have number %d", i+1, key, msg, other, n)
+			}
+			fields[key] = n
+			numbers[numKey] = field
+		}
+	}
+	return fields, nil
+}
+
+// fieldNumbers returns the field number of each of the named properties
+// of message msg. Numbers from Config.FieldMap come first; the remaining
+// properties are numbered by Config.FieldNumbering around them.
+func (g *Codegen) fieldNumbers(msg string, names []string) map[string]int {
+	nums := make(map[string]int, len(names))
+	used := make(map[int]bool)
+	var rest []string
+	for _, name := range names {
+		if n, ok := g.config.FieldMap[msg+"."+name]; ok {
+			nums[name] = n
+			used[n] = true
+		} else {
+			rest = append(rest, name)
+		}
+	}
+
+	if g.config.FieldNumbering == FieldNumberingStable {
+		// Resolve collisions in name order, not declaration order, so
+		// they do not depend on where the properties are declared.
+		sort.Strings(rest)
+		for _, name := range rest {
+			n := stableFieldNumber(name)
+			if used[n] {
+				g.log.Warn("field number collision; pin the field with a field map to keep its number stable", "message", msg, "field", name, "number", n)
+			}
+			for used[n] {
+				n = n%maxStableFieldNumber + 1
+			}
+			nums[name] = n
+			used[n] = true
+		}
+		return nums
+	}
+
+	n := 1
+	for _, name := range rest {
+		for used[n] {
+			n++
+		}
+		nums[name] = n
+		used[n] = true
+	}
+	return nums
+}
+
+// stableFieldNumber returns the hashed field number of a property name,
+// from 1 to maxStableFieldNumber.
+func stableFieldNumber(name string) int {
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	return int(h.Sum32()%maxStableFieldNumber) + 1
+}
@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package proto
+
+import (
+	"maps"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/albertocavalcante/lspls/model"
+)
+
+// fieldLine matches a message field, capturing its name and number.
+var fieldLine = regexp.MustCompile(`(\w+) = (\d+);`)
+
+// messageFieldNumbers generates a message with the given properties and
+// returns its field numbers by field name.
+func messageFieldNumbers(t *testing.T, cfg Config, props ...string) map[string]int {
+	t.Helper()
+	s := &model.Structure{Name: "Hover"}
+	for _, name := range props {
+		s.Properties = append(s.Properties, model.Property{Name: name, Type: &model.Type{Kind: "base", Name: "string"}})
+	}
+	got := New(&model.Model{}, cfg).generateMessage(s)
+	nums := make(map[string]int)
+	for _, m := range fieldLine.FindAllStringSubmatch(got, -1) {
+		n, _ := strconv.Atoi(m[2])
+		nums[m[1]] = n
+	}
+	if len(nums) != len(props) {
+		t.Fatalf("got %d fields, want %d:\n%s", len(nums), len(props), got)
+	}
+	return nums
+}
+
+// TestStableFieldNumbersReordered checks that stable numbering gives each
+// field the same number when the properties are reordered or a property
+// is inserted, where sequential numbering does not.
+func TestStableFieldNumbersReordered(t *testing.T) {
+	stable := Config{FieldNumbering: FieldNumberingStable}
+	before := messageFieldNumbers(t, stable, "contents", "range", "kind")
+	after := messageFieldNumbers(t, stable, "kind", "extra", "range", "contents")
+	for name, n := range before {
+		if after[name] != n {
+			t.Errorf("%s = %d after reordering, want %d", name, after[name], n)
+		}
+		if n < 1 || n > maxStableFieldNumber {
+			t.Errorf("%s = %d, want a number from 1 to %d", name, n, maxStableFieldNumber)
+		}
+	}
+
+	sequential := messageFieldNumbers(t, Config{}, "kind", "range", "contents")
+	if sequential["kind"] != 1 || sequential["contents"] != 3 {
+		t.Errorf("sequential numbers = %v, want declaration order", sequential)
+	}
+}
+
+func TestFieldMap(t *testing.T) {
+	fieldMap, err := ParseFieldMap("# pinned\nHover.range = 1\nHover.kind=7, Other.kind=2\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := messageFieldNumbers(t, Config{FieldMap: fieldMap}, "contents", "range", "kind", "extra")
+	want := map[string]int{"contents": 2, "range": 1, "kind": 7, "extra": 3}
+	if !maps.Equal(got, want) {
+		t.Errorf("sequential numbers = %v, want %v", got, want)
+	}
+
+	got = messageFieldNumbers(t, Config{FieldNumbering: FieldNumberingStable, FieldMap: fieldMap}, "contents", "range")
+	if got["range"] != 1 || got["contents"] != stableFieldNumber("contents") {
+		t.Errorf("stable numbers = %v, want range pinned to 1 and contents hashed", got)
+	}
+}
+
+func TestParseFieldMapErrors(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "Hover=1", want: "want Message.field=number"},
+		{in: "Hover.range", want: "want Message.field=number"},
+		{in: "Hover.range=0", want: "want a field number"},
+		{in: "Hover.range=19500", want: "outside 19000-19999"},
+		{in: "Hover.range=x", want: "want a field number"},
+		{in: "Hover.range=1\nHover.range=2", want: "line 2: Hover.range is mapped twice"},
+		{in: "Hover.range=1,Hover.kind=1", want: "Hover.kind and Hover.range both have number 1"},
+	}
+	for _, tc := range tests {
+		_, err := ParseFieldMap(tc.in)
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("ParseFieldMap(%q) error = %v, want %q", tc.in, err, tc.want)
+		}
+	}
+}
+
+func TestInvalidFieldNumbering(t *testing.T) {
+	_, err := New(&model.Model{}, Config{FieldNumbering: "hashed"}).Generate()
+	if err == nil || !strings.Contains(err.Error(), "want sequential or stable") {
+		t.Errorf("Generate error = %v, want an invalid field numbering error", err)
+	}
+}
@@ -32,19 +32,56 @@ func (g *Generator) Metadata() generator.Metadata {
 	}
 }
 
+// Describe returns the options, outputs, and requirements of this generator.
+func (g *Generator) Describe() generator.Description {
+	return generator.Description{
+		Options: []generator.OptionInfo{
+			{Key: "package", Flag: "-p", Default: "lsp", Description: "Protocol Buffers package name"},
+			{Key: "go_package", Default: "", Description: "Value of option go_package, if set"},
+			{Key: "field_numbering", Flag: "--proto-field-numbering", Default: "sequential", Description: "Number message fields sequentially in declaration order, or stable: from a hash of the field name"},
+			{Key: "field_map", Flag: "--proto-field-map", Default: "", Description: "Message.field=number entries pinning field numbers; the flag reads them from a file"},
+		},
+		Outputs: []string{
+			"protocol.proto: messages and enums",
+			"index.md: type index (directory output, --index)",
+		},
+		Requirements: []string{
+			"proto3 syntax (protoc 3.15+)",
+			"the google/protobuf any.proto and struct.proto well-known types",
+		},
+	}
+}
+
 // Generate produces proto output files from the LSP model.
 func (g *Generator) Generate(ctx context.Context, m *model.Model, cfg generator.Config) (*generator.Output, error) {
+	m, err := generator.ResolveEnumBases(m)
+	if err != nil {
+		return nil, err
+	}
+	fieldMap, err := ParseFieldMap(cfg.Option("field_map", ""))
+	if err != nil {
+		return nil, err
+	}
 	// Convert generator.Config to internal Config
 	internalCfg := Config{
 		PackageName:     cfg.Option("package", "lsp"),
 		GoPackage:       cfg.Option("go_package", ""),
 		Types:           cfg.Types,
+		FieldNumbering:  cfg.Option("field_numbering", FieldNumberingSequential),
+		FieldMap:        fieldMap,
+		Acronyms:        cfg.Acronyms,
+		NameOverrides:   cfg.NameOverrides,
 		ResolveDeps:     cfg.ResolveDeps,
 		IncludeProposed: cfg.IncludeProposed,
+		MinifyDocs:      cfg.MinifyDocs,
+		Indent:          cfg.Indent,
 		Source:          cfg.Source,
 		Ref:             cfg.Ref,
 		CommitHash:      cfg.CommitHash,
 		LSPVersion:      cfg.LSPVersion,
+		ToolVersion:     cfg.ToolVersion,
+		Timestamp:       cfg.Timestamp,
+		Logger:          cfg.Logger,
 	}
 
 	// Create internal generator and generate
@@ -64,5 +101,8 @@ func (g *Generator) Generate(ctx context.Context, m *model.Model, cfg generator.
 	}
 
 	result.Add(filename, out.Proto)
+	if cfg.Index && cfg.OutputDir != "" {
+		result.Add(generator.IndexFile, generator.MarkdownIndex("LSP Types", generator.Index(m, cfg)))
+	}
 	return result, nil
 }
@@ -9,6 +9,7 @@ package proto
 import (
 	"strings"
 
+	"github.com/albertocavalcante/lspls/internal/lspbase"
 	"github.com/albertocavalcante/lspls/model"
 )
 
@@ -20,13 +21,17 @@ type TypeResolver struct {
 
 	// Set of types defined in the model (to validate references)
 	definedTypes map[string]bool
+
+	// Derives message names from LSP names
+	casing *lspbase.Casing
 }
 
 // NewTypeResolver creates a new TypeResolver populated from the model.
-func NewTypeResolver(m *model.Model, includeProposed bool, overrides map[string]string) *TypeResolver {
+func NewTypeResolver(m *model.Model, includeProposed bool, overrides map[string]string, casing *lspbase.Casing) *TypeResolver {
 	r := &TypeResolver{
 		typeMap:      make(map[string]string),
 		definedTypes: make(map[string]bool),
+		casing:       casing,
 	}
 
 	r.init(m, includeProposed, overrides)
@@ -93,11 +98,11 @@ func (r *TypeResolver) registerTypeAlias(a *model.TypeAlias) {
 		}
 
 	case "reference":
-		r.typeMap[a.Name] = toProtoMessageName(a.Type.Name)
+		r.typeMap[a.Name] = toProtoMessageName(r.casing, a.Type.Name)
 
 	case "array":
 		if a.Type.Element != nil && a.Type.Element.Kind == "reference" {
-			r.typeMap[a.Name] = "repeated " + toProtoMessageName(a.Type.Element.Name)
+			r.typeMap[a.Name] = "repeated " + toProtoMessageName(r.casing, a.Type.Element.Name)
 		}
 	}
 }
@@ -115,7 +120,7 @@ func (r *TypeResolver) Resolve(lspType string) string {
 		return protoType
 	}
 	// If it's a known union/structure, return the message name
-	return toProtoMessageName(lspType)
+	return toProtoMessageName(r.casing, lspType)
 }
 
 // IsKnown checks if a type is known (defined in model, well-known, or scalar).
@@ -0,0 +1,448 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+// Package zig generates Zig source code from the LSP specification model.
+//
+// The generated code works with std.json:
+//   - struct with fields named after the JSON properties for LSP structures
+//   - ?T defaulting to null for optional properties
+//   - enum with explicit values for enumerations
+//   - tagged union(enum) with custom JSON methods for union ("or") types
+//   - const aliases for LSP type aliases
+package zig
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/albertocavalcante/lspls/generator"
+	"github.com/albertocavalcante/lspls/internal/lspbase"
+	"github.com/albertocavalcante/lspls/model"
+)
+
+// Codegen generates Zig source from the LSP model.
+type Codegen struct {
+	model  *model.Model
+	config Config
+	log    *slog.Logger
+
+	// casing derives identifiers from LSP names.
+	casing *lspbase.Casing
+
+	types      *orderedMap[string]
+	typeFilter map[string]bool
+
+	// unions tracks generated tagged unions to avoid duplicates.
+	unions *orderedMap[unionInfo]
+
+	proposedTypes map[string]bool
+}
+
+// unionInfo holds information about a generated tagged union.
+type unionInfo struct {
+	name     string        // e.g. "Or_Int_String"
+	variants []variantInfo // sorted variant descriptors
+}
+
+// Output contains the generated Zig content.
+type Output struct {
+	Zig []byte
+}
+
+// New creates a new Zig Codegen.
+func New(m *model.Model, cfg Config) *Codegen {
+	c := &Codegen{
+		model:         m,
+		config:        cfg,
+		types:         newOrderedMap[string](),
+		unions:        newOrderedMap[unionInfo](),
+		proposedTypes: buildProposedCache(m),
+		casing:        lspbase.NewCasing(cfg.Acronyms, cfg.NameOverrides),
+	}
+	c.log = cfg.Logger
+	if c.log == nil {
+		c.log = slog.New(slog.DiscardHandler)
+	}
+	if len(cfg.Types) > 0 {
+		c.typeFilter = make(map[string]bool)
+		for _, t := range cfg.Types {
+			c.typeFilter[t] = true
+		}
+	}
+	return c
+}
+
+func buildProposedCache(m *model.Model) map[string]bool {
+	items := make([]lspbase.NamedProposal, 0, len(m.Structures)+len(m.Enumerations)+len(m.TypeAliases))
+	for _, s := range m.Structures {
+		items = append(items, lspbase.NamedProposal{Name: s.Name, Proposed: s.Proposed})
+	}
+	for _, e := range m.Enumerations {
+		items = append(items, lspbase.NamedProposal{Name: e.Name, Proposed: e.Proposed})
+	}
+	for _, a := range m.TypeAliases {
+		items = append(items, lspbase.NamedProposal{Name: a.Name, Proposed: a.Proposed})
+	}
+	return lspbase.ProposedTypes(items...)
+}
+
+// Generate produces the Zig source file.
+func (g *Codegen) Generate() (*Output, error) {
+	if g.typeFilter != nil && g.config.ResolveDeps {
+		g.typeFilter = generator.ResolveDeps(g.model, g.typeFilter, g.config.IncludeProposed)
+	}
+
+	for _, s := range g.model.Structures {
+		if !g.shouldInclude(s.Name, s.Proposed) {
+			continue
+		}
+		g.log.Debug("generating structure", "name", s.Name)
+		if err := g.checkJSONNames(s); err != nil {
+			return nil, err
+		}
+		g.generateStructure(s)
+	}
+
+	for _, e := range g.model.Enumerations {
+		if !g.shouldInclude(e.Name, e.Proposed) {
+			continue
+		}
+		g.log.Debug("generating enumeration", "name", e.Name)
+		g.generateEnumeration(e)
+	}
+
+	for _, a := range g.model.TypeAliases {
+		if !g.shouldInclude(a.Name, a.Proposed) {
+			continue
+		}
+		g.log.Debug("generating type alias", "name", a.Name)
+		g.generateTypeAlias(a)
+	}
+
+	return &Output{Zig: g.emit()}, nil
+}
+
+func (g *Codegen) shouldInclude(name string, proposed bool) bool {
+	if proposed && !g.config.IncludeProposed {
+		return false
+	}
+	if g.typeFilter != nil && !g.typeFilter[name] {
+		return false
+	}
+	return true
+}
+
+// docs returns doc, or the empty string when documentation is minified.
+func (g *Codegen) docs(doc string) string {
+	if g.config.MinifyDocs {
+		return ""
+	}
+	return doc
+}
+
+func (g *Codegen) isProposed(name string) bool {
+	return g.proposedTypes[name]
+}
+
+// ── Structure → struct ──────────────────────────────────────────────
+
+func (g *Codegen) generateStructure(s *model.Structure) {
+	var buf bytes.Buffer
+
+	writeDoc(&buf, "", g.docs(s.Documentation), s.Since, s.Deprecated)
+
+	// Collect properties (including inherited ones from extends/mixins)
+	props := g.collectProperties(s)
+
+	if len(props) == 0 {
+		fmt.Fprintf(&buf, "pub const %s = struct {};\n", g.typeName(s.Name))
+	} else {
+		fmt.Fprintf(&buf, "pub const %s = struct {\n", g.typeName(s.Name))
+		for _, p := range props {
+			g.generateField(&buf, &p, s.Since)
+		}
+		buf.WriteString("};\n")
+	}
+
+	g.types.set(s.Name, buf.String())
+}
+
+// collectProperties returns the properties of s with those of its extends
+// and mixins flattened in, each JSON name once: when a name repeats, the
+// most-derived property is kept.
+func (g *Codegen) collectProperties(s *model.Structure) []model.Property {
+	props, _ := generator.DedupProperties(g.flattenProperties(s))
+	return props
+}
+
+// checkJSONNames reports the JSON names that flattening gives more than
+// one property of s: as an error under StrictJSONNames, as a warning
+// otherwise.
+func (g *Codegen) checkJSONNames(s *model.Structure) error {
+	_, dups := generator.DedupProperties(g.flattenProperties(s))
+	return generator.CheckJSONNames(g.log, s.Name, dups, g.config.StrictJSONNames)
+}
+
+// flattenProperties gathers direct properties. Extends/mixins are flattened
+// into the struct because Zig structs have no inheritance.
+func (g *Codegen) flattenProperties(s *model.Structure) []model.Property {
+	var props []model.Property
+
+	for _, ext := range slices.Concat(s.Extends, s.Mixins) {
+		if ext.Kind == "reference" {
+			for _, parent := range g.model.Structures {
+				if parent.Name == ext.Name {
+					props = append(props, g.flattenProperties(parent)...)
+				}
+			}
+		}
+	}
+
+	// Own properties (skip proposed when not included)
+	for _, p := range s.Properties {
+		if p.Proposed && !g.config.IncludeProposed {
+			continue
+		}
+		props = append(props, p)
+	}
+
+	return props
+}
+
+// generateField writes the struct field for p. The field is named after
+// the JSON property, which is what std.json matches on. parentSince is the
+// enclosing structure's version; a property added later gets its own
+// @since line.
+func (g *Codegen) generateField(buf *bytes.Buffer, p *model.Property, parentSince string) {
+	doc := g.docs(p.Documentation)
+	writeDoc(buf, "    ", doc, lspbase.MemberSince(p.Since, parentSince, doc), p.Deprecated)
+
+	zt := g.zigType(p.Type)
+	if p.Optional {
+		// Absent properties parse as null; don't double-up nullable types.
+		if !strings.HasPrefix(zt, "?") {
+			zt = "?" + zt
+		}
+		fmt.Fprintf(buf, "    %s: %s = null,\n", identifier(p.Name), zt)
+		return
+	}
+	fmt.Fprintf(buf, "    %s: %s,\n", identifier(p.Name), zt)
+}
+
+// ── Enumeration → enum ──────────────────────────────────────────────
+
+func (g *Codegen) generateEnumeration(e *model.Enumeration) {
+	var buf bytes.Buffer
+
+	writeDoc(&buf, "", g.docs(e.Documentation), e.Since, e.Deprecated)
+
+	// Filter values for proposed
+	var values []model.EnumValue
+	for _, v := range e.Values {
+		if v.Proposed && !g.config.IncludeProposed {
+			continue
+		}
+		values = append(values, v)
+	}
+
+	if zigBaseType(e.Type) == "[]const u8" {
+		// String enum: std.json reads and writes tags by name, so each tag
+		// is the string value itself.
+		fmt.Fprintf(&buf, "pub const %s = enum {\n", g.typeName(e.Name))
+		for _, v := range values {
+			doc := g.docs(v.Documentation)
+			writeDoc(&buf, "    ", doc, lspbase.MemberSince(v.Since, e.Since, doc), "")
+			strVal, _ := v.Value.(string)
+			fmt.Fprintf(&buf, "    %s,\n", identifier(strVal))
+		}
+		buf.WriteString("};\n")
+		g.types.set(e.Name, buf.String())
+		return
+	}
+
+	// Integer enum: explicit values, written as numbers. Enumerations that
+	// allow custom values are non-exhaustive.
+	fmt.Fprintf(&buf, "pub const %s = enum(%s) {\n", g.typeName(e.Name), zigBaseType(e.Type))
+	for _, v := range values {
+		doc := g.docs(v.Documentation)
+		writeDoc(&buf, "    ", doc, lspbase.MemberSince(v.Since, e.Since, doc), "")
+		fmt.Fprintf(&buf, "    %s = %s,\n", g.enumTagName(v.Name), formatIntValue(v.Value))
+	}
+	if e.SupportsCustomValues {
+		buf.WriteString("    _,\n")
+	}
+	buf.WriteString("\n")
+	buf.WriteString("    pub fn jsonStringify(self: @This(), jws: anytype) !void {\n")
+	buf.WriteString("        try jws.write(@intFromEnum(self));\n")
+	buf.WriteString("    }\n")
+	buf.WriteString("};\n")
+
+	g.types.set(e.Name, buf.String())
+}
+
+// ── Type alias → const ──────────────────────────────────────────────
+
+func (g *Codegen) generateTypeAlias(a *model.TypeAlias) {
+	var buf bytes.Buffer
+
+	writeDoc(&buf, "", g.docs(a.Documentation), a.Since, a.Deprecated)
+	fmt.Fprintf(&buf, "pub const %s = %s;\n", g.typeName(a.Name), g.zigType(a.Type))
+
+	g.types.set(a.Name, buf.String())
+}
+
+// ── Tagged unions for union types ───────────────────────────────────
+
+func (g *Codegen) generateUnion(buf *bytes.Buffer, info unionInfo) {
+	memberTypes := make([]string, 0, len(info.variants))
+	for _, v := range info.variants {
+		memberTypes = append(memberTypes, v.zigType)
+	}
+	fmt.Fprintf(buf, "/// Union type: %s\n", strings.Join(memberTypes, " | "))
+	fmt.Fprintf(buf, "pub const %s = union(enum) {\n", info.name)
+	for _, v := range info.variants {
+		fmt.Fprintf(buf, "    %s: %s,\n", v.tag, v.zigType)
+	}
+
+	// std.json encodes unions as {"tag": value}; LSP unions are untagged.
+	buf.WriteString("\n")
+	buf.WriteString("    pub fn jsonStringify(self: @This(), jws: anytype) !void {\n")
+	buf.WriteString("        switch (self) {\n")
+	buf.WriteString("            inline else => |value| try jws.write(value),\n")
+	buf.WriteString("        }\n")
+	buf.WriteString("    }\n")
+	buf.WriteString("\n")
+	buf.WriteString("    pub fn jsonParse(allocator: std.mem.Allocator, source: anytype, options: std.json.ParseOptions) !@This() {\n")
+	buf.WriteString("        const value = try std.json.innerParse(std.json.Value, allocator, source, options);\n")
+	buf.WriteString("        return jsonParseFromValue(allocator, value, options);\n")
+	buf.WriteString("    }\n")
+	buf.WriteString("\n")
+	buf.WriteString("    pub fn jsonParseFromValue(allocator: std.mem.Allocator, source: std.json.Value, options: std.json.ParseOptions) std.json.ParseFromValueError!@This() {\n")
+
+	// Structures with a literal-valued property are selected by its value.
+	for _, v := range info.variants {
+		if v.discriminator != "" {
+			fmt.Fprintf(buf, "        if (%s)\n", v.discriminator)
+			fmt.Fprintf(buf, "            return .{ .%s = try std.json.parseFromValueLeaky(%s, allocator, source, options) };\n", v.tag, v.zigType)
+		}
+	}
+	buf.WriteString("        return parseUnion(@This(), allocator, source, options);\n")
+	buf.WriteString("    }\n")
+	buf.WriteString("};\n\n")
+}
+
+// unionHelpers are the functions the tagged unions' JSON methods share.
+const unionHelpers = `/// Returns the first member of union T that source parses as, in
+/// declaration order.
+fn parseUnion(comptime T: type, allocator: std.mem.Allocator, source: std.json.Value, options: std.json.ParseOptions) std.json.ParseFromValueError!T {
+    inline for (std.meta.fields(T)) |field| {
+        if (std.json.parseFromValueLeaky(field.type, allocator, source, options)) |value| {
+            return @unionInit(T, field.name, value);
+        } else |_| {}
+    }
+    return error.UnexpectedToken;
+}
+
+/// Reports whether source is an object whose property name equals literal.
+fn hasLiteral(source: std.json.Value, name: []const u8, literal: std.json.Value) bool {
+    if (source != .object) return false;
+    const value = source.object.get(name) orelse return false;
+    return switch (literal) {
+        .string => |s| value == .string and std.mem.eql(u8, value.string, s),
+        .integer => |n| value == .integer and value.integer == n,
+        .bool => |b| value == .bool and value.bool == b,
+        else => false,
+    };
+}
+`
+
+// ── Emit final file ─────────────────────────────────────────────────
+
+func (g *Codegen) emit() []byte {
+	var buf bytes.Buffer
+
+	buf.WriteString(g.fileHeader())
+	buf.WriteString("const std = @import(\"std\");\n\n")
+
+	// Types (structures, enums, type aliases) in sorted order
+	for _, name := range g.types.keys() {
+		buf.WriteString(g.types.get(name))
+		buf.WriteString("\n")
+	}
+
+	// Tagged unions for union types
+	keys := g.unions.keys()
+	for _, name := range keys {
+		g.generateUnion(&buf, g.unions.get(name))
+	}
+	if len(keys) > 0 {
+		buf.WriteString(unionHelpers)
+	}
+
+	return buf.Bytes()
+}
+
+func (g *Codegen) fileHeader() string {
+	var lines []string
+	lines = append(lines, "// Code generated by lspls. DO NOT EDIT.")
+	if g.config.Source != "" {
+		lines = append(lines, fmt.Sprintf("// Source: %s", g.config.Source))
+	}
+	if g.config.Ref != "" {
+		lines = append(lines, fmt.Sprintf("// Ref: %s", g.config.Ref))
+	}
+	if g.config.CommitHash != "" {
+		lines = append(lines, fmt.Sprintf("// Commit: %s", g.config.CommitHash))
+	}
+	if g.config.LSPVersion != "" {
+		lines = append(lines, fmt.Sprintf("// LSP Version: %s", g.config.LSPVersion))
+	}
+	if g.config.ToolVersion != "" {
+		lines = append(lines, fmt.Sprintf("// Generator: lspls %s", g.config.ToolVersion))
+	}
+	if !g.config.Timestamp.IsZero() {
+		lines = append(lines, fmt.Sprintf("// Generated: %s", g.config.Timestamp.UTC().Format(time.RFC3339)))
+	}
+	lines = append(lines, "")
+	return strings.Join(lines, "\n")
+}
+
+// ── Helpers ─────────────────────────────────────────────────────────
+
+// writeDoc writes doc, since, and deprecated as /// doc comments at indent.
+func writeDoc(buf *bytes.Buffer, indent, doc, since, deprecated string) {
+	if doc != "" {
+		lspbase.WriteComment(buf, indent+"///", doc)
+	}
+	hasSince := since != "" && !strings.Contains(doc, "@since "+since)
+	if hasSince {
+		if doc != "" {
+			fmt.Fprintf(buf, "%s///\n", indent)
+		}
+		fmt.Fprintf(buf, "%s/// @since %s\n", indent, since)
+	}
+	if deprecated != "" {
+		if doc != "" || hasSince {
+			fmt.Fprintf(buf, "%s///\n", indent)
+		}
+		fmt.Fprintf(buf, "%s/// @deprecated %s\n", indent, deprecated)
+	}
+}
+
+func formatIntValue(v any) string {
+	switch val := v.(type) {
+	case float64:
+		return fmt.Sprintf("%d", int64(val))
+	case int:
+		return fmt.Sprintf("%d", val)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
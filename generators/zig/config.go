@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package zig
+
+import (
+	"log/slog"
+	"time"
+)
+
+// Config holds configuration for Zig generation.
+type Config struct {
+	// TypePrefix and TypeSuffix are added to the name of every generated
+	// type, including Or_* unions. JSON property names are unchanged.
+	TypePrefix string
+	TypeSuffix string
+
+	// Acronyms are words cased the same way wherever they appear in a
+	// generated identifier. NameOverrides maps an LSP name to its
+	// identifier, bypassing the other rules.
+	Acronyms      []string
+	NameOverrides map[string]string
+
+	// Types to include (empty means all).
+	Types []string
+
+	// ResolveDeps includes transitively referenced types.
+	ResolveDeps bool
+
+	// IncludeProposed generates types marked as proposed.
+	IncludeProposed bool
+
+	// MinifyDocs omits documentation comments, keeping @since and
+	// @deprecated tags.
+	MinifyDocs bool
+
+	// StrictJSONNames fails generation when flattening extends and mixins
+	// gives more than one property of a structure the same JSON name,
+	// instead of keeping the most-derived one with a warning.
+	StrictJSONNames bool
+
+	// Source metadata for header comments.
+	Source     string
+	Ref        string
+	CommitHash string
+	LSPVersion string
+
+	// ToolVersion and Timestamp, when set, add the lspls version and the
+	// generation time to header comments.
+	ToolVersion string
+	Timestamp   time.Time
+
+	// Logger receives per-type progress at debug level and warnings about
+	// types that degrade to std.json.Value. If nil, nothing is logged.
+	Logger *slog.Logger
+}
@@ -0,0 +1,91 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package zig
+
+import (
+	"context"
+
+	"github.com/albertocavalcante/lspls/generator"
+	"github.com/albertocavalcante/lspls/model"
+)
+
+// Generator implements [generator.Generator] for Zig code generation.
+type Generator struct{}
+
+// NewGenerator creates a new Zig generator.
+func NewGenerator() *Generator {
+	return &Generator{}
+}
+
+// Metadata returns information about this generator.
+func (g *Generator) Metadata() generator.Metadata {
+	return generator.Metadata{
+		Name:           "zig",
+		Version:        "1.0.0",
+		Description:    "Generate Zig structs and tagged unions from LSP specification",
+		FileExtensions: []string{".zig"},
+		URL:            "https://github.com/albertocavalcante/lspls",
+	}
+}
+
+// Describe returns the options, outputs, and requirements of this generator.
+func (g *Generator) Describe() generator.Description {
+	return generator.Description{
+		Outputs: []string{
+			"protocol.zig: structs, enums, and tagged unions",
+			"index.md: type index (directory output, --index)",
+		},
+		Requirements: []string{
+			"Zig 0.12+ (std.json.innerParse, parseFromValueLeaky)",
+		},
+	}
+}
+
+// Generate produces Zig output files from the LSP model.
+func (g *Generator) Generate(ctx context.Context, m *model.Model, cfg generator.Config) (*generator.Output, error) {
+	m, err := generator.ResolveEnumBases(m)
+	if err != nil {
+		return nil, err
+	}
+	internalCfg := Config{
+		Types:           cfg.Types,
+		TypePrefix:      cfg.TypePrefix,
+		TypeSuffix:      cfg.TypeSuffix,
+		Acronyms:        cfg.Acronyms,
+		NameOverrides:   cfg.NameOverrides,
+		ResolveDeps:     cfg.ResolveDeps,
+		IncludeProposed: cfg.IncludeProposed,
+		MinifyDocs:      cfg.MinifyDocs,
+		StrictJSONNames: cfg.StrictJSONNames,
+		Source:          cfg.Source,
+		Ref:             cfg.Ref,
+		CommitHash:      cfg.CommitHash,
+		LSPVersion:      cfg.LSPVersion,
+		ToolVersion:     cfg.ToolVersion,
+		Timestamp:       cfg.Timestamp,
+		Logger:          cfg.Logger,
+	}
+
+	gen := New(m, internalCfg)
+	out, err := gen.Generate()
+	if err != nil {
+		return nil, err
+	}
+
+	result := generator.NewOutput()
+
+	filename := "protocol.zig"
+	if cfg.OutputFile != "" {
+		filename = cfg.OutputFile
+	}
+
+	result.Add(filename, out.Zig)
+	if cfg.Index && cfg.OutputDir != "" {
+		result.Add(generator.IndexFile, generator.MarkdownIndex("LSP Types", generator.Index(m, cfg)))
+	}
+	return result, nil
+}
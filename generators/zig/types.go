@@ -0,0 +1,318 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package zig
+
+import (
+	"cmp"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/albertocavalcante/lspls/internal/lspbase"
+	"github.com/albertocavalcante/lspls/model"
+)
+
+// zigType converts an LSP type to its Zig equivalent. T | null becomes ?T.
+func (g *Codegen) zigType(t *model.Type) string {
+	if t == nil {
+		return "std.json.Value"
+	}
+
+	if t.IsOptional() {
+		inner := g.zigType(t.NonNullType())
+		if strings.HasPrefix(inner, "?") {
+			return inner
+		}
+		return "?" + inner
+	}
+
+	switch t.Kind {
+	case "base":
+		return zigBaseType(t)
+
+	case "reference":
+		return g.typeName(t.Name)
+
+	case "array":
+		return "[]const " + g.zigType(t.Element)
+
+	case "map":
+		// std.json maps JSON objects to string-keyed hash maps only, which
+		// covers every LSP map key type.
+		valType := "std.json.Value"
+		if vt, ok := t.Value.(*model.Type); ok {
+			valType = g.zigType(vt)
+		}
+		return fmt.Sprintf("std.json.ArrayHashMap(%s)", valType)
+
+	case "literal":
+		g.log.Warn("literal type degraded to std.json.Value", "line", t.Line)
+		return "std.json.Value"
+
+	case "stringLiteral":
+		return "[]const u8"
+
+	case "integerLiteral":
+		return "i32"
+
+	case "booleanLiteral":
+		return "bool"
+
+	case "or":
+		return g.getOrType(t)
+
+	case "and":
+		g.log.Warn("intersection type degraded to std.json.Value", "line", t.Line)
+		return "std.json.Value"
+
+	case "tuple":
+		g.log.Warn("tuple type degraded to []const std.json.Value", "line", t.Line)
+		return "[]const std.json.Value"
+
+	default:
+		g.log.Warn("unknown type kind degraded to std.json.Value", "kind", t.Kind, "line", t.Line)
+		return "std.json.Value"
+	}
+}
+
+// zigBaseType maps an LSP base type name to a Zig type.
+func zigBaseType(t *model.Type) string {
+	switch t.Name {
+	case lspbase.TypeString, lspbase.TypeURI, lspbase.TypeDocumentURI, lspbase.TypeRegExp:
+		return "[]const u8"
+	case lspbase.TypeInteger:
+		return "i32"
+	case lspbase.TypeUinteger:
+		return "u32"
+	case lspbase.TypeDecimal:
+		return "f64"
+	case lspbase.TypeBoolean:
+		return "bool"
+	case lspbase.TypeLSPObject:
+		return "std.json.ArrayHashMap(std.json.Value)"
+	case lspbase.TypeLSPArray:
+		return "[]const std.json.Value"
+	default: // LSPAny, null
+		return "std.json.Value"
+	}
+}
+
+// typeNameForIdent returns an identifier-safe name for an LSP type,
+// used when building union names (e.g. Or_TextEdit_Location).
+func (g *Codegen) typeNameForIdent(t *model.Type) string {
+	if t == nil {
+		return "Value"
+	}
+	switch t.Kind {
+	case "base":
+		switch t.Name {
+		case lspbase.TypeString, lspbase.TypeURI, lspbase.TypeDocumentURI, lspbase.TypeRegExp:
+			return "String"
+		case lspbase.TypeInteger:
+			return "Int"
+		case lspbase.TypeUinteger:
+			return "Uint"
+		case lspbase.TypeDecimal:
+			return "Float"
+		case lspbase.TypeBoolean:
+			return "Bool"
+		case lspbase.TypeLSPObject:
+			return "Object"
+		case lspbase.TypeLSPArray:
+			return "Array"
+		default:
+			return "Value"
+		}
+	case "reference":
+		return g.casing.ExportName(t.Name)
+	case "array":
+		return "Arr" + g.typeNameForIdent(t.Element)
+	case "map":
+		valName := "Value"
+		if vt, ok := t.Value.(*model.Type); ok {
+			valName = g.typeNameForIdent(vt)
+		}
+		return "Map" + g.typeNameForIdent(t.Key) + valName
+	case "literal":
+		return "Literal"
+	case "stringLiteral":
+		return "String"
+	case "integerLiteral":
+		return "Int"
+	case "booleanLiteral":
+		return "Bool"
+	case "or":
+		return "Union"
+	case "and":
+		return "Intersection"
+	case "tuple":
+		return "Tuple"
+	default:
+		return "Value"
+	}
+}
+
+// variantInfo describes one field of a tagged union.
+type variantInfo struct {
+	identName     string // identifier-safe name (for the union name)
+	tag           string // union field name
+	zigType       string // full Zig type
+	discriminator string // condition on source selecting this variant, if any
+}
+
+// getOrType returns the Zig type name for an "or" union type, registering
+// a tagged union for generation if not already done.
+func (g *Codegen) getOrType(t *model.Type) string {
+	if t.Kind != "or" || len(t.Items) == 0 {
+		return "std.json.Value"
+	}
+
+	// Filter out null items and proposed types
+	var nonNullItems []*model.Type
+	for _, item := range t.Items {
+		if item.Kind == "base" && item.Name == "null" {
+			continue
+		}
+		if !g.config.IncludeProposed && item.Kind == "reference" && g.isProposed(item.Name) {
+			continue
+		}
+		nonNullItems = append(nonNullItems, item)
+	}
+
+	if len(nonNullItems) == 0 {
+		return "std.json.Value"
+	}
+	if len(nonNullItems) == 1 {
+		return g.zigType(nonNullItems[0])
+	}
+
+	var variants []variantInfo
+	for _, item := range nonNullItems {
+		ident := g.typeNameForIdent(item)
+		variants = append(variants, variantInfo{
+			identName:     ident,
+			tag:           g.enumTagName(ident),
+			zigType:       g.zigType(item),
+			discriminator: g.discriminator(item),
+		})
+	}
+
+	slices.SortFunc(variants, func(a, b variantInfo) int {
+		return cmp.Compare(a.identName, b.identName)
+	})
+	// Members that map to the same Zig type, such as string and
+	// DocumentUri, share one field.
+	variants = slices.CompactFunc(variants, func(a, b variantInfo) bool {
+		return a.identName == b.identName
+	})
+	if len(variants) == 1 {
+		return variants[0].zigType
+	}
+
+	var identNames []string
+	for _, v := range variants {
+		identNames = append(identNames, v.identName)
+	}
+
+	unionName := g.config.TypePrefix + "Or_" + strings.Join(identNames, "_") + g.config.TypeSuffix
+
+	if _, exists := g.unions.m[unionName]; !exists {
+		g.unions.set(unionName, unionInfo{
+			name:     unionName,
+			variants: variants,
+		})
+	}
+
+	return unionName
+}
+
+// discriminator returns a condition on the JSON value source that
+// identifies item within a union, when item references a structure with a
+// literal-valued property. It returns "" otherwise.
+func (g *Codegen) discriminator(item *model.Type) string {
+	if item.Kind != "reference" {
+		return ""
+	}
+	for _, s := range g.model.Structures {
+		if s.Name != item.Name {
+			continue
+		}
+		p, ok := s.Discriminator()
+		if !ok {
+			return ""
+		}
+		var literal string
+		switch p.Type.Kind {
+		case "stringLiteral":
+			literal = fmt.Sprintf(".{ .string = %s }", strconv.Quote(fmt.Sprint(p.Type.Value)))
+		case "integerLiteral":
+			literal = fmt.Sprintf(".{ .integer = %s }", formatIntValue(p.Type.Value))
+		default:
+			literal = fmt.Sprintf(".{ .bool = %v }", p.Type.Value)
+		}
+		return fmt.Sprintf("hasLiteral(source, %q, %s)", p.Name, literal)
+	}
+	return ""
+}
+
+// typeName converts an LSP type name to a Zig type name (PascalCase),
+// with TypePrefix and TypeSuffix applied.
+func (g *Codegen) typeName(name string) string {
+	return identifier(g.config.TypePrefix + g.casing.ExportName(name) + g.config.TypeSuffix)
+}
+
+// enumTagName converts an enum value name or union member name to a Zig
+// field name (snake_case).
+func (g *Codegen) enumTagName(name string) string {
+	return identifier(g.casing.CamelToSnake(name))
+}
+
+// identifier returns name as a Zig identifier. Names that are keywords,
+// primitive types, or not identifiers at all are quoted as @"name", which
+// Zig and std.json treat exactly like the bare name.
+func identifier(name string) string {
+	if zigIdentRe.MatchString(name) && !zigReserved[name] && !zigIntTypeRe.MatchString(name) {
+		return name
+	}
+	return "@" + strconv.Quote(name)
+}
+
+var (
+	zigIdentRe   = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	zigIntTypeRe = regexp.MustCompile(`^[iu][0-9]+$`)
+)
+
+// zigReserved lists Zig keywords, primitive types, and primitive values,
+// none of which may be used as a bare identifier.
+var zigReserved = map[string]bool{
+	// Keywords
+	"addrspace": true, "align": true, "allowzero": true, "and": true,
+	"anyframe": true, "anytype": true, "asm": true, "async": true,
+	"await": true, "break": true, "callconv": true, "catch": true,
+	"comptime": true, "const": true, "continue": true, "defer": true,
+	"else": true, "enum": true, "errdefer": true, "error": true,
+	"export": true, "extern": true, "fn": true, "for": true, "if": true,
+	"inline": true, "linksection": true, "noalias": true, "noinline": true,
+	"nosuspend": true, "opaque": true, "or": true, "orelse": true,
+	"packed": true, "pub": true, "resume": true, "return": true,
+	"struct": true, "suspend": true, "switch": true, "test": true,
+	"threadlocal": true, "try": true, "union": true, "unreachable": true,
+	"usingnamespace": true, "var": true, "volatile": true, "while": true,
+
+	// Primitive types
+	"anyerror": true, "anyopaque": true, "bool": true, "c_char": true,
+	"c_int": true, "c_long": true, "c_longdouble": true, "c_longlong": true,
+	"c_short": true, "c_uint": true, "c_ulong": true, "c_ulonglong": true,
+	"c_ushort": true, "comptime_float": true, "comptime_int": true,
+	"f16": true, "f32": true, "f64": true, "f80": true, "f128": true,
+	"isize": true, "noreturn": true, "type": true, "usize": true, "void": true,
+
+	// Primitive values
+	"_": true, "false": true, "null": true, "true": true, "undefined": true,
+}
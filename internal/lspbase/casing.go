@@ -0,0 +1,173 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package lspbase
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Casing customizes the identifier casing of the name helpers. Its methods
+// behave as the package functions of the same names on a nil *Casing or
+// one with no acronyms and overrides.
+type Casing struct {
+	// Acronyms are words written the same way wherever they appear as a
+	// word of a name, such as "URI" in "DocumentUri" -> "DocumentURI" and
+	// "documentURI" -> "document_uri". A word matches an acronym
+	// regardless of its case.
+	Acronyms []string
+
+	// Overrides maps an LSP name to the identifier Capitalize, ExportName,
+	// and FieldName return for it, bypassing every other rule.
+	Overrides map[string]string
+}
+
+// NewCasing returns a Casing with the given acronyms and overrides, or nil
+// if there are neither.
+func NewCasing(acronyms []string, overrides map[string]string) *Casing {
+	if len(acronyms) == 0 && len(overrides) == 0 {
+		return nil
+	}
+	return &Casing{Acronyms: acronyms, Overrides: overrides}
+}
+
+// Capitalize is like the package function Capitalize, applying the
+// overrides and acronyms.
+func (c *Casing) Capitalize(name string) string {
+	if id, ok := c.override(name); ok {
+		return id
+	}
+	return c.acronyms(Capitalize(name), false)
+}
+
+// ExportName is like the package function ExportName, applying the
+// overrides and acronyms.
+func (c *Casing) ExportName(name string) string {
+	if id, ok := c.override(name); ok {
+		return id
+	}
+	return c.acronyms(ExportName(name), false)
+}
+
+// FieldName is like the package function FieldName, applying the
+// overrides and acronyms. A lowercase first word is kept lowercase, so
+// "uri" stays "uri" while "documentUri" -> "documentURI".
+func (c *Casing) FieldName(name string) string {
+	if id, ok := c.override(name); ok {
+		return id
+	}
+	return c.acronyms(FieldName(name), true)
+}
+
+// CamelToSnake is like the package function CamelToSnake, keeping an
+// acronym written in uppercase as one word.
+func (c *Casing) CamelToSnake(name string) string {
+	if c == nil || len(c.Acronyms) == 0 || isAllUpper(name) {
+		return CamelToSnake(name)
+	}
+	return strings.ToLower(strings.Join(c.words(name), "_"))
+}
+
+// CamelToScreamingSnake is like the package function CamelToScreamingSnake,
+// keeping an acronym written in uppercase as one word.
+func (c *Casing) CamelToScreamingSnake(name string) string {
+	if c == nil || len(c.Acronyms) == 0 || isAllUpper(name) {
+		return CamelToScreamingSnake(name)
+	}
+	return strings.ToUpper(strings.Join(c.words(name), "_"))
+}
+
+// override returns the override of name, if any.
+func (c *Casing) override(name string) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	id, ok := c.Overrides[name]
+	return id, ok
+}
+
+// acronyms returns id with each of its words that matches an acronym
+// replaced by it. If keepFirst is set, a first word starting with a
+// lowercase letter is left alone.
+func (c *Casing) acronyms(id string, keepFirst bool) string {
+	if c == nil || len(c.Acronyms) == 0 {
+		return id
+	}
+	words := c.words(id)
+	for i, w := range words {
+		if i == 0 && keepFirst {
+			if r, _ := utf8.DecodeRuneInString(w); unicode.IsLower(r) {
+				continue
+			}
+		}
+		for _, a := range c.Acronyms {
+			if strings.EqualFold(w, a) {
+				words[i] = a
+				break
+			}
+		}
+	}
+	return strings.Join(words, "")
+}
+
+// words splits name before each uppercase letter, as CamelToSnake does,
+// except that an acronym written in uppercase and not followed by a
+// lowercase letter is kept as one word ("URIScheme" -> "URI", "Scheme").
+// Joining the words gives back name.
+func (c *Casing) words(name string) []string {
+	var words []string
+	start := 0
+	for i := 0; i < len(name); {
+		if n := c.acronymAt(name, i); n > 0 {
+			if i > start {
+				words = append(words, name[start:i])
+			}
+			words = append(words, name[i:i+n])
+			i += n
+			start = i
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(name[i:])
+		if unicode.IsUpper(r) && i > start {
+			words = append(words, name[start:i])
+			start = i
+		}
+		i += size
+	}
+	if start < len(name) {
+		words = append(words, name[start:])
+	}
+	return words
+}
+
+// acronymAt returns the length of the longest acronym written in
+// uppercase at name[i:] and not followed by a lowercase letter, or 0.
+func (c *Casing) acronymAt(name string, i int) int {
+	longest := 0
+	for _, a := range c.Acronyms {
+		upper := strings.ToUpper(a)
+		if len(upper) <= longest || !strings.HasPrefix(name[i:], upper) {
+			continue
+		}
+		if r, _ := utf8.DecodeRuneInString(name[i+len(upper):]); unicode.IsLower(r) {
+			continue
+		}
+		longest = len(upper)
+	}
+	return longest
+}
+
+// isAllUpper reports whether every letter of name is uppercase.
+func isAllUpper(name string) bool {
+	for _, r := range name {
+		if !unicode.IsUpper(r) && unicode.IsLetter(r) {
+			return false
+		}
+	}
+	return true
+}
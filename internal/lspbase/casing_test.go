@@ -0,0 +1,91 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package lspbase
+
+import "testing"
+
+func TestCasingAcronyms(t *testing.T) {
+	c := NewCasing([]string{"URI", "ID", "JSON"}, nil)
+	tests := []struct {
+		name string
+		fn   func(string) string
+		in   string
+		want string
+	}{
+		{name: "export title case", fn: c.ExportName, in: "DocumentUri", want: "DocumentURI"},
+		{name: "export lowercase", fn: c.ExportName, in: "uri", want: "URI"},
+		{name: "export middle", fn: c.ExportName, in: "textDocumentIdentifier", want: "TextDocumentIdentifier"},
+		{name: "export two acronyms", fn: c.ExportName, in: "jsonId", want: "JSONID"},
+		{name: "export plural left", fn: c.ExportName, in: "baseUris", want: "BaseUris"},
+		{name: "export method", fn: c.ExportName, in: "workspace/didChangeUri", want: "WorkspaceDidChangeURI"},
+		{name: "export underscore", fn: c.ExportName, in: "_id", want: "Xid"},
+		{name: "capitalize", fn: c.Capitalize, in: "requestId", want: "RequestID"},
+		{name: "field first word kept", fn: c.FieldName, in: "uri", want: "uri"},
+		{name: "field later word", fn: c.FieldName, in: "documentUri", want: "documentURI"},
+		{name: "field meta prefix", fn: c.FieldName, in: "$id", want: "id"},
+		{name: "snake uppercase acronym", fn: c.CamelToSnake, in: "documentURI", want: "document_uri"},
+		{name: "snake leading acronym", fn: c.CamelToSnake, in: "URIScheme", want: "uri_scheme"},
+		{name: "snake title case", fn: c.CamelToSnake, in: "documentUri", want: "document_uri"},
+		{name: "snake all upper", fn: c.CamelToSnake, in: "URI", want: "uri"},
+		{name: "snake not acronym", fn: c.CamelToSnake, in: "ABCDef", want: "a_b_c_def"},
+		{name: "snake acronym before lowercase", fn: c.CamelToSnake, in: "IDs", want: "i_ds"},
+		{name: "screaming", fn: c.CamelToScreamingSnake, in: "JSONValue", want: "JSON_VALUE"},
+		{name: "screaming two acronyms", fn: c.CamelToScreamingSnake, in: "JSONURIKind", want: "JSON_URI_KIND"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.fn(tc.in); got != tc.want {
+				t.Errorf("%s(%q) = %q, want %q", tc.name, tc.in, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCasingLongestAcronym(t *testing.T) {
+	c := NewCasing([]string{"UR", "URI"}, nil)
+	if got, want := c.CamelToSnake("baseURIValue"), "base_uri_value"; got != want {
+		t.Errorf("CamelToSnake = %q, want %q", got, want)
+	}
+}
+
+func TestCasingOverrides(t *testing.T) {
+	c := NewCasing([]string{"URI"}, map[string]string{"DocumentUri": "DocURI", "uri": "Location"})
+	if got, want := c.ExportName("DocumentUri"), "DocURI"; got != want {
+		t.Errorf("ExportName = %q, want %q", got, want)
+	}
+	if got, want := c.FieldName("uri"), "Location"; got != want {
+		t.Errorf("FieldName = %q, want %q", got, want)
+	}
+	// Overrides do not apply to the snake case helpers.
+	if got, want := c.CamelToSnake("DocumentUri"), "document_uri"; got != want {
+		t.Errorf("CamelToSnake = %q, want %q", got, want)
+	}
+}
+
+// TestCasingDefault checks that a nil Casing behaves as the package
+// functions.
+func TestCasingDefault(t *testing.T) {
+	c := NewCasing(nil, nil)
+	if c != nil {
+		t.Fatalf("NewCasing(nil, nil) = %v, want nil", c)
+	}
+	for _, name := range []string{"DocumentUri", "documentURI", "_internal", "$data", "URI", "content-type"} {
+		if got, want := c.ExportName(name), ExportName(name); got != want {
+			t.Errorf("ExportName(%q) = %q, want %q", name, got, want)
+		}
+		if got, want := c.FieldName(name), FieldName(name); got != want {
+			t.Errorf("FieldName(%q) = %q, want %q", name, got, want)
+		}
+		if got, want := c.CamelToSnake(name), CamelToSnake(name); got != want {
+			t.Errorf("CamelToSnake(%q) = %q, want %q", name, got, want)
+		}
+		if got, want := c.CamelToScreamingSnake(name), CamelToScreamingSnake(name); got != want {
+			t.Errorf("CamelToScreamingSnake(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
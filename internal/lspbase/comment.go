@@ -0,0 +1,27 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package lspbase
+
+import (
+	"io"
+	"strings"
+)
+
+// WriteComment writes doc to w as line comments, one per line of doc, each
+// starting with prefix (such as "//" or "\t *") and a space. Blank lines get
+// the bare prefix and trailing whitespace is dropped, so paragraph breaks
+// don't leave the trailing spaces linters flag.
+func WriteComment(w io.Writer, prefix, doc string) {
+	for line := range strings.SplitSeq(doc, "\n") {
+		line = strings.TrimRight(line, " \t\r")
+		if line == "" {
+			_, _ = io.WriteString(w, prefix+"\n")
+		} else {
+			_, _ = io.WriteString(w, prefix+" "+line+"\n")
+		}
+	}
+}
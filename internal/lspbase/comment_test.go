@@ -0,0 +1,37 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package lspbase
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWriteComment(t *testing.T) {
+	tests := []struct {
+		name   string
+		prefix string
+		doc    string
+		want   string
+	}{
+		{name: "single line", prefix: "//", doc: "A position.", want: "// A position.\n"},
+		{name: "blank line", prefix: "//", doc: "First.\n\nSecond.", want: "// First.\n//\n// Second.\n"},
+		{name: "whitespace-only line", prefix: "\t//", doc: "First.\n   \nSecond.", want: "\t// First.\n\t//\n\t// Second.\n"},
+		{name: "trailing whitespace", prefix: " *", doc: "First.  \r\nSecond.", want: " * First.\n * Second.\n"},
+		{name: "indentation kept", prefix: "//", doc: "Example:\n    code()", want: "// Example:\n//     code()\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b strings.Builder
+			WriteComment(&b, tt.prefix, tt.doc)
+			if got := b.String(); got != tt.want {
+				t.Errorf("WriteComment(%q, %q) = %q, want %q", tt.prefix, tt.doc, got, tt.want)
+			}
+		})
+	}
+}
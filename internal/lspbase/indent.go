@@ -0,0 +1,36 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package lspbase
+
+import (
+	"bytes"
+	"strings"
+)
+
+// Reindent replaces the indentation unit from, with which an emitter
+// writes src, by to: every run of from at the start of a line becomes the
+// same number of to. Spaces left over after the last whole unit, such as
+// the one before the " *" of a block comment, are kept. An empty to
+// returns src unchanged.
+func Reindent(src []byte, from, to string) []byte {
+	if to == "" || to == from {
+		return src
+	}
+	var out bytes.Buffer
+	out.Grow(len(src))
+	for line := range bytes.Lines(src) {
+		rest := line
+		n := 0
+		for bytes.HasPrefix(rest, []byte(from)) {
+			rest = rest[len(from):]
+			n++
+		}
+		out.WriteString(strings.Repeat(to, n))
+		out.Write(rest)
+	}
+	return out.Bytes()
+}
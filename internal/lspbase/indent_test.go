@@ -0,0 +1,26 @@
+// SPDX-License-Identifier: MIT
+
+package lspbase
+
+import "testing"
+
+func TestReindent(t *testing.T) {
+	const src = "class A {\n    /**\n     * Doc.\n     */\n    val x: Int\n        get() = 1\n}\n"
+	tests := []struct {
+		name string
+		to   string
+		want string
+	}{
+		{name: "tab", to: "\t", want: "class A {\n\t/**\n\t * Doc.\n\t */\n\tval x: Int\n\t\tget() = 1\n}\n"},
+		{name: "two spaces", to: "  ", want: "class A {\n  /**\n   * Doc.\n   */\n  val x: Int\n    get() = 1\n}\n"},
+		{name: "unchanged", to: "", want: src},
+		{name: "same unit", to: "    ", want: src},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(Reindent([]byte(src), "    ", tt.to)); got != tt.want {
+				t.Errorf("Reindent(%q) = %q, want %q", tt.to, got, tt.want)
+			}
+		})
+	}
+}
@@ -31,14 +31,22 @@ func StripMeta(name string) string {
 
 // ExportName returns a Go-safe exported identifier for the given LSP name.
 // Names starting with "_" are prefixed with "X" (e.g., "_foo" -> "Xfoo").
-// All other names get their first letter uppercased.
+// All other names get their first letter uppercased. Characters that
+// cannot appear in an identifier, such as "$" and "-", are dropped and
+// the letter after them uppercased ("$ref" -> "Ref", "foo-bar" ->
+// "FooBar"); names that would then start with a digit are prefixed with
+// "X" ("1st" -> "X1st").
 func ExportName(name string) string {
 	if name == "" {
 		return ""
 	}
 	// Handle names starting with underscore (internal types)
 	if name[0] == '_' {
-		return "X" + name[1:]
+		return "X" + sanitizeIdent(name[1:])
+	}
+	name = sanitizeIdent(name)
+	if name == "" || unicode.IsDigit([]rune(name)[0]) {
+		return "X" + name
 	}
 	// Capitalize first letter
 	runes := []rune(name)
@@ -46,18 +54,43 @@ func ExportName(name string) string {
 	return string(runes)
 }
 
+// FieldName returns a property identifier for the JVM targets: name with
+// its meta-prefix stripped (see StripMeta) and characters that cannot
+// appear in an identifier dropped as in ExportName, keeping the case of the
+// first letter. Names that would then be empty or start with a digit are
+// prefixed with "_" ("$1" -> "_1").
+func FieldName(name string) string {
+	name = sanitizeIdent(StripMeta(name))
+	if name == "" || unicode.IsDigit([]rune(name)[0]) {
+		return "_" + name
+	}
+	return name
+}
+
+// sanitizeIdent drops the characters of name that are not letters, digits,
+// or "_", uppercasing the letter that follows each dropped run.
+func sanitizeIdent(name string) string {
+	var b strings.Builder
+	upper := false
+	for _, r := range name {
+		if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
 // CamelToSnake converts a CamelCase name to snake_case.
 // Fully uppercase names (like "URI") are lowered as a single word.
 func CamelToSnake(name string) string {
 	// Check if entire name is uppercase (like URI, ID)
-	allUpper := true
-	for _, r := range name {
-		if !unicode.IsUpper(r) && unicode.IsLetter(r) {
-			allUpper = false
-			break
-		}
-	}
-	if allUpper {
+	if isAllUpper(name) {
 		return strings.ToLower(name)
 	}
 
@@ -79,14 +112,7 @@ func CamelToSnake(name string) string {
 // Fully uppercase names (like "URI") are returned as-is.
 func CamelToScreamingSnake(name string) string {
 	// Check if entire name is uppercase (like URI, ID)
-	allUpper := true
-	for _, r := range name {
-		if !unicode.IsUpper(r) && unicode.IsLetter(r) {
-			allUpper = false
-			break
-		}
-	}
-	if allUpper {
+	if isAllUpper(name) {
 		return strings.ToUpper(name)
 	}
 
@@ -67,6 +67,15 @@ func TestExportName(t *testing.T) {
 		{name: "single char", input: "a", expected: "A"},
 		{name: "all caps", input: "URL", expected: "URL"},
 		{name: "camelCase", input: "textDocument", expected: "TextDocument"},
+		{name: "dollar prefix", input: "$data", expected: "Data"},
+		{name: "dollar inside", input: "foo$bar", expected: "FooBar"},
+		{name: "hyphen", input: "content-type", expected: "ContentType"},
+		{name: "dots and slash", input: "a.b/c", expected: "ABC"},
+		{name: "digit first", input: "1st", expected: "X1st"},
+		{name: "digit after invalid", input: "$1", expected: "X1"},
+		{name: "only invalid", input: "$", expected: "X"},
+		{name: "underscore then hyphen", input: "_a-b", expected: "XaB"},
+		{name: "inner underscore kept", input: "foo_bar", expected: "Foo_bar"},
 	}
 
 	for _, tc := range tests {
@@ -78,6 +87,30 @@ func TestExportName(t *testing.T) {
 	}
 }
 
+func TestFieldName(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "plain", input: "textDocument", expected: "textDocument"},
+		{name: "dollar prefix", input: "$data", expected: "data"},
+		{name: "underscore prefix", input: "_meta", expected: "meta"},
+		{name: "hyphen", input: "content-type", expected: "contentType"},
+		{name: "digit first", input: "1st", expected: "_1st"},
+		{name: "digit after prefix", input: "$1", expected: "_1"},
+		{name: "only invalid", input: "-", expected: "_"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := FieldName(tc.input); got != tc.expected {
+				t.Errorf("FieldName(%q) = %q, want %q", tc.input, got, tc.expected)
+			}
+		})
+	}
+}
+
 func TestCamelToSnake(t *testing.T) {
 	tests := []struct {
 		name     string
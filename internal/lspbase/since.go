@@ -0,0 +1,20 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package lspbase
+
+import "strings"
+
+// MemberSince returns the @since version to emit for a property or enum
+// value. It returns the empty string when the member has no version, when
+// the version matches the enclosing type's (which already carries it), or
+// when the member's documentation already mentions it.
+func MemberSince(since, parentSince, doc string) string {
+	if since == "" || since == parentSince || strings.Contains(doc, "@since "+since) {
+		return ""
+	}
+	return since
+}
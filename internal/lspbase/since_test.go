@@ -0,0 +1,33 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package lspbase
+
+import "testing"
+
+func TestMemberSince(t *testing.T) {
+	tests := []struct {
+		name        string
+		since       string
+		parentSince string
+		doc         string
+		want        string
+	}{
+		{name: "no version", since: "", parentSince: "3.16.0", want: ""},
+		{name: "same as parent", since: "3.16.0", parentSince: "3.16.0", want: ""},
+		{name: "newer than parent", since: "3.17.0", parentSince: "3.16.0", want: "3.17.0"},
+		{name: "parent unversioned", since: "3.17.0", parentSince: "", want: "3.17.0"},
+		{name: "already documented", since: "3.17.0", doc: "Doc.\n\n@since 3.17.0", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MemberSince(tt.since, tt.parentSince, tt.doc); got != tt.want {
+				t.Errorf("MemberSince(%q, %q, %q) = %q, want %q", tt.since, tt.parentSince, tt.doc, got, tt.want)
+			}
+		})
+	}
+}
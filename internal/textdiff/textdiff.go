@@ -0,0 +1,197 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+// Package textdiff computes line differences between two texts and
+// renders them as unified diffs.
+package textdiff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// context is the number of unchanged lines shown around each change.
+const context = 3
+
+// edit is one line of a diff: kept (' '), deleted ('-'), or inserted
+// ('+'). old and new are the indexes of the line in the old and new
+// text, or of the line it comes before where it is absent.
+type edit struct {
+	kind     byte
+	line     string
+	old, new int
+}
+
+// Unified returns the unified diff turning old into new, with oldName and
+// newName on its "---" and "+++" lines, or "" if the texts are equal.
+func Unified(oldName, newName, old, new string) string {
+	if old == new {
+		return ""
+	}
+	edits := diff(splitLines(old), splitLines(new))
+
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "--- %s\n+++ %s\n", oldName, newName)
+	for start := 0; start < len(edits); {
+		for start < len(edits) && edits[start].kind == ' ' {
+			start++
+		}
+		if start == len(edits) {
+			break
+		}
+		// A hunk runs until more than twice the context of unchanged
+		// lines separates two changes.
+		end := start
+		for i := start; i < len(edits); i++ {
+			if edits[i].kind != ' ' {
+				end = i + 1
+			} else if i-end >= 2*context {
+				break
+			}
+		}
+		first, last := max(start-context, 0), min(end+context, len(edits))
+		writeHunk(&buf, edits[first:last])
+		start = last
+	}
+	return buf.String()
+}
+
+// writeHunk writes the "@@" header and lines of one hunk.
+func writeHunk(buf *strings.Builder, hunk []edit) {
+	oldCount, newCount := 0, 0
+	for _, e := range hunk {
+		if e.kind != '+' {
+			oldCount++
+		}
+		if e.kind != '-' {
+			newCount++
+		}
+	}
+	fmt.Fprintf(buf, "@@ -%s +%s @@\n", hunkRange(hunk[0].old, oldCount), hunkRange(hunk[0].new, newCount))
+	for _, e := range hunk {
+		buf.WriteByte(e.kind)
+		buf.WriteString(e.line)
+		if !strings.HasSuffix(e.line, "\n") {
+			buf.WriteString("\n\\ No newline at end of file\n")
+		}
+	}
+}
+
+// hunkRange formats the 1-based line range of count lines starting at
+// index start. An empty range names the line before it, as diff -u does.
+func hunkRange(start, count int) string {
+	switch count {
+	case 0:
+		return fmt.Sprintf("%d,0", start)
+	case 1:
+		return fmt.Sprint(start + 1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+// splitLines splits s after each newline. A last line without one is
+// kept.
+func splitLines(s string) []string {
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// diff returns the edits turning a into b. Lines common to the start and
+// end are kept as they are, and the rest is compared with Myers'
+// algorithm, which is fast when few lines change, as between two
+// generations of the same specification.
+func diff(a, b []string) []edit {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	edits := make([]edit, 0, len(a)+len(b))
+	for i := range prefix {
+		edits = append(edits, edit{kind: ' ', line: a[i], old: i, new: i})
+	}
+	for _, e := range myers(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]) {
+		e.old += prefix
+		e.new += prefix
+		edits = append(edits, e)
+	}
+	for i := range suffix {
+		oi, ni := len(a)-suffix+i, len(b)-suffix+i
+		edits = append(edits, edit{kind: ' ', line: a[oi], old: oi, new: ni})
+	}
+	return edits
+}
+
+// myers returns a shortest edit script turning a into b. trace keeps, for
+// each number of edits d, the furthest x reached on each diagonal k = x-y
+// before step d, from which the path is walked back.
+func myers(a, b []string) []edit {
+	n, m := len(a), len(b)
+	offset := n + m + 1
+	v := make([]int, 2*offset+1)
+	var trace [][]int
+search:
+	for d := 0; d <= n+m; d++ {
+		trace = append(trace, append([]int(nil), v[offset-d-1:offset+d+2]...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				break search
+			}
+		}
+	}
+
+	var rev []edit
+	x, y := n, m
+	for d := len(trace) - 1; d >= 0; d-- {
+		at := func(k int) int { return trace[d][k+d+1] }
+		k := x - y
+		prevK := k - 1
+		if k == -d || (k != d && at(k-1) < at(k+1)) {
+			prevK = k + 1
+		}
+		prevX := at(prevK)
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			x--
+			y--
+			rev = append(rev, edit{kind: ' ', line: a[x], old: x, new: y})
+		}
+		if d == 0 {
+			break
+		}
+		if x == prevX {
+			rev = append(rev, edit{kind: '+', line: b[prevY], old: prevX, new: prevY})
+		} else {
+			rev = append(rev, edit{kind: '-', line: a[prevX], old: prevX, new: prevY})
+		}
+		x, y = prevX, prevY
+	}
+
+	edits := make([]edit, len(rev))
+	for i, e := range rev {
+		edits[len(rev)-1-i] = e
+	}
+	return edits
+}
@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: MIT
+
+package textdiff
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestUnified(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new string
+		want     string
+	}{
+		{name: "equal", old: "a\nb\n", new: "a\nb\n", want: ""},
+		{
+			name: "change",
+			old:  "a\nb\nc\n",
+			new:  "a\nB\nc\n",
+			want: "--- old\n+++ new\n@@ -1,3 +1,3 @@\n a\n-b\n+B\n c\n",
+		},
+		{
+			name: "new file",
+			old:  "",
+			new:  "a\nb\n",
+			want: "--- old\n+++ new\n@@ -0,0 +1,2 @@\n+a\n+b\n",
+		},
+		{
+			name: "deleted file",
+			old:  "a\n",
+			new:  "",
+			want: "--- old\n+++ new\n@@ -1 +0,0 @@\n-a\n",
+		},
+		{
+			name: "no newline at end",
+			old:  "a\nb",
+			new:  "a\nb\n",
+			want: "--- old\n+++ new\n@@ -1,2 +1,2 @@\n a\n-b\n\\ No newline at end of file\n+b\n",
+		},
+		{
+			name: "separate hunks",
+			old:  lines(1, 20),
+			new:  strings.Replace(strings.Replace(lines(1, 20), "2\n", "two\n", 1), "19\n", "", 1),
+			want: "--- old\n+++ new\n" +
+				"@@ -1,5 +1,5 @@\n 1\n-2\n+two\n 3\n 4\n 5\n" +
+				"@@ -16,5 +16,4 @@\n 16\n 17\n 18\n-19\n 20\n",
+		},
+		{
+			name: "joined hunks",
+			old:  lines(1, 10),
+			new:  strings.Replace(strings.Replace(lines(1, 10), "2\n", "two\n", 1), "8\n", "eight\n", 1),
+			want: "--- old\n+++ new\n" +
+				"@@ -1,10 +1,10 @@\n 1\n-2\n+two\n 3\n 4\n 5\n 6\n 7\n-8\n+eight\n 9\n 10\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Unified("old", "new", tt.old, tt.new); got != tt.want {
+				t.Errorf("Unified() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestDiffApplies checks that the edits of diff turn the old lines into
+// the new ones for texts with repeated lines.
+func TestDiffApplies(t *testing.T) {
+	pairs := [][2]string{
+		{"a\nb\nc\na\nb\nb\na\n", "c\nb\na\nb\na\nc\n"},
+		{"x\nx\nx\n", "y\nx\ny\nx\n"},
+		{"", "a\n"},
+		{"a\n", ""},
+	}
+	for _, p := range pairs {
+		var old, new strings.Builder
+		for _, e := range diff(splitLines(p[0]), splitLines(p[1])) {
+			if e.kind != '+' {
+				old.WriteString(e.line)
+			}
+			if e.kind != '-' {
+				new.WriteString(e.line)
+			}
+		}
+		if old.String() != p[0] || new.String() != p[1] {
+			t.Errorf("diff(%q, %q) rebuilds %q, %q", p[0], p[1], old.String(), new.String())
+		}
+	}
+}
+
+// lines returns the numbers from first to last, one per line.
+func lines(first, last int) string {
+	var b strings.Builder
+	for i := first; i <= last; i++ {
+		b.WriteString(strconv.Itoa(i) + "\n")
+	}
+	return b.String()
+}
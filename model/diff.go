@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package model
+
+import (
+	"encoding/json"
+	"slices"
+)
+
+// TypeDiff lists the named types that differ between two models.
+// All name lists are sorted.
+type TypeDiff struct {
+	// Added holds types present only in the newer model.
+	Added []string
+
+	// Changed holds types present in both models with different definitions.
+	Changed []string
+
+	// Removed holds types present only in the older model.
+	Removed []string
+}
+
+// Diff compares the structures, enumerations, and type aliases of old and
+// cur by name. Source line numbers are ignored, so moving a definition
+// within metaModel.json does not count as a change.
+func Diff(old, cur *Model) *TypeDiff {
+	before := namedTypes(old)
+	after := namedTypes(cur)
+
+	d := &TypeDiff{}
+	for name, def := range after {
+		prev, ok := before[name]
+		switch {
+		case !ok:
+			d.Added = append(d.Added, name)
+		case prev != def:
+			d.Changed = append(d.Changed, name)
+		}
+	}
+	for name := range before {
+		if _, ok := after[name]; !ok {
+			d.Removed = append(d.Removed, name)
+		}
+	}
+
+	slices.Sort(d.Added)
+	slices.Sort(d.Changed)
+	slices.Sort(d.Removed)
+	return d
+}
+
+// Types returns the added and changed type names, sorted. These are the
+// types a newer model introduces or redefines.
+func (d *TypeDiff) Types() []string {
+	names := slices.Concat(d.Added, d.Changed)
+	slices.Sort(names)
+	return names
+}
+
+// namedTypes maps each named type in m to a canonical encoding of its
+// definition. The kind is part of the encoding, so a structure that becomes
+// a type alias counts as changed.
+func namedTypes(m *Model) map[string]string {
+	defs := make(map[string]string)
+	for _, s := range m.Structures {
+		defs[s.Name] = "structure:" + canonicalJSON(s)
+	}
+	for _, e := range m.Enumerations {
+		defs[e.Name] = "enumeration:" + canonicalJSON(e)
+	}
+	for _, a := range m.TypeAliases {
+		defs[a.Name] = "typeAlias:" + canonicalJSON(a)
+	}
+	return defs
+}
+
+// canonicalJSON encodes v as JSON with object keys sorted and every "line"
+// field removed.
+func canonicalJSON(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	var generic any
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return ""
+	}
+	data, err = json.Marshal(stripLines(generic))
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+func stripLines(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		delete(v, "line")
+		for k, child := range v {
+			v[k] = stripLines(child)
+		}
+	case []any:
+		for i, child := range v {
+			v[i] = stripLines(child)
+		}
+	}
+	return v
+}
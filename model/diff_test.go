@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	old := &Model{
+		Structures: []*Structure{
+			{Name: "Position", Line: 10, Properties: []Property{
+				{Name: "line", Type: &Type{Kind: "base", Name: "uinteger", Line: 11}},
+			}},
+			{Name: "Range", Properties: []Property{
+				{Name: "start", Type: &Type{Kind: "reference", Name: "Position"}},
+			}},
+			{Name: "Gone"},
+		},
+		Enumerations: []*Enumeration{
+			{Name: "Kind", Type: &Type{Kind: "base", Name: "string"}, Values: []EnumValue{{Name: "A", Value: "a"}}},
+		},
+	}
+	cur := &Model{
+		Structures: []*Structure{
+			// Same definition on different lines: unchanged.
+			{Name: "Position", Line: 20, Properties: []Property{
+				{Name: "line", Type: &Type{Kind: "base", Name: "uinteger", Line: 21}},
+			}},
+			{Name: "Range", Properties: []Property{
+				{Name: "start", Type: &Type{Kind: "reference", Name: "Position"}},
+				{Name: "end", Type: &Type{Kind: "reference", Name: "Position"}},
+			}},
+			{Name: "InlayHint"},
+		},
+		TypeAliases: []*TypeAlias{
+			// Enumeration turned into a type alias: changed.
+			{Name: "Kind", Type: &Type{Kind: "base", Name: "string"}},
+		},
+	}
+
+	got := Diff(old, cur)
+	want := &TypeDiff{
+		Added:   []string{"InlayHint"},
+		Changed: []string{"Kind", "Range"},
+		Removed: []string{"Gone"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Diff() = %+v, want %+v", got, want)
+	}
+	if types, wantTypes := got.Types(), []string{"InlayHint", "Kind", "Range"}; !reflect.DeepEqual(types, wantTypes) {
+		t.Errorf("Types() = %v, want %v", types, wantTypes)
+	}
+}
+
+func TestDiffIdentical(t *testing.T) {
+	m := &Model{Structures: []*Structure{{Name: "Position"}}}
+	if got := Diff(m, m); len(got.Types()) != 0 || len(got.Removed) != 0 {
+		t.Errorf("Diff(m, m) = %+v, want no differences", got)
+	}
+}
@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+// Use of this source code is governed by a MIT-style license
+// that can be found in the LICENSE file.
+
+package model
+
+import "fmt"
+
+// Merge combines models into one, such as the LSP specification and a
+// server's proprietary extensions described in a metaModel of their own.
+// Requests and notifications are merged by method and named types by
+// name; a definition in a later model replaces an earlier one in place,
+// and new definitions are appended. The metadata is the first model's.
+//
+// A name defined as different kinds, such as a structure in one model and
+// a type alias in another, or a method that is a request in one model and
+// a notification in another, is an error. The inputs are not modified.
+func Merge(models ...*Model) (*Model, error) {
+	merged := &Model{}
+	if len(models) > 0 {
+		merged.Version = models[0].Version
+		merged.Line = models[0].Line
+	}
+
+	types := make(map[string]string)
+	methods := make(map[string]string)
+	for i, m := range models {
+		for _, def := range []struct {
+			kinds map[string]string
+			kind  string
+			names []string
+		}{
+			{methods, "request", names(m.Requests, func(r *Request) string { return r.Method })},
+			{methods, "notification", names(m.Notifications, func(n *Notification) string { return n.Method })},
+			{types, "structure", names(m.Structures, func(s *Structure) string { return s.Name })},
+			{types, "enumeration", names(m.Enumerations, func(e *Enumeration) string { return e.Name })},
+			{types, "type alias", names(m.TypeAliases, func(a *TypeAlias) string { return a.Name })},
+		} {
+			for _, name := range def.names {
+				if prev, ok := def.kinds[name]; ok && prev != def.kind {
+					return nil, fmt.Errorf("model %d: %s is a %s, but an earlier model defines it as a %s", i+1, name, def.kind, prev)
+				}
+				def.kinds[name] = def.kind
+			}
+		}
+
+		merged.Requests = mergeByName(merged.Requests, m.Requests, func(r *Request) string { return r.Method })
+		merged.Notifications = mergeByName(merged.Notifications, m.Notifications, func(n *Notification) string { return n.Method })
+		merged.Structures = mergeByName(merged.Structures, m.Structures, func(s *Structure) string { return s.Name })
+		merged.Enumerations = mergeByName(merged.Enumerations, m.Enumerations, func(e *Enumeration) string { return e.Name })
+		merged.TypeAliases = mergeByName(merged.TypeAliases, m.TypeAliases, func(a *TypeAlias) string { return a.Name })
+	}
+	return merged, nil
+}
+
+// names returns the name of each definition of defs.
+func names[T any](defs []*T, name func(*T) string) []string {
+	out := make([]string, len(defs))
+	for i, d := range defs {
+		out[i] = name(d)
+	}
+	return out
+}
+
+// mergeByName returns dst with each definition of src replacing the one
+// of the same name, or appended if there is none.
+func mergeByName[T any](dst, src []*T, name func(*T) string) []*T {
+	index := make(map[string]int, len(dst))
+	for i, d := range dst {
+		index[name(d)] = i
+	}
+	for _, d := range src {
+		if i, ok := index[name(d)]; ok {
+			dst[i] = d
+			continue
+		}
+		index[name(d)] = len(dst)
+		dst = append(dst, d)
+	}
+	return dst
+}
@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright 2026 Alberto Cavalcante. All rights reserved.
+
+package model
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMerge(t *testing.T) {
+	base := &Model{
+		Version: Metadata{Version: "3.17.0"},
+		Requests: []*Request{
+			{Method: "initialize"},
+			{Method: "textDocument/hover"},
+		},
+		Notifications: []*Notification{{Method: "initialized"}},
+		Structures: []*Structure{
+			{Name: "Position"},
+			{Name: "Range", Documentation: "base"},
+		},
+		Enumerations: []*Enumeration{{Name: "MarkupKind"}},
+	}
+	ext := &Model{
+		Version:       Metadata{Version: "1.0.0"},
+		Requests:      []*Request{{Method: "textDocument/hover", Documentation: "ext"}, {Method: "acme/lint"}},
+		Notifications: []*Notification{{Method: "acme/progress"}},
+		Structures: []*Structure{
+			{Name: "Range", Documentation: "ext"},
+			{Name: "LintParams"},
+		},
+		TypeAliases: []*TypeAlias{{Name: "LintId"}},
+	}
+
+	got, err := Merge(base, ext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Version.Version != "3.17.0" {
+		t.Errorf("Version = %q, want the first model's 3.17.0", got.Version.Version)
+	}
+
+	methods := func(reqs []*Request) []string {
+		return names(reqs, func(r *Request) string { return r.Method + ":" + r.Documentation })
+	}
+	if want := []string{"initialize:", "textDocument/hover:ext", "acme/lint:"}; !reflect.DeepEqual(methods(got.Requests), want) {
+		t.Errorf("Requests = %v, want %v", methods(got.Requests), want)
+	}
+	if want := []string{"initialized", "acme/progress"}; !reflect.DeepEqual(names(got.Notifications, func(n *Notification) string { return n.Method }), want) {
+		t.Errorf("Notifications = %v, want %v", got.Notifications, want)
+	}
+	structs := names(got.Structures, func(s *Structure) string { return s.Name + ":" + s.Documentation })
+	if want := []string{"Position:", "Range:ext", "LintParams:"}; !reflect.DeepEqual(structs, want) {
+		t.Errorf("Structures = %v, want %v", structs, want)
+	}
+	if len(got.Enumerations) != 1 || len(got.TypeAliases) != 1 {
+		t.Errorf("got %d enumerations and %d type aliases, want 1 and 1", len(got.Enumerations), len(got.TypeAliases))
+	}
+
+	// The inputs are left as they were.
+	if base.Structures[1].Documentation != "base" || len(base.Requests) != 2 {
+		t.Error("Merge modified its first model")
+	}
+}
+
+func TestMergeConflict(t *testing.T) {
+	tests := []struct {
+		name string
+		ext  *Model
+		want string
+	}{
+		{
+			name: "structure and type alias",
+			ext:  &Model{TypeAliases: []*TypeAlias{{Name: "Range"}}},
+			want: "model 2: Range is a type alias, but an earlier model defines it as a structure",
+		},
+		{
+			name: "request and notification",
+			ext:  &Model{Notifications: []*Notification{{Method: "shutdown"}}},
+			want: "model 2: shutdown is a notification, but an earlier model defines it as a request",
+		},
+	}
+	base := &Model{
+		Requests:   []*Request{{Method: "shutdown"}},
+		Structures: []*Structure{{Name: "Range"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Merge(base, tt.ext)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Merge() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
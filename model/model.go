@@ -133,12 +133,16 @@ type Structure struct {
 	// Since indicates when this structure was introduced.
 	Since string `json:"since,omitempty"`
 
+	// Deprecated is the deprecation message, if the structure is deprecated.
+	Deprecated string `json:"deprecated,omitempty"`
+
 	Line int `json:"line,omitempty"`
 }
 
 // Enumeration represents an enum type with named constants.
 type Enumeration struct {
 	Documentation string `json:"documentation,omitempty"`
+	Deprecated    string `json:"deprecated,omitempty"`
 	Name          string `json:"name"`
 	Proposed      bool   `json:"proposed,omitempty"`
 	Since         string `json:"since,omitempty"`
@@ -200,6 +204,8 @@ type Property struct {
 //   - "map": Key and Value contain the map types
 //   - "literal": Value contains a Literal with properties
 //   - "stringLiteral": Value contains the literal string value
+//   - "integerLiteral": Value contains the literal number value
+//   - "booleanLiteral": Value contains the literal boolean value
 //   - "or": Items contains the union member types
 //   - "and": Items contains the intersection member types
 //   - "tuple": Items contains the tuple element types
@@ -209,7 +215,7 @@ type Type struct {
 	Element *Type   `json:"element,omitempty"` // for "array"
 	Name    string  `json:"name,omitempty"`    // for "base", "reference"
 	Key     *Type   `json:"key,omitempty"`     // for "map"
-	Value   any     `json:"value,omitempty"`   // for "map", "stringLiteral", "literal"
+	Value   any     `json:"value,omitempty"`   // for "map", "literal", and the *Literal kinds
 	Line    int     `json:"line,omitempty"`
 }
 
@@ -266,7 +272,7 @@ func (t *Type) UnmarshalJSON(data []byte) error {
 		}
 		t.Value = lit.Value
 
-	case "base", "reference", "array", "and", "or", "tuple", "stringLiteral":
+	case "base", "reference", "array", "and", "or", "tuple", "stringLiteral", "integerLiteral", "booleanLiteral":
 		// These don't need special handling.
 
 	default:
@@ -303,3 +309,25 @@ func (t *Type) NonNullType() *Type {
 	}
 	return nil
 }
+
+// IsLiteralValue reports whether t is a string, integer, or boolean literal
+// type, whose Value is the only value it admits.
+func (t *Type) IsLiteralValue() bool {
+	switch t.Kind {
+	case "stringLiteral", "integerLiteral", "booleanLiteral":
+		return true
+	}
+	return false
+}
+
+// Discriminator returns the first required property of s whose type is a
+// literal value. Unions of such structures, like CreateFile | RenameFile |
+// DeleteFile, are told apart by that property's value.
+func (s *Structure) Discriminator() (Property, bool) {
+	for _, p := range s.Properties {
+		if !p.Optional && p.Type != nil && p.Type.IsLiteralValue() {
+			return p, true
+		}
+	}
+	return Property{}, false
+}
@@ -481,6 +481,49 @@ func TestType_UnmarshalJSON_LiteralValue(t *testing.T) {
 	}
 }
 
+func TestType_UnmarshalJSON_LiteralKinds(t *testing.T) {
+	tests := []struct {
+		input string
+		want  any
+	}{
+		{`{"kind":"stringLiteral","value":"create"}`, "create"},
+		{`{"kind":"integerLiteral","value":2}`, float64(2)},
+		{`{"kind":"booleanLiteral","value":true}`, true},
+	}
+	for _, tt := range tests {
+		var got Type
+		if err := json.Unmarshal([]byte(tt.input), &got); err != nil {
+			t.Fatalf("UnmarshalJSON(%s): %v", tt.input, err)
+		}
+		if !got.IsLiteralValue() {
+			t.Errorf("%s: IsLiteralValue() = false, want true", tt.input)
+		}
+		if got.Value != tt.want {
+			t.Errorf("%s: Value = %#v, want %#v", tt.input, got.Value, tt.want)
+		}
+	}
+}
+
+func TestStructure_Discriminator(t *testing.T) {
+	s := &Structure{
+		Name: "RenameFile",
+		Properties: []Property{
+			{Name: "annotationId", Type: &Type{Kind: "stringLiteral", Value: "x"}, Optional: true},
+			{Name: "oldUri", Type: &Type{Kind: "base", Name: "DocumentUri"}},
+			{Name: "kind", Type: &Type{Kind: "stringLiteral", Value: "rename"}},
+		},
+	}
+	p, ok := s.Discriminator()
+	if !ok || p.Name != "kind" || p.Type.Value != "rename" {
+		t.Errorf("Discriminator() = %+v, %v; want kind = rename", p, ok)
+	}
+
+	plain := &Structure{Name: "Position", Properties: []Property{{Name: "line", Type: &Type{Kind: "base", Name: "uinteger"}}}}
+	if _, ok := plain.Discriminator(); ok {
+		t.Error("Discriminator() found a discriminator on a structure without literal properties")
+	}
+}
+
 func TestModel_UnmarshalJSON(t *testing.T) {
 	input := `{
 		"metaData": {"version": "3.17.0"},